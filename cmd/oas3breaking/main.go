@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grokify/mogo/log/logutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3diff"
+	flags "github.com/jessevdk/go-flags"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+type Options struct {
+	ConfigFile    string `short:"c" long:"config" description:"Breaking rules config JSON file"`
+	Format        string `short:"f" long:"format" description:"Output format: json or markdown" default:"markdown"`
+	AllowBreaking bool   `short:"a" long:"allow-breaking" description:"Exit zero when there are breaking changes"`
+	Args          struct {
+		Base     string `positional-arg-name:"BASE_FILE" required:"1"`
+		Revision string `positional-arg-name:"REVISION_FILE" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func main() {
+	var opts Options
+	_, err := flags.Parse(&opts)
+	if flags.WroteHelp(err) {
+		return
+	}
+	logutil.FatalErr(err)
+
+	cfg := openapi3diff.DefaultBreakingConfig()
+	if len(strings.TrimSpace(opts.ConfigFile)) > 0 {
+		cfg, err = openapi3diff.ReadBreakingConfigFile(opts.ConfigFile)
+		logutil.FatalErr(err)
+	}
+
+	base, err := openapi3.ReadFile(opts.Args.Base, false)
+	logutil.FatalErr(err)
+	revision, err := openapi3.ReadFile(opts.Args.Revision, false)
+	logutil.FatalErr(err)
+
+	report, err := openapi3diff.CheckBreaking(base, revision, &cfg)
+	logutil.FatalErr(err)
+
+	switch strings.ToLower(strings.TrimSpace(opts.Format)) {
+	case FormatJSON:
+		bytes, err := report.MarshalJSONIndent("", "  ")
+		logutil.FatalErr(err)
+		_, err = os.Stdout.Write(append(bytes, '\n'))
+		logutil.FatalErr(err)
+	case FormatMarkdown, "":
+		fmt.Print(report.Markdown())
+	default:
+		logutil.FatalErr(fmt.Errorf("unknown format [%s]", opts.Format))
+	}
+
+	if report.HasBreaking() && !opts.AllowBreaking {
+		os.Exit(1)
+	}
+}
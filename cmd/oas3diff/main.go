@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grokify/mogo/log/logutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3diff"
+	flags "github.com/jessevdk/go-flags"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+type Options struct {
+	Format string `short:"f" long:"format" description:"Output format: json or markdown" default:"markdown"`
+	Args   struct {
+		Base     string `positional-arg-name:"BASE_FILE" required:"1"`
+		Revision string `positional-arg-name:"REVISION_FILE" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func main() {
+	var opts Options
+	_, err := flags.Parse(&opts)
+	if flags.WroteHelp(err) {
+		return
+	}
+	logutil.FatalErr(err)
+
+	base, err := openapi3.ReadFile(opts.Args.Base, false)
+	logutil.FatalErr(err)
+	revision, err := openapi3.ReadFile(opts.Args.Revision, false)
+	logutil.FatalErr(err)
+
+	diff, err := openapi3diff.Compare(base, revision)
+	logutil.FatalErr(err)
+
+	switch strings.ToLower(strings.TrimSpace(opts.Format)) {
+	case FormatJSON:
+		bytes, err := diff.MarshalJSONIndent("", "  ")
+		logutil.FatalErr(err)
+		_, err = os.Stdout.Write(append(bytes, '\n'))
+		logutil.FatalErr(err)
+	case FormatMarkdown, "":
+		fmt.Print(diff.Markdown())
+	default:
+		logutil.FatalErr(fmt.Errorf("unknown format [%s]", opts.Format))
+	}
+}
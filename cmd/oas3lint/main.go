@@ -1,93 +1,258 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"regexp"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/grokify/mogo/fmt/fmtutil"
 	"github.com/grokify/mogo/log/logutil"
-	"github.com/grokify/mogo/os/osutil"
-	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/mogo/log/severity"
 	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintcli"
+	"github.com/grokify/spectrum/openapi3lint/lintreport"
 	"github.com/grokify/spectrum/openapi3lint/lintutil"
 	flags "github.com/jessevdk/go-flags"
 )
 
+const (
+	FormatJSON     = "json"
+	FormatJUnit    = "junit"
+	FormatMarkdown = "markdown"
+	FormatSARIF    = "sarif"
+	FormatText     = "text"
+
+	// ExitCodeViolations is returned when violations at or above `--fail-on` exist.
+	ExitCodeViolations = 1
+)
+
 type Options struct {
-	PolicyFile    string `short:"p" long:"policyfile" description:"Policy File" required:"true"`
-	InputFileOAS3 string `short:"i" long:"inputspec" description:"Input OAS Spec File or Dir" required:"false"`
-	Severity      string `short:"s" long:"severity" description:"Severity level"`
+	PolicyFile     string `short:"p" long:"policyfile" description:"Policy File"`
+	InputFileOAS3  string `short:"i" long:"inputspec" description:"Input OAS Spec File or Dir" required:"false"`
+	Severity       string `short:"s" long:"severity" description:"Severity level" default:"error"`
+	Format         string `short:"f" long:"format" description:"Output format: text, json, junit, markdown or sarif" default:"text"`
+	FailOn         string `long:"fail-on" description:"Exit non-zero when violations at or above this severity exist, e.g. error or warning"`
+	Workers        int    `long:"workers" description:"Number of files to lint in parallel. Defaults to the number of CPUs"`
+	ListRules      bool   `long:"list-rules" description:"List available rules and exit"`
+	Explain        string `long:"explain" description:"Explain a rule and exit"`
+	Fix            bool   `long:"fix" description:"Apply rule fixes and write the corrected spec before linting"`
+	FixDryRun      bool   `long:"fix-dry-run" description:"Report rule fixes without writing the spec"`
+	Baseline       string `long:"baseline" description:"Baseline file of accepted violations. Only new violations are reported"`
+	UpdateBaseline bool   `long:"update-baseline" description:"Write current violations to the baseline file"`
+	Args           struct {
+		Inputs []string `positional-arg-name:"FILE_DIR_OR_GLOB"`
+	} `positional-args:"yes"`
 }
 
 func main() {
 	var opts Options
 	_, err := flags.Parse(&opts)
+	if flags.WroteHelp(err) {
+		return
+	}
 	logutil.FatalErr(err)
-	fmtutil.PrintJSON(opts)
 
-	if 1 == 1 {
-		spec, err := openapi3.ReadFile(opts.InputFileOAS3, false)
+	polCfg, err := lintcli.NewPolicyConfig(opts.PolicyFile)
+	logutil.FatalErr(err)
+
+	if opts.ListRules || len(strings.TrimSpace(opts.Explain)) > 0 {
+		rcs, err := polCfg.AvailableRuleCollections()
 		logutil.FatalErr(err)
-		sm := openapi3.SpecMore{Spec: spec}
-		ont := sm.Ontology()
-		fmtutil.PrintJSON(ont)
-		panic("Z")
+		if opts.ListRules {
+			logutil.FatalErr(listRules(os.Stdout, rcs))
+		} else {
+			logutil.FatalErr(explainRule(os.Stdout, rcs, opts.Explain))
+		}
+		return
 	}
 
-	vsets, err := ValidateSpecFiles(opts.InputFileOAS3, opts.PolicyFile, opts.Severity)
+	if len(strings.TrimSpace(opts.PolicyFile)) == 0 {
+		logutil.FatalErr(errors.New("`--policyfile` is required to lint"))
+	}
+	pol, err := polCfg.Policy()
+	logutil.FatalErr(err)
+
+	inputs := opts.Args.Inputs
+	if len(strings.TrimSpace(opts.InputFileOAS3)) > 0 {
+		inputs = append([]string{opts.InputFileOAS3}, inputs...)
+	}
+	files, err := lintcli.FilesFromInputs(inputs)
 	logutil.FatalErr(err)
 
-	fmtutil.MustPrintJSON(vsets.LocationsByRule())
-	fmtutil.MustPrintJSON(vsets.CountsByRule())
+	if opts.Fix || opts.FixDryRun {
+		logutil.FatalErr(fixFiles(&pol, opts.Severity, files, opts.FixDryRun))
+		if opts.FixDryRun {
+			return
+		}
+	}
 
-	fmt.Println("DONE")
+	if opts.UpdateBaseline && len(strings.TrimSpace(opts.Baseline)) == 0 {
+		logutil.FatalErr(errors.New("`--update-baseline` requires `--baseline`"))
+	} else if len(strings.TrimSpace(opts.Baseline)) > 0 && !opts.UpdateBaseline {
+		bl, err := lintutil.ReadBaselineFile(opts.Baseline)
+		logutil.FatalErr(err)
+		pol.SetBaseline(&bl)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	collectSev, err := collectSeverity(opts.Severity, opts.FailOn)
+	logutil.FatalErr(err)
+	collected, fileErrs, err := pol.ValidateSpecFilesContext(ctx, collectSev, files, opts.Workers)
+	logutil.FatalErr(err)
+	if len(fileErrs) > 0 {
+		logutil.FatalErr(fileErrs)
+	}
+
+	if opts.UpdateBaseline {
+		bl, err := lintutil.NewBaselineFiles(collected, filepath.Dir(opts.Baseline), files)
+		logutil.FatalErr(err)
+		logutil.FatalErr(bl.WriteFile(opts.Baseline, 0600))
+	}
+	vsets, err := collected.FilterSeverity(opts.Severity)
+	logutil.FatalErr(err)
+
+	documents := map[string]string{}
+	documentNames := []string{}
+	for _, file := range files {
+		documents[lintutil.FileDocument(file)] = file
+		documentNames = append(documentNames, lintutil.FileDocument(file))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(opts.Format)) {
+	case FormatSARIF:
+		bytes, err := lintreport.MarshalSARIF(&pol, vsets, "", "", "  ")
+		logutil.FatalErr(err)
+		_, err = os.Stdout.Write(append(bytes, '\n'))
+		logutil.FatalErr(err)
+	case FormatJUnit:
+		bytes, err := lintreport.MarshalJUnit(&pol, vsets, documentNames, "", "  ")
+		logutil.FatalErr(err)
+		_, err = os.Stdout.Write(bytes)
+		logutil.FatalErr(err)
+	case FormatMarkdown:
+		fmt.Print(lintreport.MarkdownSummary(&pol, vsets, true))
+	case FormatJSON:
+		fmtutil.MustPrintJSON(vsets.LocationsByRule())
+		fmtutil.MustPrintJSON(vsets.CountsByRule())
+		if vsets.SuppressedCount() > 0 {
+			fmtutil.MustPrintJSON(map[string]map[string]uint{
+				"suppressed": vsets.SuppressedCountsByRule()})
+		}
+		if vsets.Baseline != nil {
+			fmtutil.MustPrintJSON(map[string]*lintutil.BaselineResult{
+				"baseline": vsets.Baseline})
+		}
+	case FormatText, "":
+		fmt.Print(lintreport.Text(vsets, documents))
+	default:
+		logutil.FatalErr(fmt.Errorf("unknown format [%s]", opts.Format))
+	}
+
+	if len(strings.TrimSpace(opts.FailOn)) > 0 {
+		count, err := collected.CountSeverityInclude(opts.FailOn)
+		logutil.FatalErr(err)
+		if count > 0 {
+			os.Exit(ExitCodeViolations)
+		}
+	}
 }
 
-func ValidateSpecFiles(specFileOrDir string, policyfile, sev string) (*lintutil.PolicyViolationsSets, error) {
-	files, err := filesFromFileOrDir(specFileOrDir)
+// collectSeverity returns the lower of `filterSeverity` and `failOn` so that
+// violations below the reported severity still count towards `--fail-on`.
+func collectSeverity(filterSeverity, failOn string) (string, error) {
+	filterSeverity, err := severity.Parse(filterSeverity)
+	if err != nil || len(strings.TrimSpace(failOn)) == 0 {
+		return filterSeverity, err
+	}
+	failOn, err = severity.Parse(failOn)
 	if err != nil {
-		return nil, err
+		return filterSeverity, err
+	}
+	if failOnLower, err := severity.SeverityInclude(failOn, filterSeverity); err != nil {
+		return filterSeverity, err
+	} else if failOnLower {
+		return failOn, nil
 	}
+	return filterSeverity, nil
+}
 
-	polCfg, err := openapi3lint.NewPolicyConfigFile(policyfile)
-	if err != nil {
-		return nil, err
+// listRules writes the available rules and their descriptions by collection.
+func listRules(w io.Writer, rcs openapi3lint.RuleCollections) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, rc := range rcs {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s\n", rc.Name())
+		for _, ruleName := range rc.RuleNames() {
+			rule, err := rc.Rule(ruleName)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", ruleName, rule.Scope(), openapi3lint.RuleDescription(rule))
+		}
 	}
-	//polCfg.AddRuleCollection(extensions.NewRuleCollectionExtensions())
-	//logutil.FatalErr(fmtutil.PrintJSON(polCfg))
-	//logutil.FatalErr(fmtutil.PrintJSON(polCfg.RuleNames()))
+	return tw.Flush()
+}
 
-	pol, err := polCfg.Policy()
+// explainRule writes the collection, scope and description of a rule.
+func explainRule(w io.Writer, rcs openapi3lint.RuleCollections, ruleName string) error {
+	ruleName = strings.ToLower(strings.TrimSpace(ruleName))
+	rule, rc, err := rcs.Rule(ruleName)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	fmtutil.MustPrintJSON(pol)
-	fmtutil.MustPrintJSON(pol.RuleNames())
-
-	return pol.ValidateSpecFiles(sev, files)
+	desc := openapi3lint.RuleDescription(rule)
+	if len(strings.TrimSpace(desc)) == 0 {
+		desc = "No description available."
+	}
+	_, err = fmt.Fprintf(w, "%s\n\nCollection: %s\nScope:      %s\n\n%s\n",
+		rule.Name(), rc.Name(), rule.Scope(), desc)
+	return err
 }
 
-func filesFromFileOrDir(filename string) ([]string, error) {
-	var files []string
-	if len(filename) > 0 {
-		isDir, err := osutil.IsDir(filename)
+// fixFiles applies policy fixes to each file as source edits and writes the
+// result unless `dryRun` is set. Applied and skipped fixes are reported on stderr so the
+// lint report on stdout is unaffected.
+func fixFiles(pol *openapi3lint.Policy, filterSeverity string, files []string, dryRun bool) error {
+	sev, err := severity.Parse(filterSeverity)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
 		if err != nil {
-			return files, err
+			return err
 		}
-
-		if isDir {
-			entries, err := osutil.ReadDirMore(filename,
-				regexp.MustCompile(`(?i)\.(json|yaml|yml)$`), false, true, false)
-			logutil.FatalErr(err)
-
-			files = osutil.DirEntries(entries).Names(filename, true)
-		} else {
-			files = []string{filename}
+		fixed, res, err := pol.FixSource(data, lintutil.FileDocument(file), sev)
+		if err != nil {
+			return err
+		}
+		bytes, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s\n", string(bytes))
+		if dryRun || len(res.Applied) == 0 {
+			continue
+		}
+		fi, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, fixed, fi.Mode().Perm()); err != nil {
+			return err
 		}
-	} else {
-		files = []string{filename}
 	}
-	return files, nil
+	return nil
 }
 
 /*
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/grokify/mogo/log/logutil"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3lint/lintcli"
+	"github.com/grokify/spectrum/openapi3lint/lintlsp"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// Options are command line options. The server communicates over stdin and
+// stdout, so it is configured by the editor's language client command.
+type Options struct {
+	PolicyFile string `short:"p" long:"policyfile" description:"Policy File" required:"true"`
+	Severity   string `short:"s" long:"severity" description:"Severity level" default:"info"`
+	Stdio      bool   `long:"stdio" description:"Use stdio. This is the default and only transport"`
+}
+
+func main() {
+	var opts Options
+	_, err := flags.Parse(&opts)
+	if flags.WroteHelp(err) {
+		return
+	}
+	logutil.FatalErr(err)
+
+	sev, err := severity.Parse(opts.Severity)
+	logutil.FatalErr(err)
+
+	pol, err := lintcli.NewPolicy(opts.PolicyFile)
+	logutil.FatalErr(err)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = lintlsp.NewServer(pol, sev).Serve(ctx, os.Stdin, os.Stdout)
+	logutil.FatalErr(err)
+}
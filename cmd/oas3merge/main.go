@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/grokify/mogo/log/logutil"
+	"github.com/grokify/spectrum/openapi3"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type Options struct {
+	Output    string `short:"o" long:"output" description:"Output merged spec file. Defaults to stdout"`
+	Conflicts string `short:"c" long:"conflicts" description:"Output conflicts JSON file"`
+	Driver    bool   `short:"d" long:"driver" description:"Git merge driver mode: write the merged spec to the OURS file"`
+	Path      string `short:"p" long:"path" description:"Path of the merged file used to select JSON or YAML output, e.g. git's %P"`
+	Args      struct {
+		Base   string `positional-arg-name:"BASE_FILE" required:"1"`
+		Ours   string `positional-arg-name:"OURS_FILE" required:"1"`
+		Theirs string `positional-arg-name:"THEIRS_FILE" required:"1"`
+	} `positional-args:"yes"`
+}
+
+var (
+	rxJSON = regexp.MustCompile(`(?i)\.json$`)
+	rxYAML = regexp.MustCompile(`(?i)\.ya?ml$`)
+)
+
+func main() {
+	var opts Options
+	_, err := flags.Parse(&opts)
+	if flags.WroteHelp(err) {
+		return
+	}
+	logutil.FatalErr(err)
+
+	output := opts.Output
+	if opts.Driver {
+		output = opts.Args.Ours
+	}
+	formatPath := opts.Path
+	if len(formatPath) == 0 {
+		formatPath = output
+	}
+	bytes, conflicts, err := mergeFiles(opts.Args.Base, opts.Args.Ours, opts.Args.Theirs, formatPath)
+	logutil.FatalErr(err)
+	if len(output) > 0 {
+		logutil.FatalErr(os.WriteFile(output, bytes, 0600))
+	} else {
+		_, err = os.Stdout.Write(bytes)
+		logutil.FatalErr(err)
+	}
+
+	if len(strings.TrimSpace(opts.Conflicts)) > 0 {
+		conflictsBytes, err := json.MarshalIndent(conflicts, "", "  ")
+		logutil.FatalErr(err)
+		logutil.FatalErr(os.WriteFile(opts.Conflicts, conflictsBytes, 0600))
+	}
+	for _, mc := range conflicts {
+		fmt.Fprintf(os.Stderr, "CONFLICT %s\n", mc.Pointer)
+	}
+	if len(conflicts) > 0 {
+		os.Exit(1)
+	}
+}
+
+// mergeFiles merges three spec files and returns the merged spec encoded as
+// YAML or JSON by the extension of `formatPath`. Files are parsed by content,
+// not extension, because git merge drivers receive extension-less temporary
+// files. If `formatPath` has no JSON or YAML extension, the format of the
+// ours file is used.
+func mergeFiles(baseFile, oursFile, theirsFile, formatPath string) ([]byte, openapi3.MergeConflicts, error) {
+	base, _, err := readSpecOrNil(baseFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	ours, oursData, err := readSpecOrNil(oursFile)
+	if err != nil {
+		return nil, nil, err
+	} else if ours == nil {
+		return nil, nil, fmt.Errorf("ours file is empty [%s]", oursFile)
+	}
+	theirs, _, err := readSpecOrNil(theirsFile)
+	if err != nil {
+		return nil, nil, err
+	} else if theirs == nil {
+		return nil, nil, fmt.Errorf("theirs file is empty [%s]", theirsFile)
+	}
+
+	merged, conflicts, err := openapi3.MergeThreeWay(base, ours, theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+	sm := openapi3.SpecMore{Spec: merged}
+	var bytes []byte
+	if isYAML(formatPath, oursData) {
+		bytes, err = sm.MarshalYAML()
+	} else {
+		bytes, err = sm.MarshalJSON("", "  ")
+	}
+	return bytes, conflicts, err
+}
+
+// readSpecOrNil parses a JSON or YAML spec file by content. It returns nil for
+// an empty file, which git supplies as the base when there is no common
+// ancestor.
+func readSpecOrNil(filename string) (*openapi3.Spec, []byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	} else if len(bytes.TrimSpace(data)) == 0 {
+		return nil, data, nil
+	}
+	spec, err := openapi3.Parse(data)
+	if err != nil {
+		return nil, data, fmt.Errorf("cannot parse spec [%s]: %w", filename, err)
+	}
+	return spec, data, nil
+}
+
+// isYAML returns whether output is YAML by the extension of `formatPath`, or
+// by the content of `data` if the extension is not JSON or YAML.
+func isYAML(formatPath string, data []byte) bool {
+	if rxYAML.MatchString(formatPath) {
+		return true
+	} else if rxJSON.MatchString(formatPath) {
+		return false
+	}
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] != '{'
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+)
+
+const (
+	testMergeBase = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+`
+	testMergeOurs = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+paths: {}
+`
+	testMergeOursJSON = `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1.0.0"}, "tags": [{"name": "pets"}], "paths": {}}`
+	testMergeTheirs   = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: stores
+paths: {}
+`
+)
+
+var mergeFilesTests = []struct {
+	base       string
+	ours       string
+	formatPath string
+	yaml       bool
+}{
+	{testMergeBase, testMergeOurs, "", true},
+	{testMergeBase, testMergeOurs, "openapi.json", false},
+	{"", testMergeOursJSON, "", false},
+	{"", testMergeOursJSON, "spec/openapi.yml", true},
+}
+
+// TestMergeFiles ensures extension-less files, as supplied by git merge
+// drivers, are parsed by content and the output format follows the format
+// path or, without a JSON or YAML extension, the ours file.
+func TestMergeFiles(t *testing.T) {
+	dir := t.TempDir()
+	for i, tt := range mergeFilesTests {
+		files := []string{}
+		for j, data := range []string{tt.base, tt.ours, testMergeTheirs} {
+			file := filepath.Join(dir, fmt.Sprintf(".merge_file_%d%d", i, j))
+			if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+				t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+			}
+			files = append(files, file)
+		}
+		data, conflicts, err := mergeFiles(files[0], files[1], files[2], tt.formatPath)
+		if err != nil {
+			t.Fatalf("mergeFiles() Error [%s]", err.Error())
+		}
+		if len(conflicts) != 0 {
+			t.Errorf("mergeFiles() Mismatch: want [0] conflicts, got [%d]", len(conflicts))
+		}
+		if isJSON := strings.HasPrefix(string(data), "{"); isJSON == tt.yaml {
+			t.Errorf("mergeFiles() Mismatch: format path [%s] want yaml [%v], got yaml [%v]",
+				tt.formatPath, tt.yaml, !isJSON)
+		}
+		merged, err := openapi3.Parse(data)
+		if err != nil {
+			t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+		}
+		tags := []string{}
+		for _, tag := range merged.Tags {
+			tags = append(tags, tag.Name)
+		}
+		if strings.Join(tags, ",") != "pets,stores" {
+			t.Errorf("mergeFiles() Mismatch: want tags [pets,stores], got [%s]", strings.Join(tags, ","))
+		}
+	}
+}
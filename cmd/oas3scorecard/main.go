@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grokify/mogo/log/logutil"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintcli"
+	"github.com/grokify/spectrum/openapi3lint/scorecard"
+	flags "github.com/jessevdk/go-flags"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+type Options struct {
+	PolicyFile  string `short:"p" long:"policyfile" description:"Policy File. If not set, the lint category is not scored"`
+	WeightsFile string `short:"w" long:"weights" description:"Scorecard weights JSON file"`
+	Severity    string `short:"s" long:"severity" description:"Lint severity level" default:"warning"`
+	Format      string `short:"f" long:"format" description:"Output format: json or markdown" default:"markdown"`
+	XLSXFile    string `short:"x" long:"xlsx" description:"Output XLSX file"`
+	Args        struct {
+		Inputs []string `positional-arg-name:"FILE_DIR_OR_GLOB" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func main() {
+	var opts Options
+	_, err := flags.Parse(&opts)
+	if flags.WroteHelp(err) {
+		return
+	}
+	logutil.FatalErr(err)
+
+	weights := scorecard.DefaultWeights()
+	if len(strings.TrimSpace(opts.WeightsFile)) > 0 {
+		weights, err = scorecard.ReadWeightsFile(opts.WeightsFile)
+		logutil.FatalErr(err)
+	}
+
+	var pol *openapi3lint.Policy
+	if len(strings.TrimSpace(opts.PolicyFile)) > 0 {
+		polTry, err := lintcli.NewPolicy(opts.PolicyFile)
+		logutil.FatalErr(err)
+		pol = &polTry
+	}
+
+	files, err := lintcli.FilesFromInputs(opts.Args.Inputs)
+	logutil.FatalErr(err)
+
+	scs, err := scorecard.NewScorecardsFiles(pol, opts.Severity, files, weights)
+	logutil.FatalErr(err)
+
+	if len(strings.TrimSpace(opts.XLSXFile)) > 0 {
+		logutil.FatalErr(scs.WriteXLSX(opts.XLSXFile))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(opts.Format)) {
+	case FormatJSON:
+		bytes, err := scs.MarshalJSONIndent("", "  ")
+		logutil.FatalErr(err)
+		_, err = os.Stdout.Write(append(bytes, '\n'))
+		logutil.FatalErr(err)
+	case FormatMarkdown, "":
+		fmt.Print(scs.Markdown())
+	default:
+		logutil.FatalErr(fmt.Errorf("unknown format [%s]", opts.Format))
+	}
+}
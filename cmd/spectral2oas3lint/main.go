@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grokify/mogo/fmt/fmtutil"
+	"github.com/grokify/spectrum/openapi3lint/lintspectral"
+	flags "github.com/jessevdk/go-flags"
+	"sigs.k8s.io/yaml"
+)
+
+// install: go get github.com/grokify/spectrum/cmd/spectral2oas3lint
+
+type Options struct {
+	RulesetFile string `short:"i" long:"input" description:"Input Spectral ruleset filepath" required:"true"`
+	PolicyFile  string `short:"o" long:"output" description:"Output policy filepath (.json, .yaml or .yml)" required:"true"`
+}
+
+func main() {
+	opts := Options{}
+	_, err := flags.Parse(&opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rs, err := lintspectral.ReadRulesetFile(strings.TrimSpace(opts.RulesetFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+	polCfg, rpt := lintspectral.Convert(rs)
+
+	bytes, err := json.MarshalIndent(polCfg, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	ext := strings.ToLower(filepath.Ext(opts.PolicyFile))
+	if ext == ".yaml" || ext == ".yml" {
+		bytes, err = yaml.JSONToYAML(bytes)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	err = os.WriteFile(strings.TrimSpace(opts.PolicyFile), bytes, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmtutil.MustPrintJSON(rpt)
+	fmt.Printf("WROTE [%v]\n", opts.PolicyFile)
+
+	fmt.Println("DONE")
+}
@@ -133,3 +133,55 @@ func SpecTagGroups(spec *openapi3.Spec) (TagGroupSet, error) {
 	sm.Spec.ExtensionProps.Extensions[XTagGroupsPropertyName] = tagGroups
 	return tgs, nil
 }
+
+// SpecRenameTag renames a tag in the `x-tag-groups` of a spec and returns
+// whether any tag group was changed. Tag group properties other than `tags`
+// are preserved as read.
+func SpecRenameTag(spec *openapi3.Spec, oldName, newName string) (bool, error) {
+	iface, ok := spec.ExtensionProps.Extensions[XTagGroupsPropertyName]
+	if !ok {
+		return false, nil
+	}
+	renamed := false
+	if tagGroups, ok := iface.([]TagGroup); ok {
+		for i := range tagGroups {
+			for j, tagName := range tagGroups[i].Tags {
+				if tagName == oldName {
+					tagGroups[i].Tags[j] = newName
+					renamed = true
+				}
+			}
+		}
+		return renamed, nil
+	}
+
+	rawMessage, ok := iface.(json.RawMessage)
+	if !ok {
+		return false, fmt.Errorf("E_TAG_GROUPS_TYPE [%T]", iface)
+	}
+	tagGroups := []map[string]interface{}{}
+	if err := json.Unmarshal(rawMessage, &tagGroups); err != nil {
+		return false, err
+	}
+	for _, tg := range tagGroups {
+		tagNames, ok := tg["tags"].([]interface{})
+		if !ok {
+			continue
+		}
+		for j, tagName := range tagNames {
+			if tagName == oldName {
+				tagNames[j] = newName
+				renamed = true
+			}
+		}
+	}
+	if !renamed {
+		return false, nil
+	}
+	bytes, err := json.Marshal(tagGroups)
+	if err != nil {
+		return false, err
+	}
+	spec.ExtensionProps.Extensions[XTagGroupsPropertyName] = json.RawMessage(bytes)
+	return true, nil
+}
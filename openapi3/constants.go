@@ -10,7 +10,9 @@ const (
 	FormatDateTime = "date-time"
 	FormatInt32    = "int32"
 	FormatInt64    = "int64"
+	FormatPassword = "password"
 
+	PropertyDescription = "description"
 	PropertyOperationID = "operationId"
 	PropertySummary     = "summary"
 	PropertyTags        = "tags"
@@ -18,4 +20,11 @@ const (
 	InHeader = "header"
 	InQuery  = "query"
 	InCookie = "cookie"
+
+	SecuritySchemeTypeAPIKey        = "apiKey"
+	SecuritySchemeTypeHTTP          = "http"
+	SecuritySchemeTypeOAuth2        = "oauth2"
+	SecuritySchemeTypeOpenIDConnect = "openIdConnect"
+	SecuritySchemeHTTPBasic         = "basic"
+	SecuritySchemeHTTPBearer        = "bearer"
 )
@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -8,6 +9,9 @@ import (
 )
 
 const (
+	XChangelog       = "x-changelog"
+	XLintIgnore      = "x-lint-ignore"
+	XStability       = "x-stability"
 	XTagGroups       = "x-tag-groups"
 	XThrottlingGroup = "x-throttling-group"
 )
@@ -44,3 +48,22 @@ func GetExtensionPropString(xprops oas3.ExtensionProps, key string) (string, err
 	// Important to use %s instead of %v.
 	return strings.Trim(fmt.Sprintf("%s", iface), "\""), nil
 }
+
+// GetExtensionPropUnmarshal unmarshals an extension prop value into `v`. Values
+// read from JSON or YAML are stored as `json.RawMessage` while values set via code
+// are marshaled to JSON first. `ok` is false if the key is not present.
+func GetExtensionPropUnmarshal(xprops oas3.ExtensionProps, key string, v interface{}) (bool, error) {
+	iface, ok := xprops.Extensions[key]
+	if !ok {
+		return false, nil
+	}
+	rawMessage, ok := iface.(json.RawMessage)
+	if !ok {
+		bytes, err := json.Marshal(iface)
+		if err != nil {
+			return true, err
+		}
+		rawMessage = bytes
+	}
+	return true, json.Unmarshal(rawMessage, v)
+}
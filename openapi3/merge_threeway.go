@@ -0,0 +1,281 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/grokify/mogo/encoding/jsonpointer"
+)
+
+// MergeConflict is a value changed differently in ours and theirs. `Base`,
+// `Ours` and `Theirs` are nil when the value is not present in that spec.
+type MergeConflict struct {
+	Pointer string      `json:"pointer"`
+	Base    interface{} `json:"base,omitempty"`
+	Ours    interface{} `json:"ours,omitempty"`
+	Theirs  interface{} `json:"theirs,omitempty"`
+}
+
+type MergeConflicts []MergeConflict
+
+// Pointers returns the JSON pointers of the conflicts.
+func (mcs MergeConflicts) Pointers() []string {
+	ptrs := []string{}
+	for _, mc := range mcs {
+		ptrs = append(ptrs, mc.Pointer)
+	}
+	return ptrs
+}
+
+// MergeThreeWay merges the changes from `base` to `ours` and from `base` to
+// `theirs`. Objects such as paths, operations, components and schema
+// properties are merged by key. Parameters are merged by `name` and `in`,
+// tags by `name` and servers by `url`. `required`, `enum` and operation
+// `tags` values are merged as sets. Other arrays are merged as a whole.
+// Values changed differently in ours and theirs are reported as conflicts
+// and the merged spec uses the value from ours. `base` can be nil when there
+// is no common ancestor.
+func MergeThreeWay(base, ours, theirs *Spec) (*Spec, MergeConflicts, error) {
+	if ours == nil || theirs == nil {
+		return nil, nil, ErrSpecNotSet
+	}
+	baseData := map[string]interface{}{}
+	if base != nil {
+		if err := specGenericData(base, &baseData); err != nil {
+			return nil, nil, err
+		}
+	}
+	oursData := map[string]interface{}{}
+	if err := specGenericData(ours, &oursData); err != nil {
+		return nil, nil, err
+	}
+	theirsData := map[string]interface{}{}
+	if err := specGenericData(theirs, &theirsData); err != nil {
+		return nil, nil, err
+	}
+	m := &threeWayMerger{conflicts: MergeConflicts{}}
+	merged := m.mergeObject("#", baseData, oursData, theirsData)
+	bytes, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec := &Spec{}
+	if err := spec.UnmarshalJSON(bytes); err != nil {
+		return nil, nil, err
+	}
+	return spec, m.conflicts, nil
+}
+
+func specGenericData(spec *Spec, v interface{}) error {
+	data, err := spec.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+type threeWayMerger struct {
+	conflicts MergeConflicts
+}
+
+// optValue is a value that may not be present.
+type optValue struct {
+	value   interface{}
+	present bool
+}
+
+func (ov optValue) equal(other optValue) bool {
+	return ov.present == other.present && reflect.DeepEqual(ov.value, other.value)
+}
+
+func (ov optValue) valueOrNil() interface{} {
+	if !ov.present {
+		return nil
+	}
+	return ov.value
+}
+
+func (m *threeWayMerger) mergeValue(pointer, key string, b, o, t optValue) optValue {
+	switch {
+	case o.equal(t):
+		return o
+	case b.equal(o):
+		return t
+	case b.equal(t):
+		return o
+	}
+	if o.present && t.present {
+		oMap, oIsMap := o.value.(map[string]interface{})
+		tMap, tIsMap := t.value.(map[string]interface{})
+		if oIsMap && tIsMap {
+			bMap, _ := b.value.(map[string]interface{})
+			return optValue{value: m.mergeObject(pointer, bMap, oMap, tMap), present: true}
+		}
+		oArr, oIsArr := o.value.([]interface{})
+		tArr, tIsArr := t.value.([]interface{})
+		if oIsArr && tIsArr {
+			bArr, _ := b.value.([]interface{})
+			if merged, ok := m.mergeArray(pointer, key, bArr, oArr, tArr); ok {
+				return optValue{value: merged, present: true}
+			}
+		}
+	}
+	m.conflicts = append(m.conflicts, MergeConflict{
+		Pointer: pointer,
+		Base:    b.valueOrNil(),
+		Ours:    o.valueOrNil(),
+		Theirs:  t.valueOrNil()})
+	return o
+}
+
+func (m *threeWayMerger) mergeObject(pointer string, b, o, t map[string]interface{}) map[string]interface{} {
+	keys := map[string]bool{}
+	for _, obj := range []map[string]interface{}{b, o, t} {
+		for k := range obj {
+			keys[k] = true
+		}
+	}
+	sortedKeys := []string{}
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	merged := map[string]interface{}{}
+	for _, k := range sortedKeys {
+		bv, bOK := b[k]
+		ov, oOK := o[k]
+		tv, tOK := t[k]
+		mv := m.mergeValue(pointer+"/"+jsonpointer.PropertyNameEscape(k), k,
+			optValue{bv, bOK}, optValue{ov, oOK}, optValue{tv, tOK})
+		if mv.present {
+			merged[k] = mv.value
+		}
+	}
+	return merged
+}
+
+// mergeArray merges arrays with items that have a key. `ok` is false if the
+// array items do not have unique keys, in which case the array is merged as
+// a whole.
+func (m *threeWayMerger) mergeArray(pointer, key string, b, o, t []interface{}) ([]interface{}, bool) {
+	keyFunc := arrayItemKeyFunc(key)
+	if keyFunc == nil {
+		return nil, false
+	}
+	bIndex, bKeys, bOK := indexArray(b, keyFunc)
+	oIndex, oKeys, oOK := indexArray(o, keyFunc)
+	tIndex, tKeys, tOK := indexArray(t, keyFunc)
+	if !bOK || !oOK || !tOK {
+		return nil, false
+	}
+	keys := append([]string{}, oKeys...)
+	for _, k := range tKeys {
+		if _, ok := oIndex[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range bKeys {
+		if _, ok := oIndex[k]; !ok {
+			if _, ok := tIndex[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+	merged := []interface{}{}
+	for _, k := range keys {
+		bv, bHas := arrayValue(b, bIndex, k)
+		ov, oHas := arrayValue(o, oIndex, k)
+		tv, tHas := arrayValue(t, tIndex, k)
+		// pointers use the index in ours, then theirs, then base.
+		idx := bIndex[k]
+		if i, ok := oIndex[k]; ok {
+			idx = i
+		} else if i, ok := tIndex[k]; ok {
+			idx = i
+		}
+		mv := m.mergeValue(pointer+"/"+strconv.Itoa(idx), "",
+			optValue{bv, bHas}, optValue{ov, oHas}, optValue{tv, tHas})
+		if mv.present {
+			merged = append(merged, mv.value)
+		}
+	}
+	return merged, true
+}
+
+func arrayValue(arr []interface{}, index map[string]int, key string) (interface{}, bool) {
+	if i, ok := index[key]; ok {
+		return arr[i], true
+	}
+	return nil, false
+}
+
+func indexArray(arr []interface{}, keyFunc func(item interface{}) (string, bool)) (map[string]int, []string, bool) {
+	index := map[string]int{}
+	keys := []string{}
+	for i, item := range arr {
+		k, ok := keyFunc(item)
+		if !ok {
+			return nil, nil, false
+		} else if _, ok := index[k]; ok {
+			return nil, nil, false
+		}
+		index[k] = i
+		keys = append(keys, k)
+	}
+	return index, keys, true
+}
+
+// arrayItemKeyFunc returns the item key function for arrays under a property
+// name, or nil if the array is merged as a whole.
+func arrayItemKeyFunc(key string) func(item interface{}) (string, bool) {
+	switch key {
+	case "parameters":
+		return func(item interface{}) (string, bool) {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				return "", false
+			} else if ref, ok := obj["$ref"].(string); ok {
+				return "$ref " + ref, true
+			}
+			name, nameOK := obj["name"].(string)
+			in, inOK := obj["in"].(string)
+			return in + " " + name, nameOK && inOK
+		}
+	case "tags":
+		return func(item interface{}) (string, bool) {
+			if obj, ok := item.(map[string]interface{}); ok {
+				name, ok := obj["name"].(string)
+				return name, ok
+			}
+			return scalarKey(item)
+		}
+	case "servers":
+		return func(item interface{}) (string, bool) {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			url, ok := obj["url"].(string)
+			return url, ok
+		}
+	case "required", "enum":
+		return scalarKey
+	}
+	return nil
+}
+
+func scalarKey(item interface{}) (string, bool) {
+	switch val := item.(type) {
+	case string, json.Number, bool:
+		return fmt.Sprintf("%T %v", val, val), true
+	case nil:
+		return "null", true
+	}
+	return "", false
+}
@@ -0,0 +1,210 @@
+package openapi3
+
+import (
+	"strings"
+	"testing"
+)
+
+const testMergeBase = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Pets
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+
+const testMergeOurs = `openapi: 3.0.3
+info:
+  title: Pets Ours
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: stores
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: offset
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Pets
+    post:
+      operationId: createPet
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        tag:
+          type: string
+`
+
+const testMergeTheirs = `openapi: 3.0.3
+info:
+  title: Pets Theirs
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: owners
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets, public]
+      parameters:
+        - name: limit
+          in: query
+          description: Maximum results
+          schema:
+            type: integer
+        - name: sort
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Pets
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        '200':
+          description: Owners
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, age]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        age:
+          type: integer
+`
+
+// TestMergeThreeWay ensures non-conflicting changes from ours and theirs are
+// merged by operation, parameter, schema property and tag, and that
+// conflicting changes are reported.
+func TestMergeThreeWay(t *testing.T) {
+	specs := []*Spec{}
+	for _, data := range []string{testMergeBase, testMergeOurs, testMergeTheirs} {
+		spec, err := Parse([]byte(data))
+		if err != nil {
+			t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+		}
+		specs = append(specs, spec)
+	}
+	merged, conflicts, err := MergeThreeWay(specs[0], specs[1], specs[2])
+	if err != nil {
+		t.Fatalf("openapi3.MergeThreeWay() Error [%s]", err.Error())
+	}
+	if len(conflicts) != 1 || conflicts[0].Pointer != "#/info/title" ||
+		conflicts[0].Ours != "Pets Ours" || conflicts[0].Theirs != "Pets Theirs" || conflicts[0].Base != "Pets" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want conflict [#/info/title], got [%v]", conflicts)
+	}
+	if merged.Info.Title != "Pets Ours" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want title [Pets Ours], got [%s]", merged.Info.Title)
+	}
+	tags := []string{}
+	for _, tag := range merged.Tags {
+		tags = append(tags, tag.Name)
+	}
+	if strings.Join(tags, ",") != "pets,stores,owners" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want tags [pets,stores,owners], got [%s]", strings.Join(tags, ","))
+	}
+	listPets := merged.Paths["/pets"].Get
+	params := []string{}
+	for _, paramRef := range listPets.Parameters {
+		params = append(params, paramRef.Value.Name)
+	}
+	if strings.Join(params, ",") != "limit,offset,sort" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want parameters [limit,offset,sort], got [%s]", strings.Join(params, ","))
+	}
+	if listPets.Parameters[0].Value.Description != "Maximum results" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want parameter description from theirs, got [%s]",
+			listPets.Parameters[0].Value.Description)
+	}
+	if strings.Join(listPets.Tags, ",") != "pets,public" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want operation tags [pets,public], got [%s]", strings.Join(listPets.Tags, ","))
+	}
+	if merged.Paths["/pets"].Post == nil || merged.Paths["/owners"] == nil {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want operations [POST /pets] and [GET /owners]")
+	}
+	pet := merged.Components.Schemas["Pet"].Value
+	for _, propName := range []string{"id", "name", "tag", "age"} {
+		if _, ok := pet.Properties[propName]; !ok {
+			t.Errorf("openapi3.MergeThreeWay() Mismatch: want schema property [%s]", propName)
+		}
+	}
+	if strings.Join(pet.Required, ",") != "id,age" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want required [id,age], got [%s]", strings.Join(pet.Required, ","))
+	}
+}
+
+// TestMergeThreeWayRemoveModify ensures removing an object in one spec and
+// modifying it in the other is a conflict.
+func TestMergeThreeWayRemoveModify(t *testing.T) {
+	base, err := Parse([]byte(testMergeBase))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	ours, err := Parse([]byte(testMergeBase))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	theirs, err := Parse([]byte(testMergeTheirs))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	delete(ours.Components.Schemas["Pet"].Value.Properties, "name")
+	ours.Paths["/pets"].Get.Parameters = nil
+	_, conflicts, err := MergeThreeWay(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("openapi3.MergeThreeWay() Error [%s]", err.Error())
+	}
+	if got := strings.Join(conflicts.Pointers(), ","); got != "#/paths/~1pets/get/parameters" {
+		t.Errorf("openapi3.MergeThreeWay() Mismatch: want conflicts [#/paths/~1pets/get/parameters], got [%s]", got)
+	}
+}
@@ -124,6 +124,20 @@ func (om *OperationMore) ResponseMediaTypes() []string {
 	return mediaTypes
 }
 
+// ResponseStatusCodes returns a sorted slice of response status codes,
+// including `default` when present.
+func (om *OperationMore) ResponseStatusCodes() []string {
+	statusCodes := []string{}
+	if om.Operation == nil {
+		return statusCodes
+	}
+	for statusCode := range om.Operation.Responses {
+		statusCodes = append(statusCodes, strings.TrimSpace(statusCode))
+	}
+	sort.Strings(statusCodes)
+	return statusCodes
+}
+
 // JSONPointers returns a `map[string][]string` where the keys
 // are JSON pointers and the value slice is a slice of locations.
 func (om *OperationMore) JSONPointers() map[string][]string {
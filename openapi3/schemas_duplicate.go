@@ -0,0 +1,262 @@
+package openapi3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grokify/gocharts/v2/data/table"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+)
+
+// SchemaCanonicalOpts configures schema canonicalization. `IgnoreDescriptions`
+// removes `title` and `description` and `IgnoreExamples` removes `example` and
+// `examples` so that near-equivalent schemas have the same hash.
+type SchemaCanonicalOpts struct {
+	IgnoreDescriptions bool
+	IgnoreExamples     bool
+}
+
+// schemaKeysSchema are schema keywords with a schema value.
+var schemaKeysSchema = map[string]int{"additionalProperties": 1, "items": 1, "not": 1}
+
+// schemaKeysSchemas are schema keywords with an array of schemas value.
+var schemaKeysSchemas = map[string]int{"allOf": 1, "anyOf": 1, "oneOf": 1}
+
+// SchemaCanonicalizer returns canonical forms and hashes of component schemas.
+// Child schemas in a canonical form are replaced by their hashes and local
+// `$ref` references to `#/components/schemas` are replaced by the hash of the
+// referenced schema, so schemas that differ only in names, or in whether a
+// child schema is inline or referenced, have the same hash. Recursive
+// references are replaced by their depth in the reference chain. Hashes of
+// referenced schemas are memoized so each schema is hashed once unless it is
+// part of a reference cycle.
+type SchemaCanonicalizer struct {
+	opts    SchemaCanonicalOpts
+	schemas map[string]interface{}
+	hashes  map[string]string
+}
+
+// NewSchemaCanonicalizer returns a canonicalizer for the component schemas of a spec.
+func NewSchemaCanonicalizer(spec *Spec, opts *SchemaCanonicalOpts) (*SchemaCanonicalizer, error) {
+	if spec == nil {
+		return nil, ErrSpecNotSet
+	}
+	sc := &SchemaCanonicalizer{
+		schemas: map[string]interface{}{},
+		hashes:  map[string]string{}}
+	if opts != nil {
+		sc.opts = *opts
+	}
+	for name, schRef := range spec.Components.Schemas {
+		if schRef == nil {
+			continue
+		}
+		bytes, err := json.Marshal(schRef)
+		if err != nil {
+			return nil, err
+		}
+		var raw interface{}
+		if err := json.Unmarshal(bytes, &raw); err != nil {
+			return nil, err
+		}
+		sc.schemas[name] = raw
+	}
+	return sc, nil
+}
+
+// Canonical returns the canonical form of a component schema.
+func (sc *SchemaCanonicalizer) Canonical(schemaName string) (interface{}, error) {
+	raw, ok := sc.schemas[schemaName]
+	if !ok {
+		return nil, fmt.Errorf("schema [%s] not found", schemaName)
+	}
+	canon, _ := sc.canonical(raw, []string{schemaName})
+	return canon, nil
+}
+
+// Hash returns the SHA-256 hex hash of the canonical form of a component schema.
+func (sc *SchemaCanonicalizer) Hash(schemaName string) (string, error) {
+	raw, ok := sc.schemas[schemaName]
+	if !ok {
+		return "", fmt.Errorf("schema [%s] not found", schemaName)
+	}
+	hash, _ := sc.hash(raw, []string{schemaName})
+	return hash, nil
+}
+
+// hash returns the hash of a raw schema. `stack` is the chain of component
+// schema names being resolved, used to detect recursive references. `outer` is
+// the lowest `stack` index referenced by a recursive reference within the
+// schema, or `len(stack)` if there is none.
+func (sc *SchemaCanonicalizer) hash(raw interface{}, stack []string) (hash string, outer int) {
+	if obj, ok := raw.(map[string]interface{}); ok {
+		if ref, ok := obj["$ref"].(string); ok && strings.HasPrefix(ref, PointerComponentsSchemas+"/") {
+			name := jsonpointer.PropertyNameUnescape(strings.TrimPrefix(ref, PointerComponentsSchemas+"/"))
+			if target, ok := sc.schemas[name]; ok {
+				return sc.hashRef(name, target, stack)
+			}
+		}
+	}
+	canon, outer := sc.canonical(raw, stack)
+	// canonical forms are decoded JSON values, which always encode.
+	bytes, _ := json.Marshal(canon)
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:]), outer
+}
+
+// hashRef returns the hash of the component schema `name` referenced from the
+// end of `stack`. Hashes are memoized unless they depend on schemas earlier in
+// `stack` through a recursive reference.
+func (sc *SchemaCanonicalizer) hashRef(name string, target interface{}, stack []string) (string, int) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == name {
+			hash, _ := sc.hash(map[string]interface{}{"$recursive": len(stack) - 1 - i}, stack)
+			return hash, i
+		}
+	}
+	if hash, ok := sc.hashes[name]; ok {
+		return hash, len(stack)
+	}
+	hash, outer := sc.hash(target, append(append([]string{}, stack...), name))
+	if outer >= len(stack) {
+		sc.hashes[name] = hash
+		outer = len(stack)
+	}
+	return hash, outer
+}
+
+// canonical returns the canonical form of a raw schema with child schemas
+// replaced by their hashes. `stack` and `outer` are as in `hash()`.
+func (sc *SchemaCanonicalizer) canonical(raw interface{}, stack []string) (interface{}, int) {
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw, len(stack)
+	}
+	outer := len(stack)
+	child := func(childRaw interface{}) interface{} {
+		hash, childOuter := sc.hash(childRaw, stack)
+		if childOuter < outer {
+			outer = childOuter
+		}
+		return map[string]interface{}{"$hash": hash}
+	}
+	if ref, ok := obj["$ref"].(string); ok {
+		if !strings.HasPrefix(ref, PointerComponentsSchemas+"/") {
+			return map[string]interface{}{"$ref": ref}, outer
+		}
+		name := jsonpointer.PropertyNameUnescape(strings.TrimPrefix(ref, PointerComponentsSchemas+"/"))
+		if _, ok := sc.schemas[name]; !ok {
+			return map[string]interface{}{"$ref": ref}, outer
+		}
+		return child(raw), outer
+	}
+	out := map[string]interface{}{}
+	for key, val := range obj {
+		switch {
+		case sc.opts.IgnoreDescriptions && (key == "description" || key == "title"):
+			continue
+		case sc.opts.IgnoreExamples && (key == "example" || key == "examples"):
+			continue
+		case key == "properties":
+			props := map[string]interface{}{}
+			if propsRaw, ok := val.(map[string]interface{}); ok {
+				for propName, propRaw := range propsRaw {
+					props[propName] = child(propRaw)
+				}
+			}
+			out[key] = props
+		case key == "required":
+			out[key] = sortedStrings(val)
+		case schemaKeysSchema[key] == 1:
+			if _, ok := val.(map[string]interface{}); ok {
+				out[key] = child(val)
+			} else {
+				out[key] = val
+			}
+		case schemaKeysSchemas[key] == 1:
+			items := []interface{}{}
+			if itemsRaw, ok := val.([]interface{}); ok {
+				for _, itemRaw := range itemsRaw {
+					items = append(items, child(itemRaw))
+				}
+			}
+			out[key] = items
+		default:
+			out[key] = val
+		}
+	}
+	return out, outer
+}
+
+func sortedStrings(val interface{}) interface{} {
+	items, ok := val.([]interface{})
+	if !ok {
+		return val
+	}
+	strs := []string{}
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return val
+		}
+		strs = append(strs, str)
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+// SchemaDuplicateGroup is a set of component schemas with the same canonical hash.
+type SchemaDuplicateGroup struct {
+	Hash        string   `json:"hash"`
+	SchemaNames []string `json:"schemaNames"`
+}
+
+// SchemaDuplicateGroups is a set of duplicate schema groups.
+type SchemaDuplicateGroups []SchemaDuplicateGroup
+
+// SchemaDuplicates returns groups of two or more component schemas that are
+// structurally equivalent. Schema names within a group and the groups are sorted.
+func (sm *SpecMore) SchemaDuplicates(opts *SchemaCanonicalOpts) (SchemaDuplicateGroups, error) {
+	sc, err := NewSchemaCanonicalizer(sm.Spec, opts)
+	if err != nil {
+		return nil, err
+	}
+	byHash := map[string][]string{}
+	for _, name := range sm.SchemaNames() {
+		hash, err := sc.Hash(name)
+		if err != nil {
+			return nil, err
+		}
+		byHash[hash] = append(byHash[hash], name)
+	}
+	groups := SchemaDuplicateGroups{}
+	for hash, names := range byHash {
+		if len(names) > 1 {
+			sort.Strings(names)
+			groups = append(groups, SchemaDuplicateGroup{Hash: hash, SchemaNames: names})
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].SchemaNames[0] < groups[j].SchemaNames[0]
+	})
+	return groups, nil
+}
+
+// Table returns a table with one row per group.
+func (groups SchemaDuplicateGroups) Table() *table.Table {
+	tbl := table.NewTable("Duplicate Schemas")
+	tbl.Columns = []string{"Schema", "Duplicates", "Count", "Hash"}
+	tbl.FormatMap[2] = table.FormatInt
+	for _, group := range groups {
+		tbl.Rows = append(tbl.Rows, []string{
+			group.SchemaNames[0],
+			strings.Join(group.SchemaNames[1:], ", "),
+			fmt.Sprintf("%d", len(group.SchemaNames)),
+			group.Hash})
+	}
+	return &tbl
+}
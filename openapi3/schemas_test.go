@@ -1,6 +1,8 @@
 package openapi3
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -24,3 +26,125 @@ func TestSchemaPointerExpand(t *testing.T) {
 		}
 	}
 }
+
+const testSpecSchemaNamesStatus = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {"/users": {"get": {"responses": {"200": {"description": "OK",
+    "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}}}}},
+  "components": {"schemas": {
+    "User": {"type": "object", "properties": {"account": {"$ref": "#/components/schemas/Account"}}},
+    "Unused": {"type": "object"}}}
+}`
+
+// TestSchemaNamesStatus ensures schema names are split into unreferenced,
+// referenced and missing sets.
+func TestSchemaNamesStatus(t *testing.T) {
+	spec, err := Parse([]byte(testSpecSchemaNamesStatus))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	sm := SpecMore{Spec: spec}
+	noRef, both, refNoSchema, err := sm.SchemaNamesStatus()
+	if err != nil {
+		t.Fatalf("SpecMore.SchemaNamesStatus() Error [%s]", err.Error())
+	}
+	got := strings.Join([]string{strings.Join(noRef, ","), strings.Join(both, ","), strings.Join(refNoSchema, ",")}, ";")
+	if want := "Unused;User;Account"; got != want {
+		t.Errorf("SpecMore.SchemaNamesStatus() Mismatch: want [%s], got [%s]", want, got)
+	}
+}
+
+const testSpecSchemaDuplicates = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {},
+  "components": {"schemas": {
+    "Address": {"type": "object", "description": "Postal address.", "required": ["street", "city"],
+      "properties": {"street": {"type": "string"}, "city": {"type": "string"}}},
+    "Location": {"type": "object", "required": ["city", "street"],
+      "properties": {"city": {"type": "string"}, "street": {"type": "string"}}},
+    "Person": {"type": "object", "properties": {"address": {"$ref": "#/components/schemas/Address"}}},
+    "Customer": {"type": "object", "properties": {"address": {"$ref": "#/components/schemas/Location"}}},
+    "Node": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Node"}}},
+    "Link": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Link"}}}}}
+}`
+
+var schemaDuplicatesTests = []struct {
+	opts *SchemaCanonicalOpts
+	want string
+}{
+	{&SchemaCanonicalOpts{IgnoreDescriptions: true}, "Address,Location;Customer,Person;Link,Node"},
+	{nil, "Link,Node"},
+}
+
+// TestSchemaDuplicates ensures structurally equivalent schemas are grouped with
+// references resolved and descriptions optionally ignored.
+func TestSchemaDuplicates(t *testing.T) {
+	spec, err := Parse([]byte(testSpecSchemaDuplicates))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	sm := SpecMore{Spec: spec}
+	for _, tt := range schemaDuplicatesTests {
+		groups, err := sm.SchemaDuplicates(tt.opts)
+		if err != nil {
+			t.Fatalf("SpecMore.SchemaDuplicates() Error [%s]", err.Error())
+		}
+		got := []string{}
+		for _, group := range groups {
+			got = append(got, strings.Join(group.SchemaNames, ","))
+		}
+		if gotStr := strings.Join(got, ";"); gotStr != tt.want {
+			t.Errorf("SpecMore.SchemaDuplicates() Mismatch: want [%s], got [%s]", tt.want, gotStr)
+		}
+	}
+}
+
+// testSpecSchemaChains returns a spec with two chains of `depth` schemas, `A00`
+// and `B00` onwards, where each schema references the next one twice, and two
+// reference cycles, `CycleA1` and `CycleB1` onwards.
+func testSpecSchemaChains(depth int) string {
+	schemas := []string{
+		`"CycleA1": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/CycleA2"}}}`,
+		`"CycleA2": {"type": "array", "items": {"$ref": "#/components/schemas/CycleA1"}}`,
+		`"CycleB1": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/CycleB2"}}}`,
+		`"CycleB2": {"type": "array", "items": {"$ref": "#/components/schemas/CycleB1"}}`}
+	for _, prefix := range []string{"A", "B"} {
+		for i := 0; i < depth-1; i++ {
+			schemas = append(schemas, fmt.Sprintf(`"%s%02d": {"type": "object", "properties": {`+
+				`"left": {"$ref": "#/components/schemas/%s%02d"}, "right": {"$ref": "#/components/schemas/%s%02d"}}}`,
+				prefix, i, prefix, i+1, prefix, i+1))
+		}
+		schemas = append(schemas, fmt.Sprintf(`"%s%02d": {"type": "string"}`, prefix, depth-1))
+	}
+	return `{"openapi": "3.0.3", "info": {"title": "Test", "version": "1.0.0"}, "paths": {},
+  "components": {"schemas": {` + strings.Join(schemas, ",\n") + `}}}`
+}
+
+// TestSchemaDuplicatesDeepReferences ensures schemas with deep reference chains
+// are hashed without expanding every reference and reference cycles are grouped.
+func TestSchemaDuplicatesDeepReferences(t *testing.T) {
+	depth := 40
+	spec, err := Parse([]byte(testSpecSchemaChains(depth)))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	sm := SpecMore{Spec: spec}
+	groups, err := sm.SchemaDuplicates(nil)
+	if err != nil {
+		t.Fatalf("SpecMore.SchemaDuplicates() Error [%s]", err.Error())
+	}
+	want := []string{}
+	for i := 0; i < depth; i++ {
+		want = append(want, fmt.Sprintf("A%02d,B%02d", i, i))
+	}
+	want = append(want, "CycleA1,CycleB1", "CycleA2,CycleB2")
+	got := []string{}
+	for _, group := range groups {
+		got = append(got, strings.Join(group.SchemaNames, ","))
+	}
+	if strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Errorf("SpecMore.SchemaDuplicates() Mismatch: want [%s], got [%s]", strings.Join(want, ";"), strings.Join(got, ";"))
+	}
+}
@@ -0,0 +1,132 @@
+package openapi3
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// SourcePosition is a 1-based line and column in a source file.
+type SourcePosition struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// SourceIndex resolves JSON pointers in a JSON or YAML document to source
+// positions. It can be used by any code that reports JSON pointers, such as
+// lint violations.
+type SourceIndex struct {
+	File string
+	root *yaml.Node
+}
+
+// NewSourceIndex parses JSON or YAML `data` into a `SourceIndex`.
+func NewSourceIndex(file string, data []byte) (*SourceIndex, error) {
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(data, root); err != nil {
+		return nil, err
+	}
+	return &SourceIndex{File: file, root: root}, nil
+}
+
+// ReadSourceIndexFile reads a JSON or YAML file into a `SourceIndex`.
+func ReadSourceIndexFile(filename string) (*SourceIndex, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewSourceIndex(filename, bytes)
+}
+
+// Position returns the source position for a JSON pointer. The pointer can be a
+// fragment such as `#/paths/~1users/get` or include a document, such as
+// `spec.yaml#/paths/~1users/get`, in which case the document is ignored. Object
+// members resolve to the position of their key. If the pointer cannot be fully
+// resolved, the position of the deepest existing ancestor is returned with
+// `exact` set to `false`, which is useful for violations about missing properties.
+func (idx *SourceIndex) Position(pointer string) (pos SourcePosition, exact bool) {
+	_, _, pos, exact = idx.resolve(pointer)
+	return pos, exact
+}
+
+// ScalarPosition returns the source position, value and style of the scalar at
+// a JSON pointer. If `key` is true, the mapping key for the pointer is returned
+// instead of its value. The position of a quoted scalar is that of its opening
+// quote. `ok` is `false` if the pointer cannot be resolved to a scalar.
+func (idx *SourceIndex) ScalarPosition(pointer string, key bool) (pos SourcePosition, value string, quoted, ok bool) {
+	keyNode, node, _, exact := idx.resolve(pointer)
+	if key {
+		node = keyNode
+	}
+	if !exact || node == nil || node.Kind != yaml.ScalarNode {
+		return SourcePosition{File: idx.File}, "", false, false
+	}
+	return SourcePosition{File: idx.File, Line: node.Line, Column: node.Column}, node.Value,
+		node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0, true
+}
+
+// resolve returns the key node, if the pointer is an object member, and the
+// value node for a pointer along with the position used by `Position()`.
+func (idx *SourceIndex) resolve(pointer string) (keyNode, node *yaml.Node, pos SourcePosition, exact bool) {
+	pos = SourcePosition{File: idx.File}
+	if idx.root == nil {
+		return nil, nil, pos, false
+	}
+	node = idx.root
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, nil, pos, false
+		}
+		node = node.Content[0]
+	}
+	pos.Line, pos.Column = node.Line, node.Column
+
+	if i := strings.Index(pointer, "#"); i >= 0 {
+		pointer = pointer[i+1:]
+	}
+	pointer = strings.TrimPrefix(pointer, "/")
+	if len(pointer) == 0 {
+		return nil, node, pos, true
+	}
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		for node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			found := false
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					keyNode = node.Content[i]
+					pos.Line, pos.Column = keyNode.Line, keyNode.Column
+					node = node.Content[i+1]
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, nil, pos, false
+			}
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil, nil, pos, false
+			}
+			keyNode = nil
+			node = node.Content[i]
+			pos.Line, pos.Column = node.Line, node.Column
+		default:
+			return nil, nil, pos, false
+		}
+	}
+	return keyNode, node, pos, true
+}
+
+func (pos SourcePosition) String() string {
+	return fmt.Sprintf("%s:%d:%d", pos.File, pos.Line, pos.Column)
+}
@@ -0,0 +1,93 @@
+package openapi3
+
+import (
+	"testing"
+)
+
+const testSourceIndexYAML = `openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    get:
+      parameters:
+        - name: userId
+          in: path
+      responses:
+        '200':
+          description: OK
+`
+
+const testSourceIndexJSON = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/users": {
+      "get": {"responses": {"200": {"description": "OK"}}}
+    }
+  }
+}`
+
+var sourceIndexTests = []struct {
+	data    string
+	pointer string
+	line    int
+	column  int
+	exact   bool
+}{
+	{testSourceIndexYAML, "spec.yaml#/paths/~1users~1{userId}/get", 7, 5, true},
+	{testSourceIndexYAML, "#/paths/~1users~1{userId}/get/parameters/0/in", 10, 11, true},
+	{testSourceIndexYAML, "/paths/~1users~1{userId}/get/responses/200/description", 13, 11, true},
+	{testSourceIndexYAML, "#/paths/~1users~1{userId}/get/summary", 7, 5, false},
+	{testSourceIndexYAML, "", 1, 1, true},
+	{testSourceIndexJSON, "#/paths/~1users/get", 5, 7, true},
+	{testSourceIndexJSON, "#/paths/~1users/get/operationId", 5, 7, false},
+}
+
+// TestSourceIndexPosition ensures JSON pointers resolve to YAML and JSON source positions.
+func TestSourceIndexPosition(t *testing.T) {
+	for _, tt := range sourceIndexTests {
+		idx, err := NewSourceIndex("spec", []byte(tt.data))
+		if err != nil {
+			t.Fatalf("NewSourceIndex() Error [%s]", err.Error())
+		}
+		pos, exact := idx.Position(tt.pointer)
+		if pos.Line != tt.line || pos.Column != tt.column || exact != tt.exact {
+			t.Errorf("SourceIndex.Position(\"%s\") Mismatch: want [%d:%d %v], got [%d:%d %v]",
+				tt.pointer, tt.line, tt.column, tt.exact, pos.Line, pos.Column, exact)
+		}
+	}
+}
+
+var sourceIndexScalarTests = []struct {
+	data    string
+	pointer string
+	key     bool
+	line    int
+	column  int
+	value   string
+	quoted  bool
+	ok      bool
+}{
+	{testSourceIndexYAML, "#/paths/~1users~1{userId}/get/parameters/0/name", false, 9, 17, "userId", false, true},
+	{testSourceIndexYAML, "#/paths/~1users~1{userId}", true, 6, 3, "/users/{userId}", false, true},
+	{testSourceIndexYAML, "#/paths/~1users~1{userId}/get/responses/200", true, 12, 9, "200", true, true},
+	{testSourceIndexYAML, "#/paths/~1users~1{userId}/get", false, 0, 0, "", false, false},
+	{testSourceIndexJSON, "#/paths/~1users/get/responses/200/description", false, 5, 52, "OK", true, true},
+}
+
+// TestSourceIndexScalarPosition ensures JSON pointers resolve to scalar keys and values.
+func TestSourceIndexScalarPosition(t *testing.T) {
+	for _, tt := range sourceIndexScalarTests {
+		idx, err := NewSourceIndex("spec", []byte(tt.data))
+		if err != nil {
+			t.Fatalf("NewSourceIndex() Error [%s]", err.Error())
+		}
+		pos, value, quoted, ok := idx.ScalarPosition(tt.pointer, tt.key)
+		if pos.Line != tt.line || pos.Column != tt.column || value != tt.value || quoted != tt.quoted || ok != tt.ok {
+			t.Errorf("SourceIndex.ScalarPosition(\"%s\",%v) Mismatch: want [%d:%d %s %v %v], got [%d:%d %s %v %v]",
+				tt.pointer, tt.key, tt.line, tt.column, tt.value, tt.quoted, tt.ok,
+				pos.Line, pos.Column, value, quoted, ok)
+		}
+	}
+}
@@ -460,13 +460,38 @@ func (sm *SpecMore) SchemaPointers(dedupe bool) ([]string, []string, error) {
 		nil
 }
 
+// SchemaNamesStatus compares schema names under `#/components/schemas` with
+// the schema names referenced by `$ref` and returns the names that are only
+// defined, both defined and referenced, and only referenced.
 func (sm *SpecMore) SchemaNamesStatus() (schemaNoReference, both, referenceNoSchema []string, err error) {
 	haveNames := sm.SchemaNames()
 	_, havePointers, err := sm.SchemaPointers(true)
 	if err != nil {
 		return
 	}
-	schemaNoReference, both, referenceNoSchema = stringsutil.SlicesCompare(haveNames, havePointers)
+	names := map[string]int{}
+	for _, name := range haveNames {
+		names[name] = 1
+	}
+	for _, name := range havePointers {
+		if names[name]&2 == 0 {
+			names[name] += 2
+		}
+	}
+	schemaNoReference, both, referenceNoSchema = []string{}, []string{}, []string{}
+	for name, status := range names {
+		switch status {
+		case 1:
+			schemaNoReference = append(schemaNoReference, name)
+		case 3:
+			both = append(both, name)
+		default:
+			referenceNoSchema = append(referenceNoSchema, name)
+		}
+	}
+	sort.Strings(schemaNoReference)
+	sort.Strings(both)
+	sort.Strings(referenceNoSchema)
 	return
 }
 
@@ -1,6 +1,8 @@
 package openapi3
 
 import (
+	"strings"
+
 	oas3 "github.com/getkin/kin-openapi/openapi3"
 	"github.com/grokify/gocharts/v2/data/histogram"
 )
@@ -27,3 +29,16 @@ func (sm *SpecMore) WriteFileXLSXOperationStatusCodes(filename string) error {
 	hsets := sm.StatusCodesHistogram()
 	return hsets.WriteXLSXMatrix(filename, hsets.Name, "Method", "Path", "", "")
 }
+
+// StatusCodeDefault is the response key used for the default response.
+const StatusCodeDefault = "default"
+
+// StatusCodeIsClass returns true if a response status code key such as `200` or
+// the range `2XX` is in the class identified by its first digit, e.g. `2`.
+func StatusCodeIsClass(statusCode string, class int) bool {
+	statusCode = strings.TrimSpace(statusCode)
+	if len(statusCode) != 3 || class < 1 || class > 5 {
+		return false
+	}
+	return statusCode[0] == byte('0'+class)
+}
@@ -102,3 +102,141 @@ func VisitOperations(spec *Spec, visitOp func(path, method string, op *oas3.Oper
 		VisitOperationsPathItem(path, pathItem, visitOp)
 	}
 }
+
+// VisitSchemas visits each schema under `#/components/schemas`. The JSON pointer
+// supplied does not include a document base.
+func VisitSchemas(spec *Spec, visitSchema func(jsonPointer, schemaName string, schemaRef *oas3.SchemaRef)) {
+	if spec == nil {
+		return
+	}
+	for schemaName, schemaRef := range spec.Components.Schemas {
+		if schemaRef == nil {
+			continue
+		}
+		visitSchema(
+			jsonpointer.PointerSubEscapeAll("#/components/schemas/%s", schemaName),
+			schemaName, schemaRef)
+	}
+}
+
+// VisitSchemaProperties visits each property of schemas under `#/components/schemas`.
+// The JSON pointer supplied does not include a document base.
+func VisitSchemaProperties(spec *Spec, visitProperty func(jsonPointer, schemaName, propName string, propRef *oas3.SchemaRef)) {
+	VisitSchemas(spec, func(schemaPointer, schemaName string, schemaRef *oas3.SchemaRef) {
+		if schemaRef.Value == nil {
+			return
+		}
+		for propName, propRef := range schemaRef.Value.Properties {
+			if propRef == nil {
+				continue
+			}
+			visitProperty(
+				schemaPointer+"/properties/"+jsonpointer.PropertyNameEscape(propName),
+				schemaName, propName, propRef)
+		}
+	})
+}
+
+// VisitParameters visits parameters defined under `#/components/parameters`,
+// path items and operations. Parameters that are `$ref` references are not
+// visited as they are visited at their definition. The JSON pointer supplied
+// does not include a document base.
+func VisitParameters(spec *Spec, visitParam func(jsonPointer string, paramRef *oas3.ParameterRef)) {
+	if spec == nil {
+		return
+	}
+	for paramName, paramRef := range spec.Components.Parameters {
+		if paramRef == nil || len(paramRef.Ref) > 0 {
+			continue
+		}
+		visitParam(fmt.Sprintf(jPtrParamFormat, jsonpointer.PropertyNameEscape(paramName)), paramRef)
+	}
+	for path, pathItem := range spec.Paths {
+		if pathItem == nil {
+			continue
+		}
+		for i, paramRef := range pathItem.Parameters {
+			if paramRef == nil || len(paramRef.Ref) > 0 {
+				continue
+			}
+			visitParam(jsonpointer.PointerSubEscapeAll("#/paths/%s/parameters/%d", path, i), paramRef)
+		}
+	}
+	VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil {
+			return
+		}
+		for i, paramRef := range op.Parameters {
+			if paramRef == nil || len(paramRef.Ref) > 0 {
+				continue
+			}
+			visitParam(jsonpointer.PointerSubEscapeAll(
+				"#/paths/%s/%s/parameters/%d", path, strings.ToLower(method), i), paramRef)
+		}
+	})
+}
+
+// VisitRequestBodies visits request bodies defined under `#/components/requestBodies`
+// and operations. Request bodies that are `$ref` references are not visited as they
+// are visited at their definition. The JSON pointer supplied does not include a
+// document base.
+func VisitRequestBodies(spec *Spec, visitRequestBody func(jsonPointer string, reqBodyRef *oas3.RequestBodyRef)) {
+	if spec == nil {
+		return
+	}
+	for reqBodyName, reqBodyRef := range spec.Components.RequestBodies {
+		if reqBodyRef == nil || len(reqBodyRef.Ref) > 0 {
+			continue
+		}
+		visitRequestBody(jsonpointer.PointerSubEscapeAll("#/components/requestBodies/%s", reqBodyName), reqBodyRef)
+	}
+	VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil || op.RequestBody == nil || len(op.RequestBody.Ref) > 0 {
+			return
+		}
+		visitRequestBody(jsonpointer.PointerSubEscapeAll(
+			"#/paths/%s/%s/requestBody", path, strings.ToLower(method)), op.RequestBody)
+	})
+}
+
+// VisitResponses visits responses defined under `#/components/responses` and
+// operations. `key` is the component name or the operation status code.
+// Responses that are `$ref` references are not visited as they are visited
+// at their definition. The JSON pointer supplied does not include a document base.
+func VisitResponses(spec *Spec, visitResponse func(jsonPointer, key string, respRef *oas3.ResponseRef)) {
+	if spec == nil {
+		return
+	}
+	for respName, respRef := range spec.Components.Responses {
+		if respRef == nil || len(respRef.Ref) > 0 {
+			continue
+		}
+		visitResponse(jsonpointer.PointerSubEscapeAll("#/components/responses/%s", respName), respName, respRef)
+	}
+	VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil {
+			return
+		}
+		for statusCode, respRef := range op.Responses {
+			if respRef == nil || len(respRef.Ref) > 0 {
+				continue
+			}
+			visitResponse(jsonpointer.PointerSubEscapeAll(
+				"#/paths/%s/%s/responses/%s", path, strings.ToLower(method), statusCode), statusCode, respRef)
+		}
+	})
+}
+
+// VisitTags visits tags defined in the top level `#/tags` property. The JSON
+// pointer supplied does not include a document base.
+func VisitTags(spec *Spec, visitTag func(jsonPointer string, tag *oas3.Tag)) {
+	if spec == nil {
+		return
+	}
+	for i, tag := range spec.Tags {
+		if tag == nil {
+			continue
+		}
+		visitTag(fmt.Sprintf("#/tags/%d", i), tag)
+	}
+}
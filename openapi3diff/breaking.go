@@ -0,0 +1,362 @@
+package openapi3diff
+
+import (
+	"encoding/json"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/spectrum/openapi3"
+)
+
+const (
+	ExemptDeprecated   = "deprecated"
+	ExemptOperations   = "exempt-operations"
+	ExemptStability    = openapi3.XStability
+	ExemptUnreferenced = "unreferenced"
+)
+
+// ClassifiedChange is a change with the matching rule and whether it is
+// breaking. `Exempt` is set when the change would otherwise be checked but
+// is in a deprecated or exempt stability object, or in a component schema
+// that is only referenced by exempt operations or is not referenced. `Rule`
+// is set for exempt changes and for rules configured as non-breaking.
+type ClassifiedChange struct {
+	Change
+	Rule     string `json:"rule,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Exempt   string `json:"exempt,omitempty"`
+}
+
+// BreakingReport is the classified set of changes between two specs.
+type BreakingReport struct {
+	BaseVersion     string             `json:"baseVersion,omitempty"`
+	RevisionVersion string             `json:"revisionVersion,omitempty"`
+	Changes         []ClassifiedChange `json:"changes"`
+}
+
+// CheckBreaking compares two specs and classifies each change as breaking or
+// non-breaking. If `cfg` is nil, `DefaultBreakingConfig()` is used. Changes
+// to component schemas are classified for each direction, request or
+// response, in which the schema is used by a checked operation.
+func CheckBreaking(base, revision *openapi3.Spec, cfg *BreakingConfig) (*BreakingReport, error) {
+	diff, err := Compare(base, revision)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		defaultCfg := DefaultBreakingConfig()
+		cfg = &defaultCfg
+	}
+	bc := breakingClassifier{base: base, rev: revision, cfg: cfg}
+	bc.baseUsage = bc.schemaUsage(base)
+	bc.revUsage = bc.schemaUsage(revision)
+	report := &BreakingReport{
+		BaseVersion:     diff.BaseVersion,
+		RevisionVersion: diff.RevisionVersion,
+		Changes:         []ClassifiedChange{}}
+	for _, c := range diff.Changes {
+		report.Changes = append(report.Changes, bc.classify(c))
+	}
+	return report, nil
+}
+
+// Breaking returns the breaking changes.
+func (r *BreakingReport) Breaking() []ClassifiedChange {
+	breaking := []ClassifiedChange{}
+	for _, cc := range r.Changes {
+		if cc.Breaking {
+			breaking = append(breaking, cc)
+		}
+	}
+	return breaking
+}
+
+// HasBreaking returns whether any change is breaking.
+func (r *BreakingReport) HasBreaking() bool {
+	return len(r.Breaking()) > 0
+}
+
+// MarshalJSONIndent returns the indented JSON encoding of the report.
+func (r *BreakingReport) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(r, prefix, indent)
+}
+
+// Markdown returns a Markdown report listing breaking changes with their
+// rules, followed by non-breaking changes.
+func (r *BreakingReport) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# API Breaking Changes\n\n")
+	if len(r.BaseVersion) > 0 || len(r.RevisionVersion) > 0 {
+		sb.WriteString("`" + r.BaseVersion + "` => `" + r.RevisionVersion + "`\n\n")
+	}
+	breaking := r.Breaking()
+	if len(breaking) == 0 {
+		sb.WriteString("No breaking changes.\n\n")
+	} else {
+		sb.WriteString("## Breaking\n\n")
+		for _, cc := range breaking {
+			sb.WriteString("* [" + cc.Rule + "] " + cc.Change.String() + " (`" + cc.Pointer + "`)\n")
+		}
+		sb.WriteString("\n")
+	}
+	if len(r.Changes) > len(breaking) {
+		sb.WriteString("## Non-breaking\n\n")
+		for _, cc := range r.Changes {
+			if cc.Breaking {
+				continue
+			}
+			line := "* " + cc.Change.String() + " (`" + cc.Pointer + "`)"
+			if len(cc.Exempt) > 0 {
+				line += " exempt: " + cc.Exempt
+			}
+			sb.WriteString(line + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// schemaUsage is the use of a component schema by operations. `request`
+// and `response` only include checked operations.
+type schemaUsage struct {
+	used     bool
+	request  bool
+	response bool
+}
+
+type breakingClassifier struct {
+	base      *openapi3.Spec
+	rev       *openapi3.Spec
+	cfg       *BreakingConfig
+	baseUsage map[string]*schemaUsage
+	revUsage  map[string]*schemaUsage
+}
+
+func (bc *breakingClassifier) classify(c Change) ClassifiedChange {
+	cc := ClassifiedChange{Change: c}
+	directions := []string{c.Direction}
+	if len(c.Path) > 0 {
+		cc.Exempt = bc.operationExempt(c.Path, c.Method, c.OperationID)
+	}
+	if len(cc.Exempt) == 0 && c.Deprecated && !bc.cfg.CheckDeprecated {
+		cc.Exempt = ExemptDeprecated
+	}
+	if len(c.Schema) > 0 && len(c.Path) == 0 {
+		directions = []string{}
+		used := false
+		for _, usage := range []*schemaUsage{bc.baseUsage[c.Schema], bc.revUsage[c.Schema]} {
+			if usage == nil {
+				continue
+			}
+			used = used || usage.used
+			if usage.request {
+				directions = append(directions, DirectionRequest)
+			}
+			if usage.response {
+				directions = append(directions, DirectionResponse)
+			}
+		}
+		if len(cc.Exempt) == 0 && !used {
+			cc.Exempt = ExemptUnreferenced
+		} else if len(cc.Exempt) == 0 && len(directions) == 0 {
+			cc.Exempt = ExemptOperations
+		}
+		if len(directions) == 0 {
+			// classify exempt changes in both directions to report the rule.
+			directions = []string{DirectionRequest, DirectionResponse}
+		}
+	}
+	for _, direction := range directions {
+		rule := changeRule(c, direction)
+		if len(rule) == 0 {
+			continue
+		}
+		if len(cc.Rule) == 0 {
+			cc.Rule = rule
+		}
+		if bc.cfg.RuleBreaking(rule) {
+			cc.Rule = rule
+			cc.Breaking = true
+			break
+		}
+	}
+	if len(cc.Rule) == 0 {
+		cc.Exempt = ""
+	} else if len(cc.Exempt) > 0 {
+		cc.Breaking = false
+	}
+	return cc
+}
+
+// operationExempt returns the exemption for an operation, using the base
+// spec operation when present.
+func (bc *breakingClassifier) operationExempt(path, method, operationID string) string {
+	pathItem, op := findOperation(bc.base, path, method, operationID)
+	if op == nil {
+		pathItem, op = findOperation(bc.rev, path, method, operationID)
+	}
+	if op == nil {
+		return ""
+	}
+	return bc.exemption(pathItem, op)
+}
+
+func (bc *breakingClassifier) exemption(pathItem *oas3.PathItem, op *oas3.Operation) string {
+	if op.Deprecated && !bc.cfg.CheckDeprecated {
+		return ExemptDeprecated
+	}
+	stability := openapi3.GetExtensionPropStringOrEmpty(op.ExtensionProps, openapi3.XStability)
+	if len(stability) == 0 && pathItem != nil {
+		stability = openapi3.GetExtensionPropStringOrEmpty(pathItem.ExtensionProps, openapi3.XStability)
+	}
+	if bc.cfg.stabilityExempt(stability) {
+		return ExemptStability + ": " + stability
+	}
+	return ""
+}
+
+func findOperation(spec *openapi3.Spec, path, method, operationID string) (*oas3.PathItem, *oas3.Operation) {
+	if pathItem, ok := spec.Paths[path]; ok && pathItem != nil {
+		if op := pathItem.GetOperation(strings.ToUpper(method)); op != nil {
+			return pathItem, op
+		}
+	}
+	if len(operationID) == 0 {
+		return nil, nil
+	}
+	for _, pathItem := range spec.Paths {
+		if pathItem == nil {
+			continue
+		}
+		for _, op := range pathItem.Operations() {
+			if op != nil && op.OperationID == operationID {
+				return pathItem, op
+			}
+		}
+	}
+	return nil, nil
+}
+
+// schemaUsage returns the use of component schemas by operations, directly
+// or through other component schemas.
+func (bc *breakingClassifier) schemaUsage(spec *openapi3.Spec) map[string]*schemaUsage {
+	usages := map[string]*schemaUsage{}
+	for path, pathItem := range spec.Paths {
+		if pathItem == nil {
+			continue
+		}
+		for method, op := range pathItem.Operations() {
+			if op == nil {
+				continue
+			}
+			oe := opEntry{path: path, method: method, pathItem: pathItem, op: op}
+			checked := len(bc.exemption(pathItem, op)) == 0
+			for _, name := range schemaClosure(spec, operationRequestRefs(spec, oe)) {
+				usage := usageFor(usages, name)
+				usage.used = true
+				usage.request = usage.request || checked
+			}
+			for _, name := range schemaClosure(spec, operationResponseRefs(spec, op)) {
+				usage := usageFor(usages, name)
+				usage.used = true
+				usage.response = usage.response || checked
+			}
+		}
+	}
+	return usages
+}
+
+func usageFor(usages map[string]*schemaUsage, name string) *schemaUsage {
+	usage, ok := usages[name]
+	if !ok {
+		usage = &schemaUsage{}
+		usages[name] = usage
+	}
+	return usage
+}
+
+func operationRequestRefs(spec *openapi3.Spec, oe opEntry) []string {
+	names := []string{}
+	for _, pe := range effectiveParameters(spec, oe) {
+		names = append(names, schemaRefNames(pe.param.Schema)...)
+		names = append(names, contentRefNames(pe.param.Content)...)
+	}
+	if body := resolveRequestBody(spec, oe.op.RequestBody); body != nil {
+		names = append(names, contentRefNames(body.Content)...)
+	}
+	return names
+}
+
+func operationResponseRefs(spec *openapi3.Spec, op *oas3.Operation) []string {
+	names := []string{}
+	for _, respRef := range op.Responses {
+		resp := resolveResponse(spec, respRef)
+		if resp == nil {
+			continue
+		}
+		for _, headerRef := range resp.Headers {
+			if header := resolveHeader(spec, headerRef); header != nil {
+				names = append(names, schemaRefNames(header.Schema)...)
+			}
+		}
+		names = append(names, contentRefNames(resp.Content)...)
+	}
+	return names
+}
+
+func contentRefNames(content oas3.Content) []string {
+	names := []string{}
+	for _, mt := range content {
+		if mt != nil {
+			names = append(names, schemaRefNames(mt.Schema)...)
+		}
+	}
+	return names
+}
+
+// schemaRefNames returns the component schema names referenced by a schema,
+// without following the references.
+func schemaRefNames(schRef *oas3.SchemaRef) []string {
+	if schRef == nil {
+		return []string{}
+	} else if len(schRef.Ref) > 0 {
+		if name, ok := componentName(schRef.Ref, openapi3.PointerComponentsSchemas); ok {
+			return []string{name}
+		}
+		return []string{}
+	} else if schRef.Value == nil {
+		return []string{}
+	}
+	sch := schRef.Value
+	names := []string{}
+	for _, propRef := range sch.Properties {
+		names = append(names, schemaRefNames(propRef)...)
+	}
+	names = append(names, schemaRefNames(sch.Items)...)
+	names = append(names, schemaRefNames(sch.AdditionalProperties)...)
+	names = append(names, schemaRefNames(sch.Not)...)
+	for _, refs := range []oas3.SchemaRefs{sch.AllOf, sch.AnyOf, sch.OneOf} {
+		for _, ref := range refs {
+			names = append(names, schemaRefNames(ref)...)
+		}
+	}
+	return names
+}
+
+// schemaClosure returns the supplied schema names and the component schemas
+// they reference.
+func schemaClosure(spec *openapi3.Spec, names []string) []string {
+	seen := map[string]bool{}
+	closure := []string{}
+	for len(names) > 0 {
+		name := names[0]
+		names = names[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		closure = append(closure, name)
+		names = append(names, schemaRefNames(spec.Components.Schemas[name])...)
+	}
+	return closure
+}
@@ -0,0 +1,288 @@
+package openapi3diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	RuleOperationRemoved         = "operation-removed"
+	RuleParameterRequired        = "parameter-required"
+	RuleParameterRemoved         = "parameter-removed"
+	RuleRequestBodyRequired      = "request-body-required"
+	RuleRequestPropertyRequired  = "request-property-required"
+	RuleRequestPropertyRemoved   = "request-property-removed"
+	RuleResponseRemoved          = "response-removed"
+	RuleResponsePropertyRemoved  = "response-property-removed"
+	RuleResponsePropertyOptional = "response-property-optional"
+	RuleResponseHeaderRemoved    = "response-header-removed"
+	RuleResponseEnumWidened      = "response-enum-widened"
+	RuleMediaTypeRemoved         = "media-type-removed"
+	RuleEnumNarrowed             = "enum-narrowed"
+	RuleTypeChanged              = "type-changed"
+	RuleConstraintNarrowed       = "constraint-narrowed"
+	RuleSecurityAdded            = "security-added"
+	RuleSecurityRemoved          = "security-removed"
+	RuleServerRemoved            = "server-removed"
+)
+
+const StabilityExperimental = "experimental"
+
+// DefaultBreakingRules returns the rules and whether each is breaking by
+// default. Removing request parameters and properties is not breaking by
+// default because servers typically ignore unknown input.
+func DefaultBreakingRules() map[string]bool {
+	return map[string]bool{
+		RuleOperationRemoved:         true,
+		RuleParameterRequired:        true,
+		RuleParameterRemoved:         false,
+		RuleRequestBodyRequired:      true,
+		RuleRequestPropertyRequired:  true,
+		RuleRequestPropertyRemoved:   false,
+		RuleResponseRemoved:          true,
+		RuleResponsePropertyRemoved:  true,
+		RuleResponsePropertyOptional: true,
+		RuleResponseHeaderRemoved:    true,
+		RuleResponseEnumWidened:      true,
+		RuleMediaTypeRemoved:         true,
+		RuleEnumNarrowed:             true,
+		RuleTypeChanged:              true,
+		RuleConstraintNarrowed:       true,
+		RuleSecurityAdded:            true,
+		RuleSecurityRemoved:          true,
+		RuleServerRemoved:            true,
+	}
+}
+
+// BreakingConfig configures breaking change classification. `Rules` sets
+// whether each rule is breaking, overriding `DefaultBreakingRules()`. Changes
+// to operations with an `x-stability` value in `ExemptStability` are never
+// breaking, nor are changes to objects deprecated in the base spec unless
+// `CheckDeprecated` is set.
+type BreakingConfig struct {
+	Rules           map[string]bool `json:"rules,omitempty"`
+	ExemptStability []string        `json:"exemptStability,omitempty"`
+	CheckDeprecated bool            `json:"checkDeprecated,omitempty"`
+}
+
+// DefaultBreakingConfig returns the default rules with `experimental`
+// operations exempt.
+func DefaultBreakingConfig() BreakingConfig {
+	return BreakingConfig{
+		Rules:           DefaultBreakingRules(),
+		ExemptStability: []string{StabilityExperimental}}
+}
+
+// ReadBreakingConfigFile reads a JSON config file. Rules in the file override
+// the default rules and `exemptStability`, if present, replaces the default.
+func ReadBreakingConfigFile(filename string) (BreakingConfig, error) {
+	cfg := DefaultBreakingConfig()
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return cfg, err
+	}
+	fileCfg := BreakingConfig{}
+	if err := json.Unmarshal(bytes, &fileCfg); err != nil {
+		return cfg, err
+	}
+	for rule, breaking := range fileCfg.Rules {
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		if _, ok := cfg.Rules[rule]; !ok {
+			return cfg, fmt.Errorf("unknown breaking rule [%s]", rule)
+		}
+		cfg.Rules[rule] = breaking
+	}
+	if fileCfg.ExemptStability != nil {
+		cfg.ExemptStability = fileCfg.ExemptStability
+	}
+	cfg.CheckDeprecated = fileCfg.CheckDeprecated
+	return cfg, nil
+}
+
+// RuleBreaking returns whether changes matching a rule are breaking.
+func (cfg *BreakingConfig) RuleBreaking(rule string) bool {
+	if breaking, ok := cfg.Rules[rule]; ok {
+		return breaking
+	}
+	return DefaultBreakingRules()[rule]
+}
+
+func (cfg *BreakingConfig) stabilityExempt(stability string) bool {
+	stability = strings.ToLower(strings.TrimSpace(stability))
+	if len(stability) == 0 {
+		return false
+	}
+	for _, exempt := range cfg.ExemptStability {
+		if strings.ToLower(strings.TrimSpace(exempt)) == stability {
+			return true
+		}
+	}
+	return false
+}
+
+// changeRule returns the rule matching a change for schemas used in the
+// supplied direction, or an empty string if no rule matches.
+func changeRule(c Change, direction string) string {
+	switch c.Object {
+	case ObjectOperation:
+		if c.Type == ChangeTypeRemoved || c.Field == "path" {
+			return RuleOperationRemoved
+		}
+	case ObjectParameter:
+		if (c.Type == ChangeTypeAdded && c.Required) || (c.Field == "required" && c.New == true) {
+			return RuleParameterRequired
+		} else if c.Type == ChangeTypeRemoved {
+			return RuleParameterRemoved
+		}
+		return schemaFieldRule(c, DirectionRequest)
+	case ObjectRequestBody:
+		if (c.Type == ChangeTypeAdded && c.Required) || (c.Field == "required" && c.New == true) {
+			return RuleRequestBodyRequired
+		}
+	case ObjectResponse:
+		if c.Type == ChangeTypeRemoved && strings.HasPrefix(c.Name, "2") {
+			return RuleResponseRemoved
+		}
+	case ObjectMediaType:
+		if c.Type == ChangeTypeRemoved {
+			return RuleMediaTypeRemoved
+		}
+	case ObjectHeader:
+		if c.Type == ChangeTypeRemoved {
+			return RuleResponseHeaderRemoved
+		}
+		return schemaFieldRule(c, DirectionResponse)
+	case ObjectSecurity:
+		if c.Type == ChangeTypeRemoved {
+			return RuleSecurityRemoved
+		}
+		oldReqs, _ := c.Old.([]string)
+		newReqs, _ := c.New.([]string)
+		if c.Type == ChangeTypeAdded && allowsAnonymous(oldReqs) && !allowsAnonymous(newReqs) {
+			return RuleSecurityAdded
+		}
+	case ObjectServer:
+		if c.Type == ChangeTypeRemoved {
+			return RuleServerRemoved
+		}
+	case ObjectSchemaProperty:
+		switch {
+		case direction == DirectionRequest &&
+			((c.Type == ChangeTypeAdded && c.Required) || (c.Field == "required" && c.New == true)):
+			return RuleRequestPropertyRequired
+		case direction == DirectionRequest && c.Type == ChangeTypeRemoved:
+			return RuleRequestPropertyRemoved
+		case direction == DirectionResponse && c.Type == ChangeTypeRemoved:
+			return RuleResponsePropertyRemoved
+		case direction == DirectionResponse && c.Field == "required" && c.New == false:
+			return RuleResponsePropertyOptional
+		}
+		return schemaFieldRule(c, direction)
+	case ObjectSchema:
+		return schemaFieldRule(c, direction)
+	}
+	return ""
+}
+
+func schemaFieldRule(c Change, direction string) string {
+	switch c.Field {
+	case "type", "format", "$ref":
+		return RuleTypeChanged
+	case "enum":
+		oldEnum, _ := c.Old.([]string)
+		newEnum, _ := c.New.([]string)
+		if direction == DirectionRequest &&
+			((len(oldEnum) == 0 && len(newEnum) > 0) || len(subtractStrings(oldEnum, newEnum)) > 0) {
+			return RuleEnumNarrowed
+		} else if direction == DirectionResponse && len(oldEnum) > 0 &&
+			(len(newEnum) == 0 || len(subtractStrings(newEnum, oldEnum)) > 0) {
+			return RuleResponseEnumWidened
+		}
+	case "maximum", "maxLength", "maxItems", "maxProperties":
+		oldVal, oldOK := numberValue(c.Old)
+		newVal, newOK := numberValue(c.New)
+		if direction == DirectionRequest && newOK && (!oldOK || newVal < oldVal) {
+			return RuleConstraintNarrowed
+		}
+	case "minimum", "minLength", "minItems", "minProperties":
+		oldVal, oldOK := numberValue(c.Old)
+		newVal, newOK := numberValue(c.New)
+		if direction == DirectionRequest && newOK && (!oldOK || newVal > oldVal) {
+			return RuleConstraintNarrowed
+		}
+	case "pattern":
+		if direction == DirectionRequest && c.New != "" {
+			return RuleConstraintNarrowed
+		}
+	case "nullable":
+		if direction == DirectionRequest && c.New == false {
+			return RuleConstraintNarrowed
+		}
+	case "exclusiveMinimum", "exclusiveMaximum":
+		if direction == DirectionRequest && c.New == true {
+			return RuleConstraintNarrowed
+		}
+	case "multipleOf", "not":
+		if direction == DirectionRequest && c.New != nil {
+			return RuleConstraintNarrowed
+		}
+	case "additionalProperties":
+		if direction == DirectionRequest && additionalPropertiesRank(c.New) < additionalPropertiesRank(c.Old) {
+			return RuleConstraintNarrowed
+		}
+	}
+	return ""
+}
+
+// additionalPropertiesRank orders `additionalProperties` values from the most
+// restrictive, `false`, to the least restrictive, not set or `true`.
+func additionalPropertiesRank(v interface{}) int {
+	switch v {
+	case false:
+		return 0
+	case schemaValueSet:
+		return 1
+	}
+	return 2
+}
+
+// allowsAnonymous returns whether security requirement strings allow requests
+// without credentials, either with no requirements or with an empty `{}`
+// requirement.
+func allowsAnonymous(reqs []string) bool {
+	if len(reqs) == 0 {
+		return true
+	}
+	for _, req := range reqs {
+		if len(req) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func numberValue(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case uint64:
+		return float64(val), true
+	}
+	return 0, false
+}
+
+// subtractStrings returns the strings in `a` that are not in `b`.
+func subtractStrings(a, b []string) []string {
+	set := stringSet(b)
+	diff := []string{}
+	for _, s := range a {
+		if !set[s] {
+			diff = append(diff, s)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
@@ -0,0 +1,267 @@
+package openapi3diff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+)
+
+const breakingTestBase = `openapi: 3.0.3
+info:
+  title: Breaking
+  version: 1.0.0
+paths:
+  /beta:
+    x-stability: experimental
+    get:
+      operationId: getBeta
+      responses:
+        '200':
+          description: Beta
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Beta'
+  /legacy:
+    get:
+      operationId: getLegacy
+      deprecated: true
+      responses:
+        '200':
+          description: Legacy
+  /items:
+    get:
+      operationId: listItems
+      parameters:
+        - name: cursor
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Items
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+components:
+  schemas:
+    Beta:
+      type: object
+      properties:
+        id:
+          type: integer
+    Item:
+      type: object
+      properties:
+        status:
+          type: string
+          enum: [active, inactive]
+        note:
+          type: string
+          deprecated: true
+`
+
+const breakingTestRevision = `openapi: 3.0.3
+info:
+  title: Breaking
+  version: 1.1.0
+paths:
+  /beta:
+    x-stability: experimental
+    get:
+      operationId: getBeta
+      responses:
+        '200':
+          description: Beta
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Beta'
+  /items:
+    get:
+      operationId: listItems
+      responses:
+        '200':
+          description: Items
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+components:
+  schemas:
+    Beta:
+      type: object
+      properties:
+        id:
+          type: string
+    Item:
+      type: object
+      properties:
+        status:
+          type: string
+          enum: [active, inactive, archived]
+`
+
+var checkBreakingTests = []struct {
+	pointer         string
+	checkDeprecated bool
+	rules           map[string]bool
+	rule            string
+	breaking        bool
+	exempt          string
+}{
+	{"#/components/schemas/Beta/properties/id", false, nil, RuleTypeChanged, false, ExemptOperations},
+	{"#/paths/~1legacy/get", false, nil, RuleOperationRemoved, false, ExemptDeprecated},
+	{"#/paths/~1legacy/get", true, nil, RuleOperationRemoved, true, ""},
+	{"#/components/schemas/Item/properties/status", false, nil, RuleResponseEnumWidened, true, ""},
+	{"#/components/schemas/Item/properties/note", false, nil, RuleResponsePropertyRemoved, false, ExemptDeprecated},
+	{"#/paths/~1items/get/parameters/0", false, nil, RuleParameterRemoved, false, ""},
+	{"#/paths/~1items/get/parameters/0", false, map[string]bool{RuleParameterRemoved: true}, RuleParameterRemoved, true, ""},
+}
+
+// TestCheckBreaking ensures changes are classified by rule and that
+// deprecated and `x-stability` exempt objects are not breaking.
+func TestCheckBreaking(t *testing.T) {
+	base, err := openapi3.Parse([]byte(breakingTestBase))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	revision, err := openapi3.Parse([]byte(breakingTestRevision))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	for _, tt := range checkBreakingTests {
+		cfg := DefaultBreakingConfig()
+		cfg.CheckDeprecated = tt.checkDeprecated
+		for rule, breaking := range tt.rules {
+			cfg.Rules[rule] = breaking
+		}
+		report, err := CheckBreaking(base, revision, &cfg)
+		if err != nil {
+			t.Fatalf("CheckBreaking() Error [%s]", err.Error())
+		}
+		found := false
+		for _, cc := range report.Changes {
+			if cc.Pointer != tt.pointer {
+				continue
+			}
+			found = true
+			if cc.Rule != tt.rule || cc.Breaking != tt.breaking || cc.Exempt != tt.exempt {
+				t.Errorf("CheckBreaking() Mismatch: pointer [%s] want [%s,%v,%s], got [%s,%v,%s]",
+					tt.pointer, tt.rule, tt.breaking, tt.exempt, cc.Rule, cc.Breaking, cc.Exempt)
+			}
+		}
+		if !found {
+			t.Errorf("CheckBreaking() Mismatch: want change at [%s], got none", tt.pointer)
+		}
+	}
+}
+
+const securityAddedTestSpec = `openapi: 3.0.3
+info:
+  title: Security
+  version: 1.0.0
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+paths:
+  /items:
+    get:
+      %s
+      responses:
+        "200":
+          description: OK
+`
+
+var checkBreakingSecurityAddedTests = []struct {
+	base     string
+	revision string
+	rule     string
+	breaking bool
+}{
+	{"", "security: [{apiKey: []}]", RuleSecurityAdded, true},
+	{"security: [{}]", "security: [{apiKey: []}]", RuleSecurityAdded, true},
+	{"security: [{}]", "security: [{}, {apiKey: []}]", "", false},
+	{"security: [{apiKey: []}]", "security: [{apiKey: []}, {oauth: []}]", "", false},
+}
+
+// TestCheckBreakingSecurityAdded ensures adding security requirements is
+// breaking only if requests without credentials were allowed and are no
+// longer allowed.
+func TestCheckBreakingSecurityAdded(t *testing.T) {
+	for _, tt := range checkBreakingSecurityAddedTests {
+		base, err := openapi3.Parse([]byte(fmt.Sprintf(securityAddedTestSpec, tt.base)))
+		if err != nil {
+			t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+		}
+		revision, err := openapi3.Parse([]byte(fmt.Sprintf(securityAddedTestSpec, tt.revision)))
+		if err != nil {
+			t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+		}
+		report, err := CheckBreaking(base, revision, nil)
+		if err != nil {
+			t.Fatalf("CheckBreaking() Error [%s]", err.Error())
+		}
+		found := false
+		for _, cc := range report.Changes {
+			if cc.Object != ObjectSecurity || cc.Type != ChangeTypeAdded {
+				continue
+			}
+			found = true
+			if cc.Rule != tt.rule || cc.Breaking != tt.breaking {
+				t.Errorf("CheckBreaking() Mismatch: [%s] => [%s] want [%s,%v], got [%s,%v]",
+					tt.base, tt.revision, tt.rule, tt.breaking, cc.Rule, cc.Breaking)
+			}
+		}
+		if !found {
+			t.Errorf("CheckBreaking() Mismatch: [%s] => [%s] want added security, got none", tt.base, tt.revision)
+		}
+	}
+}
+
+// TestCheckBreakingFixtures ensures the breaking changes in the fixture specs
+// are found and that a spec has no breaking changes against itself.
+func TestCheckBreakingFixtures(t *testing.T) {
+	base, err := openapi3.ReadFile("testdata/base.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	revision, err := openapi3.ReadFile("testdata/revision.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	report, err := CheckBreaking(base, revision, nil)
+	if err != nil {
+		t.Fatalf("CheckBreaking() Error [%s]", err.Error())
+	}
+	if got := len(report.Breaking()); got != 13 {
+		t.Errorf("BreakingReport.Breaking() Mismatch: want [13], got [%d]", got)
+	}
+	report, err = CheckBreaking(base, base, nil)
+	if err != nil {
+		t.Fatalf("CheckBreaking() Error [%s]", err.Error())
+	}
+	if report.HasBreaking() {
+		t.Errorf("BreakingReport.HasBreaking() Mismatch: want [false], got [true]")
+	}
+}
+
+// TestReadBreakingConfigFile ensures config file rules override the default
+// rules.
+func TestReadBreakingConfigFile(t *testing.T) {
+	cfg, err := ReadBreakingConfigFile("testdata/breaking_config.json")
+	if err != nil {
+		t.Fatalf("ReadBreakingConfigFile() Error [%s]", err.Error())
+	}
+	if !cfg.RuleBreaking(RuleParameterRemoved) || cfg.RuleBreaking(RuleServerRemoved) || !cfg.RuleBreaking(RuleTypeChanged) {
+		t.Errorf("ReadBreakingConfigFile() Mismatch: want rules [%s,%s] overridden", RuleParameterRemoved, RuleServerRemoved)
+	}
+	if !cfg.stabilityExempt("Beta") {
+		t.Errorf("ReadBreakingConfigFile() Mismatch: want stability [beta] exempt")
+	}
+}
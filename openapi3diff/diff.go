@@ -0,0 +1,704 @@
+package openapi3diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+)
+
+const (
+	ChangeTypeAdded    = "added"
+	ChangeTypeRemoved  = "removed"
+	ChangeTypeModified = "modified"
+)
+
+const (
+	ObjectOperation      = "operation"
+	ObjectParameter      = "parameter"
+	ObjectRequestBody    = "request-body"
+	ObjectResponse       = "response"
+	ObjectHeader         = "header"
+	ObjectMediaType      = "media-type"
+	ObjectSecurity       = "security"
+	ObjectServer         = "server"
+	ObjectTag            = "tag"
+	ObjectSchema         = "schema"
+	ObjectSchemaProperty = "schema-property"
+)
+
+const (
+	DirectionRequest  = "request"
+	DirectionResponse = "response"
+)
+
+// Change is a difference between a base and a revision spec. `Pointer` is the
+// JSON pointer in the revision spec, or in the base spec for removals. `Field`
+// is set for modifications along with `Old` and `New` values. `Direction` is
+// set for schemas used in requests or responses and `Schema` is set for
+// changes within component schemas. `Deprecated` is set when the operation,
+// parameter, header or property was deprecated in the base spec. `Path`, `Method`, `OperationID` and `Tags`
+// identify the operation for operation changes.
+type Change struct {
+	Type        string      `json:"type"`
+	Object      string      `json:"object"`
+	Pointer     string      `json:"pointer"`
+	Name        string      `json:"name,omitempty"`
+	In          string      `json:"in,omitempty"`
+	Field       string      `json:"field,omitempty"`
+	Old         interface{} `json:"old,omitempty"`
+	New         interface{} `json:"new,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+	Direction   string      `json:"direction,omitempty"`
+	Schema      string      `json:"schema,omitempty"`
+	Path        string      `json:"path,omitempty"`
+	Method      string      `json:"method,omitempty"`
+	OperationID string      `json:"operationId,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+}
+
+// Operation returns the operation as `METHOD path`, or an empty string for
+// changes that are not in an operation.
+func (c Change) Operation() string {
+	if len(c.Path) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.ToUpper(c.Method) + " " + c.Path)
+}
+
+// String returns a human readable description of the change.
+func (c Change) String() string {
+	parts := []string{c.Type, c.Object}
+	switch {
+	case c.Object == ObjectOperation:
+		parts = append(parts, "`"+c.Operation()+"`")
+	case c.Object == ObjectParameter && len(c.In) > 0:
+		parts = append(parts, "`"+c.Name+"` ("+c.In+")")
+	case len(c.Name) > 0:
+		parts = append(parts, "`"+c.Name+"`")
+	}
+	if c.Required && c.Type != ChangeTypeModified {
+		parts = append(parts, "(required)")
+	}
+	s := strings.Join(parts, " ")
+	if len(c.Field) > 0 {
+		s += fmt.Sprintf(" %s [%s] => [%s]", c.Field, valueString(c.Old), valueString(c.New))
+	}
+	if len(c.Schema) > 0 && !(c.Object == ObjectSchema && c.Name == c.Schema) {
+		s += " in schema `" + c.Schema + "`"
+	}
+	if op := c.Operation(); len(op) > 0 && c.Object != ObjectOperation {
+		s += " in `" + op + "`"
+	}
+	return s
+}
+
+func valueString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(val, ", ")
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// SpecDiff is the set of changes between two specs.
+type SpecDiff struct {
+	BaseVersion     string   `json:"baseVersion,omitempty"`
+	RevisionVersion string   `json:"revisionVersion,omitempty"`
+	Changes         []Change `json:"changes"`
+}
+
+// Compare returns the structural changes from `base` to `revision`, covering
+// operations, matched by path and method or by `operationId`, parameters,
+// request bodies, responses, headers, security requirements, servers, tags
+// and component schemas down to property level. Local `$ref` references to
+// components other than schemas are resolved. Schema `$ref` references are
+// compared by name and referenced component schemas are compared once.
+func Compare(base, revision *openapi3.Spec) (*SpecDiff, error) {
+	if base == nil || revision == nil {
+		return nil, openapi3.ErrSpecNotSet
+	}
+	d := &differ{base: base, rev: revision, changes: []Change{}}
+	d.diffServers()
+	d.diffTags()
+	d.diffSecurity(d.base.Security, d.rev.Security, "#/security", opContext{})
+	d.diffOperations()
+	d.diffComponentSchemas()
+	diff := &SpecDiff{Changes: d.changes}
+	if base.Info != nil {
+		diff.BaseVersion = base.Info.Version
+	}
+	if revision.Info != nil {
+		diff.RevisionVersion = revision.Info.Version
+	}
+	diff.Sort()
+	return diff, nil
+}
+
+// Sort sorts changes by pointer, field and type.
+func (diff *SpecDiff) Sort() {
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		ci, cj := diff.Changes[i], diff.Changes[j]
+		if ci.Pointer != cj.Pointer {
+			return ci.Pointer < cj.Pointer
+		} else if ci.Field != cj.Field {
+			return ci.Field < cj.Field
+		}
+		return ci.Type < cj.Type
+	})
+}
+
+type differ struct {
+	base    *openapi3.Spec
+	rev     *openapi3.Spec
+	changes []Change
+}
+
+// opContext identifies the operation that changes belong to.
+type opContext struct {
+	path        string
+	method      string
+	operationID string
+	tags        []string
+}
+
+func (d *differ) add(oc opContext, c Change) {
+	if len(oc.path) > 0 {
+		c.Path = oc.path
+		c.Method = oc.method
+		c.OperationID = oc.operationID
+		if len(oc.tags) > 0 {
+			c.Tags = append([]string{}, oc.tags...)
+		}
+	}
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) addField(oc opContext, c Change, field string, oldVal, newVal interface{}) {
+	c.Type = ChangeTypeModified
+	c.Field = field
+	c.Old = oldVal
+	c.New = newVal
+	d.add(oc, c)
+}
+
+func escape(s string) string {
+	return jsonpointer.PropertyNameEscape(s)
+}
+
+func (d *differ) diffServers() {
+	baseURLs := map[string]int{}
+	for i, server := range d.base.Servers {
+		if server != nil {
+			baseURLs[server.URL] = i
+		}
+	}
+	revURLs := map[string]int{}
+	for i, server := range d.rev.Servers {
+		if server != nil {
+			revURLs[server.URL] = i
+		}
+	}
+	for url, i := range baseURLs {
+		if _, ok := revURLs[url]; !ok {
+			d.add(opContext{}, Change{Type: ChangeTypeRemoved, Object: ObjectServer,
+				Pointer: fmt.Sprintf("#/servers/%d", i), Name: url})
+		}
+	}
+	for url, i := range revURLs {
+		if j, ok := baseURLs[url]; !ok {
+			d.add(opContext{}, Change{Type: ChangeTypeAdded, Object: ObjectServer,
+				Pointer: fmt.Sprintf("#/servers/%d", i), Name: url})
+		} else {
+			d.diffServer(d.base.Servers[j], d.rev.Servers[i], fmt.Sprintf("#/servers/%d", i))
+		}
+	}
+}
+
+// diffServer reports `description` and server variable changes for servers
+// matched by URL. Variable fields are named such as `variables.region.default`.
+func (d *differ) diffServer(base, rev *oas3.Server, pointer string) {
+	change := Change{Object: ObjectServer, Pointer: pointer, Name: rev.URL}
+	if base.Description != rev.Description {
+		d.addField(opContext{}, change, "description", base.Description, rev.Description)
+	}
+	names := map[string]bool{}
+	for name := range base.Variables {
+		names[name] = true
+	}
+	for name := range rev.Variables {
+		names[name] = true
+	}
+	sortedNames := []string{}
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	for _, name := range sortedNames {
+		baseVar, revVar := base.Variables[name], rev.Variables[name]
+		if baseVar == nil {
+			baseVar = &oas3.ServerVariable{}
+		}
+		if revVar == nil {
+			revVar = &oas3.ServerVariable{}
+		}
+		field := "variables." + name + "."
+		if baseVar.Default != revVar.Default {
+			d.addField(opContext{}, change, field+"default", baseVar.Default, revVar.Default)
+		}
+		if baseVar.Description != revVar.Description {
+			d.addField(opContext{}, change, field+"description", baseVar.Description, revVar.Description)
+		}
+		if baseEnum, revEnum := sortedStrings(baseVar.Enum), sortedStrings(revVar.Enum); strings.Join(baseEnum, "\n") != strings.Join(revEnum, "\n") {
+			d.addField(opContext{}, change, field+"enum", baseEnum, revEnum)
+		}
+	}
+}
+
+func (d *differ) diffTags() {
+	baseTags := map[string]int{}
+	for i, tag := range d.base.Tags {
+		if tag != nil {
+			baseTags[tag.Name] = i
+		}
+	}
+	revTags := map[string]int{}
+	for i, tag := range d.rev.Tags {
+		if tag != nil {
+			revTags[tag.Name] = i
+		}
+	}
+	for name, i := range baseTags {
+		if _, ok := revTags[name]; !ok {
+			d.add(opContext{}, Change{Type: ChangeTypeRemoved, Object: ObjectTag,
+				Pointer: fmt.Sprintf("#/tags/%d", i), Name: name})
+		}
+	}
+	for name, i := range revTags {
+		if j, ok := baseTags[name]; !ok {
+			d.add(opContext{}, Change{Type: ChangeTypeAdded, Object: ObjectTag,
+				Pointer: fmt.Sprintf("#/tags/%d", i), Name: name})
+		} else {
+			d.diffTag(d.base.Tags[j], d.rev.Tags[i], fmt.Sprintf("#/tags/%d", i))
+		}
+	}
+}
+
+// diffTag reports `description` and `externalDocs` changes for tags matched
+// by name.
+func (d *differ) diffTag(base, rev *oas3.Tag, pointer string) {
+	change := Change{Object: ObjectTag, Pointer: pointer, Name: rev.Name}
+	if base.Description != rev.Description {
+		d.addField(opContext{}, change, "description", base.Description, rev.Description)
+	}
+	baseDocs, revDocs := base.ExternalDocs, rev.ExternalDocs
+	if baseDocs == nil {
+		baseDocs = &oas3.ExternalDocs{}
+	}
+	if revDocs == nil {
+		revDocs = &oas3.ExternalDocs{}
+	}
+	if baseDocs.URL != revDocs.URL {
+		d.addField(opContext{}, change, "externalDocs.url", baseDocs.URL, revDocs.URL)
+	}
+	if baseDocs.Description != revDocs.Description {
+		d.addField(opContext{}, change, "externalDocs.description", baseDocs.Description, revDocs.Description)
+	}
+}
+
+// SecurityRequirementStrings returns security requirements as sorted strings
+// such as `apiKey, oauth:read write`. Schemes within a requirement are sorted.
+func SecurityRequirementStrings(reqs oas3.SecurityRequirements) []string {
+	strs := []string{}
+	for _, req := range reqs {
+		names := []string{}
+		for name := range req {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := []string{}
+		for _, name := range names {
+			scopes := append([]string{}, req[name]...)
+			sort.Strings(scopes)
+			if len(scopes) > 0 {
+				parts = append(parts, name+":"+strings.Join(scopes, " "))
+			} else {
+				parts = append(parts, name)
+			}
+		}
+		strs = append(strs, strings.Join(parts, ", "))
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+func (d *differ) diffSecurity(baseReqs, revReqs oas3.SecurityRequirements, pointer string, oc opContext) {
+	baseStrs := map[string]bool{}
+	for _, s := range SecurityRequirementStrings(baseReqs) {
+		baseStrs[s] = true
+	}
+	revStrs := map[string]bool{}
+	for _, s := range SecurityRequirementStrings(revReqs) {
+		revStrs[s] = true
+	}
+	for s := range baseStrs {
+		if !revStrs[s] {
+			d.add(oc, Change{Type: ChangeTypeRemoved, Object: ObjectSecurity, Pointer: pointer, Name: s})
+		}
+	}
+	for s := range revStrs {
+		if !baseStrs[s] {
+			d.add(oc, Change{Type: ChangeTypeAdded, Object: ObjectSecurity, Pointer: pointer, Name: s,
+				Old: SecurityRequirementStrings(baseReqs),
+				New: SecurityRequirementStrings(revReqs)})
+		}
+	}
+}
+
+type opEntry struct {
+	path     string
+	method   string
+	pathItem *oas3.PathItem
+	op       *oas3.Operation
+}
+
+func (oe opEntry) pointer() string {
+	return fmt.Sprintf("#/paths/%s/%s", escape(oe.path), strings.ToLower(oe.method))
+}
+
+func (oe opEntry) context() opContext {
+	return opContext{
+		path:        oe.path,
+		method:      strings.ToUpper(oe.method),
+		operationID: oe.op.OperationID,
+		tags:        oe.op.Tags}
+}
+
+func operations(spec *openapi3.Spec) map[string]opEntry {
+	ops := map[string]opEntry{}
+	for path, pathItem := range spec.Paths {
+		if pathItem == nil {
+			continue
+		}
+		for method, op := range pathItem.Operations() {
+			if op != nil {
+				ops[strings.ToUpper(method)+" "+path] = opEntry{
+					path: path, method: strings.ToUpper(method), pathItem: pathItem, op: op}
+			}
+		}
+	}
+	return ops
+}
+
+func (d *differ) diffOperations() {
+	baseOps := operations(d.base)
+	revOps := operations(d.rev)
+	revOnlyByOpID := map[string]string{}
+	for key, oe := range revOps {
+		if _, ok := baseOps[key]; !ok && len(oe.op.OperationID) > 0 {
+			revOnlyByOpID[oe.op.OperationID] = key
+		}
+	}
+	matchedRev := map[string]bool{}
+	for key, boe := range baseOps {
+		if roe, ok := revOps[key]; ok {
+			matchedRev[key] = true
+			d.diffOperation(boe, roe)
+			continue
+		}
+		if revKey, ok := revOnlyByOpID[boe.op.OperationID]; ok && len(boe.op.OperationID) > 0 && !matchedRev[revKey] {
+			matchedRev[revKey] = true
+			roe := revOps[revKey]
+			d.addField(roe.context(), Change{Object: ObjectOperation, Pointer: roe.pointer(), Deprecated: boe.op.Deprecated},
+				"path", boe.method+" "+boe.path, roe.method+" "+roe.path)
+			d.diffOperation(boe, roe)
+			continue
+		}
+		d.add(boe.context(), Change{Type: ChangeTypeRemoved, Object: ObjectOperation, Pointer: boe.pointer(),
+			Deprecated: boe.op.Deprecated})
+	}
+	for key, roe := range revOps {
+		if !matchedRev[key] {
+			d.add(roe.context(), Change{Type: ChangeTypeAdded, Object: ObjectOperation, Pointer: roe.pointer()})
+		}
+	}
+}
+
+func (d *differ) diffOperation(boe, roe opEntry) {
+	oc := roe.context()
+	opPointer := roe.pointer()
+	opChange := Change{Object: ObjectOperation, Pointer: opPointer, Deprecated: boe.op.Deprecated}
+	if boe.op.OperationID != roe.op.OperationID {
+		d.addField(oc, opChange, "operationId", boe.op.OperationID, roe.op.OperationID)
+	}
+	if boe.op.Deprecated != roe.op.Deprecated {
+		d.addField(oc, opChange, "deprecated", boe.op.Deprecated, roe.op.Deprecated)
+	}
+	if baseTags, revTags := sortedStrings(boe.op.Tags), sortedStrings(roe.op.Tags); strings.Join(baseTags, "\n") != strings.Join(revTags, "\n") {
+		d.addField(oc, opChange, "tags", baseTags, revTags)
+	}
+	d.diffParameters(boe, roe, oc)
+	d.diffRequestBody(boe, roe, oc)
+	d.diffResponses(boe, roe, oc)
+	if boe.op.Security != nil || roe.op.Security != nil {
+		d.diffSecurity(effectiveSecurity(d.base, boe.op), effectiveSecurity(d.rev, roe.op), opPointer+"/security", oc)
+	}
+}
+
+func effectiveSecurity(spec *openapi3.Spec, op *oas3.Operation) oas3.SecurityRequirements {
+	if op.Security != nil {
+		return *op.Security
+	}
+	return spec.Security
+}
+
+func sortedStrings(strs []string) []string {
+	sorted := append([]string{}, strs...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+type paramEntry struct {
+	param   *oas3.Parameter
+	pointer string
+}
+
+// effectiveParameters returns path item and operation parameters by `in` and
+// `name`, with operation parameters overriding path item parameters.
+func effectiveParameters(spec *openapi3.Spec, oe opEntry) map[string]paramEntry {
+	params := map[string]paramEntry{}
+	addParams := func(paramRefs oas3.Parameters, pointer string) {
+		for i, paramRef := range paramRefs {
+			param := resolveParameter(spec, paramRef)
+			if param == nil {
+				continue
+			}
+			params[param.In+" "+param.Name] = paramEntry{
+				param:   param,
+				pointer: fmt.Sprintf("%s/parameters/%d", pointer, i)}
+		}
+	}
+	addParams(oe.pathItem.Parameters, "#/paths/"+escape(oe.path))
+	addParams(oe.op.Parameters, oe.pointer())
+	return params
+}
+
+func (d *differ) diffParameters(boe, roe opEntry, oc opContext) {
+	baseParams := effectiveParameters(d.base, boe)
+	revParams := effectiveParameters(d.rev, roe)
+	for key, bpe := range baseParams {
+		if _, ok := revParams[key]; !ok {
+			d.add(oc, Change{Type: ChangeTypeRemoved, Object: ObjectParameter, Pointer: bpe.pointer,
+				Name: bpe.param.Name, In: bpe.param.In, Required: bpe.param.Required, Deprecated: bpe.param.Deprecated})
+		}
+	}
+	for key, rpe := range revParams {
+		bpe, ok := baseParams[key]
+		if !ok {
+			d.add(oc, Change{Type: ChangeTypeAdded, Object: ObjectParameter, Pointer: rpe.pointer,
+				Name: rpe.param.Name, In: rpe.param.In, Required: rpe.param.Required})
+			continue
+		}
+		change := Change{Object: ObjectParameter, Pointer: rpe.pointer,
+			Name: rpe.param.Name, In: rpe.param.In, Required: rpe.param.Required, Deprecated: bpe.param.Deprecated}
+		if bpe.param.Required != rpe.param.Required {
+			d.addField(oc, change, "required", bpe.param.Required, rpe.param.Required)
+		}
+		if bpe.param.Deprecated != rpe.param.Deprecated {
+			d.addField(oc, change, "deprecated", bpe.param.Deprecated, rpe.param.Deprecated)
+		}
+		d.diffSchema(bpe.param.Schema, rpe.param.Schema, rpe.pointer+"/schema",
+			schemaContext{oc: oc, direction: DirectionRequest, object: ObjectParameter, name: rpe.param.Name,
+				deprecated: bpe.param.Deprecated})
+		d.diffContent(bpe.param.Content, rpe.param.Content, rpe.pointer+"/content", oc, DirectionRequest)
+	}
+}
+
+func (d *differ) diffRequestBody(boe, roe opEntry, oc opContext) {
+	pointer := roe.pointer() + "/requestBody"
+	baseBody := resolveRequestBody(d.base, boe.op.RequestBody)
+	revBody := resolveRequestBody(d.rev, roe.op.RequestBody)
+	switch {
+	case baseBody == nil && revBody == nil:
+		return
+	case baseBody == nil:
+		d.add(oc, Change{Type: ChangeTypeAdded, Object: ObjectRequestBody, Pointer: pointer, Required: revBody.Required})
+		return
+	case revBody == nil:
+		d.add(oc, Change{Type: ChangeTypeRemoved, Object: ObjectRequestBody,
+			Pointer: boe.pointer() + "/requestBody", Required: baseBody.Required})
+		return
+	}
+	if baseBody.Required != revBody.Required {
+		d.addField(oc, Change{Object: ObjectRequestBody, Pointer: pointer, Required: revBody.Required},
+			"required", baseBody.Required, revBody.Required)
+	}
+	d.diffContent(baseBody.Content, revBody.Content, pointer+"/content", oc, DirectionRequest)
+}
+
+func (d *differ) diffContent(baseContent, revContent oas3.Content, pointer string, oc opContext, direction string) {
+	for mediaType := range baseContent {
+		if _, ok := revContent[mediaType]; !ok {
+			d.add(oc, Change{Type: ChangeTypeRemoved, Object: ObjectMediaType,
+				Pointer: pointer + "/" + escape(mediaType), Name: mediaType, Direction: direction})
+		}
+	}
+	for mediaType, revMT := range revContent {
+		baseMT, ok := baseContent[mediaType]
+		if !ok {
+			d.add(oc, Change{Type: ChangeTypeAdded, Object: ObjectMediaType,
+				Pointer: pointer + "/" + escape(mediaType), Name: mediaType, Direction: direction})
+			continue
+		}
+		if baseMT == nil || revMT == nil {
+			continue
+		}
+		d.diffSchema(baseMT.Schema, revMT.Schema, pointer+"/"+escape(mediaType)+"/schema",
+			schemaContext{oc: oc, direction: direction, object: ObjectSchema})
+	}
+}
+
+func (d *differ) diffResponses(boe, roe opEntry, oc opContext) {
+	for code, respRef := range boe.op.Responses {
+		if _, ok := roe.op.Responses[code]; !ok && respRef != nil {
+			d.add(oc, Change{Type: ChangeTypeRemoved, Object: ObjectResponse,
+				Pointer: boe.pointer() + "/responses/" + escape(code), Name: code})
+		}
+	}
+	for code, revRespRef := range roe.op.Responses {
+		pointer := roe.pointer() + "/responses/" + escape(code)
+		baseRespRef, ok := boe.op.Responses[code]
+		if !ok {
+			d.add(oc, Change{Type: ChangeTypeAdded, Object: ObjectResponse, Pointer: pointer, Name: code})
+			continue
+		}
+		baseResp := resolveResponse(d.base, baseRespRef)
+		revResp := resolveResponse(d.rev, revRespRef)
+		if baseResp == nil || revResp == nil {
+			continue
+		}
+		d.diffHeaders(baseResp.Headers, revResp.Headers, pointer+"/headers", oc)
+		d.diffContent(baseResp.Content, revResp.Content, pointer+"/content", oc, DirectionResponse)
+	}
+}
+
+func (d *differ) diffHeaders(baseHeaders, revHeaders oas3.Headers, pointer string, oc opContext) {
+	for name, headerRef := range baseHeaders {
+		if _, ok := revHeaders[name]; !ok {
+			header := resolveHeader(d.base, headerRef)
+			d.add(oc, Change{Type: ChangeTypeRemoved, Object: ObjectHeader, Pointer: pointer + "/" + escape(name),
+				Name: name, Direction: DirectionResponse, Required: header != nil && header.Required,
+				Deprecated: header != nil && header.Deprecated})
+		}
+	}
+	for name, revHeaderRef := range revHeaders {
+		revHeader := resolveHeader(d.rev, revHeaderRef)
+		headerPointer := pointer + "/" + escape(name)
+		baseHeaderRef, ok := baseHeaders[name]
+		if !ok {
+			d.add(oc, Change{Type: ChangeTypeAdded, Object: ObjectHeader, Pointer: headerPointer,
+				Name: name, Direction: DirectionResponse, Required: revHeader != nil && revHeader.Required})
+			continue
+		}
+		baseHeader := resolveHeader(d.base, baseHeaderRef)
+		if baseHeader == nil || revHeader == nil {
+			continue
+		}
+		if baseHeader.Required != revHeader.Required {
+			d.addField(oc, Change{Object: ObjectHeader, Pointer: headerPointer, Name: name,
+				Direction: DirectionResponse, Required: revHeader.Required, Deprecated: baseHeader.Deprecated},
+				"required", baseHeader.Required, revHeader.Required)
+		}
+		d.diffSchema(baseHeader.Schema, revHeader.Schema, headerPointer+"/schema",
+			schemaContext{oc: oc, direction: DirectionResponse, object: ObjectHeader, name: name,
+				deprecated: baseHeader.Deprecated})
+	}
+}
+
+func (d *differ) diffComponentSchemas() {
+	for name, schRef := range d.base.Components.Schemas {
+		if _, ok := d.rev.Components.Schemas[name]; !ok && schRef != nil {
+			d.add(opContext{}, Change{Type: ChangeTypeRemoved, Object: ObjectSchema,
+				Pointer: openapi3.PointerComponentsSchemas + "/" + escape(name), Name: name, Schema: name})
+		}
+	}
+	for name, revSchRef := range d.rev.Components.Schemas {
+		pointer := openapi3.PointerComponentsSchemas + "/" + escape(name)
+		baseSchRef, ok := d.base.Components.Schemas[name]
+		if !ok {
+			d.add(opContext{}, Change{Type: ChangeTypeAdded, Object: ObjectSchema, Pointer: pointer, Name: name, Schema: name})
+			continue
+		}
+		d.diffSchema(baseSchRef, revSchRef, pointer,
+			schemaContext{object: ObjectSchema, name: name, schema: name})
+	}
+}
+
+func componentName(ref, prefix string) (string, bool) {
+	if !strings.HasPrefix(ref, prefix+"/") {
+		return "", false
+	}
+	return jsonpointer.PropertyNameUnescape(strings.TrimPrefix(ref, prefix+"/")), true
+}
+
+func resolveParameter(spec *openapi3.Spec, paramRef *oas3.ParameterRef) *oas3.Parameter {
+	if paramRef == nil {
+		return nil
+	} else if paramRef.Value != nil {
+		return paramRef.Value
+	}
+	if name, ok := componentName(paramRef.Ref, "#/components/parameters"); ok {
+		if compRef := spec.Components.Parameters[name]; compRef != nil && compRef != paramRef {
+			return resolveParameter(spec, compRef)
+		}
+	}
+	return nil
+}
+
+func resolveRequestBody(spec *openapi3.Spec, bodyRef *oas3.RequestBodyRef) *oas3.RequestBody {
+	if bodyRef == nil {
+		return nil
+	} else if bodyRef.Value != nil {
+		return bodyRef.Value
+	}
+	if name, ok := componentName(bodyRef.Ref, "#/components/requestBodies"); ok {
+		if compRef := spec.Components.RequestBodies[name]; compRef != nil && compRef != bodyRef {
+			return resolveRequestBody(spec, compRef)
+		}
+	}
+	return nil
+}
+
+func resolveResponse(spec *openapi3.Spec, respRef *oas3.ResponseRef) *oas3.Response {
+	if respRef == nil {
+		return nil
+	} else if respRef.Value != nil {
+		return respRef.Value
+	}
+	if name, ok := componentName(respRef.Ref, "#/components/responses"); ok {
+		if compRef := spec.Components.Responses[name]; compRef != nil && compRef != respRef {
+			return resolveResponse(spec, compRef)
+		}
+	}
+	return nil
+}
+
+func resolveHeader(spec *openapi3.Spec, headerRef *oas3.HeaderRef) *oas3.Header {
+	if headerRef == nil {
+		return nil
+	} else if headerRef.Value != nil {
+		return headerRef.Value
+	}
+	if name, ok := componentName(headerRef.Ref, "#/components/headers"); ok {
+		if compRef := spec.Components.Headers[name]; compRef != nil && compRef != headerRef {
+			return resolveHeader(spec, compRef)
+		}
+	}
+	return nil
+}
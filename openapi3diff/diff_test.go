@@ -0,0 +1,324 @@
+package openapi3diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+)
+
+func readDiff(t *testing.T) *SpecDiff {
+	base, err := openapi3.ReadFile("testdata/base.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	revision, err := openapi3.ReadFile("testdata/revision.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	diff, err := Compare(base, revision)
+	if err != nil {
+		t.Fatalf("Compare() Error [%s]", err.Error())
+	}
+	return diff
+}
+
+var compareTests = []struct {
+	changeType string
+	object     string
+	pointer    string
+	field      string
+	old        string
+	new        string
+	required   bool
+}{
+	{ChangeTypeAdded, ObjectOperation, "#/paths/~1owners/get", "", "", "", false},
+	{ChangeTypeRemoved, ObjectOperation, "#/paths/~1stores/get", "", "", "", false},
+	{ChangeTypeModified, ObjectOperation, "#/paths/~1pets~1{id}/get", "path", "GET /pets/{petId}", "GET /pets/{id}", false},
+	{ChangeTypeAdded, ObjectParameter, "#/paths/~1pets/get/parameters/2", "", "", "", true},
+	{ChangeTypeRemoved, ObjectParameter, "#/paths/~1pets~1{petId}/get/parameters/0", "", "", "", true},
+	{ChangeTypeModified, ObjectParameter, "#/paths/~1pets/get/parameters/0/schema", "maximum", "100", "50", false},
+	{ChangeTypeModified, ObjectParameter, "#/paths/~1pets/get/parameters/1/schema", "enum", "available, pending, sold", "available, pending", false},
+	{ChangeTypeModified, ObjectRequestBody, "#/paths/~1pets/post/requestBody", "required", "false", "true", true},
+	{ChangeTypeRemoved, ObjectResponse, "#/paths/~1pets~1{petId}/get/responses/404", "", "", "", false},
+	{ChangeTypeRemoved, ObjectHeader, "#/paths/~1pets/get/responses/200/headers/X-Total-Count", "", "", "", false},
+	{ChangeTypeAdded, ObjectSecurity, "#/paths/~1pets/post/security", "", "apiKey", "oauth:write", false},
+	{ChangeTypeRemoved, ObjectSecurity, "#/paths/~1pets/post/security", "", "", "", false},
+	{ChangeTypeAdded, ObjectServer, "#/servers/0", "", "", "", false},
+	{ChangeTypeRemoved, ObjectTag, "#/tags/1", "", "", "", false},
+	{ChangeTypeAdded, ObjectSchema, "#/components/schemas/Owner", "", "", "", false},
+	{ChangeTypeRemoved, ObjectSchema, "#/components/schemas/Error", "", "", "", false},
+	{ChangeTypeAdded, ObjectSchemaProperty, "#/components/schemas/Pet/properties/species", "", "", "", true},
+	{ChangeTypeRemoved, ObjectSchemaProperty, "#/components/schemas/Pet/properties/tag", "", "", "", false},
+	{ChangeTypeModified, ObjectSchemaProperty, "#/components/schemas/Pet/properties/id", "type", "integer", "string", false},
+}
+
+// TestCompare ensures changes are reported with their objects, JSON pointers
+// and old and new values.
+func TestCompare(t *testing.T) {
+	diff := readDiff(t)
+	if diff.BaseVersion != "1.0.0" || diff.RevisionVersion != "1.1.0" {
+		t.Errorf("Compare() Mismatch: want versions [1.0.0,1.1.0], got [%s,%s]",
+			diff.BaseVersion, diff.RevisionVersion)
+	}
+	if len(diff.Changes) != 22 {
+		t.Errorf("Compare() Mismatch: want [22] changes, got [%d]", len(diff.Changes))
+	}
+	for _, tt := range compareTests {
+		found := false
+		for _, c := range diff.Changes {
+			if c.Type != tt.changeType || c.Object != tt.object || c.Pointer != tt.pointer || c.Field != tt.field {
+				continue
+			}
+			found = true
+			if valueString(c.Old) != tt.old || valueString(c.New) != tt.new {
+				t.Errorf("Compare() Mismatch: pointer [%s] field [%s] want [%s => %s], got [%s => %s]",
+					tt.pointer, tt.field, tt.old, tt.new, valueString(c.Old), valueString(c.New))
+			}
+			if c.Required != tt.required {
+				t.Errorf("Compare() Mismatch: pointer [%s] want required [%v], got [%v]",
+					tt.pointer, tt.required, c.Required)
+			}
+		}
+		if !found {
+			t.Errorf("Compare() Mismatch: want [%s %s] at [%s] field [%s], got none",
+				tt.changeType, tt.object, tt.pointer, tt.field)
+		}
+	}
+}
+
+// TestCompareSame ensures a spec compared with itself has no changes.
+func TestCompareSame(t *testing.T) {
+	spec, err := openapi3.ReadFile("testdata/base.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	diff, err := Compare(spec, spec)
+	if err != nil {
+		t.Fatalf("Compare() Error [%s]", err.Error())
+	}
+	if len(diff.Changes) != 0 {
+		t.Errorf("Compare() Mismatch: want [0] changes, got [%d]", len(diff.Changes))
+	}
+}
+
+// TestMarkdown ensures the changelog groups changes by tag with untagged
+// changes last.
+func TestMarkdown(t *testing.T) {
+	diff := readDiff(t)
+	if tags := strings.Join(diff.Tags(), ","); tags != "owners,pets,stores,Other" {
+		t.Errorf("SpecDiff.Tags() Mismatch: want [owners,pets,stores,Other], got [%s]", tags)
+	}
+	md := diff.Markdown()
+	for _, want := range []string{
+		"`1.0.0` => `1.1.0`",
+		"## pets\n",
+		"* Added parameter `owner` (query) (required) in `GET /pets` (`#/paths/~1pets/get/parameters/2`)",
+		"* Modified schema-property `id` type [integer] => [string] in schema `Pet`",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("SpecDiff.Markdown() Mismatch: want [%s], got [%s]", want, md)
+		}
+	}
+	if strings.Index(md, "## Other") < strings.Index(md, "## stores") {
+		t.Errorf("SpecDiff.Markdown() Mismatch: want [Other] last")
+	}
+}
+
+const schemaFieldsTestBase = `openapi: 3.0.3
+info:
+  title: Schema Fields
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Item'
+      responses:
+        '204':
+          description: Created
+components:
+  schemas:
+    Item:
+      type: object
+      additionalProperties: true
+      properties:
+        count:
+          type: number
+        tags:
+          type: object
+          additionalProperties:
+            type: string
+`
+
+const schemaFieldsTestRevision = `openapi: 3.0.3
+info:
+  title: Schema Fields
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Item'
+      responses:
+        '204':
+          description: Created
+components:
+  schemas:
+    Item:
+      type: object
+      additionalProperties: false
+      maxProperties: 10
+      properties:
+        count:
+          type: number
+          multipleOf: 5
+          exclusiveMinimum: true
+          minimum: 0
+          not:
+            enum: [15]
+        tags:
+          type: object
+`
+
+var compareSchemaFieldsTests = []struct {
+	pointer string
+	field   string
+	old     string
+	new     string
+	rule    string
+}{
+	{"#/components/schemas/Item", "additionalProperties", "true", "false", RuleConstraintNarrowed},
+	{"#/components/schemas/Item", "maxProperties", "", "10", RuleConstraintNarrowed},
+	{"#/components/schemas/Item/properties/count", "multipleOf", "", "5", RuleConstraintNarrowed},
+	{"#/components/schemas/Item/properties/count", "exclusiveMinimum", "false", "true", RuleConstraintNarrowed},
+	{"#/components/schemas/Item/properties/count", "not", "", "schema", RuleConstraintNarrowed},
+	{"#/components/schemas/Item/properties/tags", "additionalProperties", "schema", "", ""},
+}
+
+// TestCompareSchemaFields ensures `additionalProperties`, `not` and the
+// `multipleOf`, exclusive and property count limits are compared.
+func TestCompareSchemaFields(t *testing.T) {
+	base, err := openapi3.Parse([]byte(schemaFieldsTestBase))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	revision, err := openapi3.Parse([]byte(schemaFieldsTestRevision))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	report, err := CheckBreaking(base, revision, nil)
+	if err != nil {
+		t.Fatalf("CheckBreaking() Error [%s]", err.Error())
+	}
+	for _, tt := range compareSchemaFieldsTests {
+		found := false
+		for _, c := range report.Changes {
+			if c.Pointer != tt.pointer || c.Field != tt.field {
+				continue
+			}
+			found = true
+			if valueString(c.Old) != tt.old || valueString(c.New) != tt.new || c.Rule != tt.rule {
+				t.Errorf("CheckBreaking() Mismatch: pointer [%s] field [%s] want [%s => %s] rule [%s], got [%s => %s] rule [%s]",
+					tt.pointer, tt.field, tt.old, tt.new, tt.rule, valueString(c.Old), valueString(c.New), c.Rule)
+			}
+		}
+		if !found {
+			t.Errorf("CheckBreaking() Mismatch: want change at [%s] field [%s], got none", tt.pointer, tt.field)
+		}
+	}
+}
+
+const serversTagsTestBase = `openapi: 3.0.3
+info:
+  title: Servers Tags
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com
+    description: Production
+    variables:
+      region:
+        default: us
+        enum: [us, eu]
+tags:
+  - name: pets
+    description: Pets
+paths: {}
+`
+
+const serversTagsTestRevision = `openapi: 3.0.3
+info:
+  title: Servers Tags
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com
+    description: Production API
+    variables:
+      region:
+        default: eu
+        enum: [eu, us, ap]
+tags:
+  - name: pets
+    description: Pet operations
+    externalDocs:
+      url: https://example.com/docs/pets
+paths: {}
+`
+
+var compareServersTagsTests = []struct {
+	object  string
+	pointer string
+	field   string
+	old     string
+	new     string
+}{
+	{ObjectServer, "#/servers/0", "description", "Production", "Production API"},
+	{ObjectServer, "#/servers/0", "variables.region.default", "us", "eu"},
+	{ObjectServer, "#/servers/0", "variables.region.enum", "eu, us", "ap, eu, us"},
+	{ObjectTag, "#/tags/0", "description", "Pets", "Pet operations"},
+	{ObjectTag, "#/tags/0", "externalDocs.url", "", "https://example.com/docs/pets"},
+}
+
+// TestCompareServersTags ensures field changes in servers matched by URL and
+// tags matched by name are reported as modified and not breaking.
+func TestCompareServersTags(t *testing.T) {
+	base, err := openapi3.Parse([]byte(serversTagsTestBase))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	revision, err := openapi3.Parse([]byte(serversTagsTestRevision))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	report, err := CheckBreaking(base, revision, nil)
+	if err != nil {
+		t.Fatalf("CheckBreaking() Error [%s]", err.Error())
+	}
+	if len(report.Changes) != len(compareServersTagsTests) {
+		t.Errorf("CheckBreaking() Mismatch: want [%d] changes, got [%d]", len(compareServersTagsTests), len(report.Changes))
+	}
+	for _, tt := range compareServersTagsTests {
+		found := false
+		for _, c := range report.Changes {
+			if c.Pointer != tt.pointer || c.Field != tt.field {
+				continue
+			}
+			found = true
+			if c.Type != ChangeTypeModified || c.Object != tt.object || c.Breaking ||
+				valueString(c.Old) != tt.old || valueString(c.New) != tt.new {
+				t.Errorf("CheckBreaking() Mismatch: pointer [%s] field [%s] want [%s %s %s => %s], got [%s %s %s => %s] breaking [%v]",
+					tt.pointer, tt.field, ChangeTypeModified, tt.object, tt.old, tt.new,
+					c.Type, c.Object, valueString(c.Old), valueString(c.New), c.Breaking)
+			}
+		}
+		if !found {
+			t.Errorf("CheckBreaking() Mismatch: want change at [%s] field [%s], got none", tt.pointer, tt.field)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package openapi3diff
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// TagOther is the changelog group for changes that are not in a tagged
+// operation, such as servers and component schemas.
+const TagOther = "Other"
+
+// MarshalJSONIndent returns the indented JSON encoding of the diff.
+func (diff *SpecDiff) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(diff, prefix, indent)
+}
+
+// ByTag returns changes grouped by operation tag. Changes in operations with
+// multiple tags are included under each tag. Changes without tags are grouped
+// under `TagOther`.
+func (diff *SpecDiff) ByTag() map[string][]Change {
+	groups := map[string][]Change{}
+	for _, c := range diff.Changes {
+		if len(c.Tags) == 0 {
+			groups[TagOther] = append(groups[TagOther], c)
+			continue
+		}
+		for _, tag := range c.Tags {
+			groups[tag] = append(groups[tag], c)
+		}
+	}
+	return groups
+}
+
+// Tags returns the sorted changelog group names with `TagOther` last.
+func (diff *SpecDiff) Tags() []string {
+	groups := diff.ByTag()
+	tags := []string{}
+	for tag := range groups {
+		if tag != TagOther {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	if _, ok := groups[TagOther]; ok {
+		tags = append(tags, TagOther)
+	}
+	return tags
+}
+
+// Markdown returns a Markdown changelog grouped by operation tag.
+func (diff *SpecDiff) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# API Changelog\n\n")
+	if len(diff.BaseVersion) > 0 || len(diff.RevisionVersion) > 0 {
+		sb.WriteString("`" + diff.BaseVersion + "` => `" + diff.RevisionVersion + "`\n\n")
+	}
+	if len(diff.Changes) == 0 {
+		sb.WriteString("No changes.\n")
+		return sb.String()
+	}
+	groups := diff.ByTag()
+	for _, tag := range diff.Tags() {
+		sb.WriteString("## " + tag + "\n\n")
+		for _, c := range groups[tag] {
+			s := c.String()
+			sb.WriteString("* " + strings.ToUpper(s[:1]) + s[1:] + " (`" + c.Pointer + "`)\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
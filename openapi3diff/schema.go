@@ -0,0 +1,227 @@
+package openapi3diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemaContext describes where a schema is used. `object` and `name` are
+// used for changes to the schema itself, e.g. a parameter or a property.
+type schemaContext struct {
+	oc         opContext
+	direction  string
+	object     string
+	name       string
+	schema     string
+	deprecated bool
+}
+
+func (sc schemaContext) change(pointer string) Change {
+	return Change{
+		Object:     sc.object,
+		Pointer:    pointer,
+		Name:       sc.name,
+		Direction:  sc.direction,
+		Schema:     sc.schema,
+		Deprecated: sc.deprecated}
+}
+
+func (d *differ) diffSchema(baseRef, revRef *oas3.SchemaRef, pointer string, sc schemaContext) {
+	switch {
+	case baseRef == nil && revRef == nil:
+		return
+	case baseRef == nil:
+		c := sc.change(pointer)
+		c.Type = ChangeTypeAdded
+		d.add(sc.oc, c)
+		return
+	case revRef == nil:
+		c := sc.change(pointer)
+		c.Type = ChangeTypeRemoved
+		d.add(sc.oc, c)
+		return
+	}
+	if len(baseRef.Ref) > 0 || len(revRef.Ref) > 0 {
+		// referenced component schemas are compared separately.
+		if baseRef.Ref != revRef.Ref {
+			d.addField(sc.oc, sc.change(pointer), "$ref", baseRef.Ref, revRef.Ref)
+		}
+		return
+	}
+	base, rev := baseRef.Value, revRef.Value
+	if base == nil || rev == nil {
+		return
+	}
+	if base.Deprecated {
+		sc.deprecated = true
+	}
+	for _, fd := range schemaFieldDiffs(base, rev) {
+		d.addField(sc.oc, sc.change(pointer), fd.field, fd.oldVal, fd.newVal)
+	}
+	if baseEnum, revEnum := enumStrings(base.Enum), enumStrings(rev.Enum); strings.Join(baseEnum, "\n") != strings.Join(revEnum, "\n") {
+		d.addField(sc.oc, sc.change(pointer), "enum", baseEnum, revEnum)
+	}
+	d.diffProperties(base, rev, pointer, sc)
+	d.diffSchema(base.Items, rev.Items, pointer+"/items", sc)
+	if base.AdditionalProperties != nil && rev.AdditionalProperties != nil {
+		d.diffSchema(base.AdditionalProperties, rev.AdditionalProperties, pointer+"/additionalProperties", sc)
+	}
+	if base.Not != nil && rev.Not != nil {
+		d.diffSchema(base.Not, rev.Not, pointer+"/not", sc)
+	}
+	d.diffSchemaList(base.AllOf, rev.AllOf, pointer, "allOf", sc)
+	d.diffSchemaList(base.AnyOf, rev.AnyOf, pointer, "anyOf", sc)
+	d.diffSchemaList(base.OneOf, rev.OneOf, pointer, "oneOf", sc)
+}
+
+func (d *differ) diffProperties(base, rev *oas3.Schema, pointer string, sc schemaContext) {
+	baseRequired := stringSet(base.Required)
+	revRequired := stringSet(rev.Required)
+	propSC := sc
+	propSC.object = ObjectSchemaProperty
+	for name, baseProp := range base.Properties {
+		if _, ok := rev.Properties[name]; !ok {
+			propSC.name = name
+			propSC.deprecated = sc.deprecated || schemaDeprecated(baseProp)
+			c := propSC.change(pointer + "/properties/" + escape(name))
+			c.Type = ChangeTypeRemoved
+			c.Required = baseRequired[name]
+			d.add(sc.oc, c)
+		}
+	}
+	for name, revProp := range rev.Properties {
+		propSC.name = name
+		propSC.deprecated = sc.deprecated
+		propPointer := pointer + "/properties/" + escape(name)
+		baseProp, ok := base.Properties[name]
+		if !ok {
+			c := propSC.change(propPointer)
+			c.Type = ChangeTypeAdded
+			c.Required = revRequired[name]
+			d.add(sc.oc, c)
+			continue
+		}
+		if schemaDeprecated(baseProp) {
+			propSC.deprecated = true
+		}
+		if baseRequired[name] != revRequired[name] {
+			c := propSC.change(propPointer)
+			c.Required = revRequired[name]
+			d.addField(sc.oc, c, "required", baseRequired[name], revRequired[name])
+		}
+		d.diffSchema(baseProp, revProp, propPointer, propSC)
+	}
+}
+
+func (d *differ) diffSchemaList(base, rev oas3.SchemaRefs, pointer, field string, sc schemaContext) {
+	if len(base) != len(rev) {
+		d.addField(sc.oc, sc.change(pointer), field, len(base), len(rev))
+	}
+	for i := 0; i < len(base) && i < len(rev); i++ {
+		d.diffSchema(base[i], rev[i], fmt.Sprintf("%s/%s/%d", pointer, field, i), sc)
+	}
+}
+
+func schemaDeprecated(schRef *oas3.SchemaRef) bool {
+	return schRef != nil && schRef.Value != nil && schRef.Value.Deprecated
+}
+
+type fieldDiff struct {
+	field  string
+	oldVal interface{}
+	newVal interface{}
+}
+
+func schemaFieldDiffs(base, rev *oas3.Schema) []fieldDiff {
+	fields := []fieldDiff{
+		{"type", base.Type, rev.Type},
+		{"format", base.Format, rev.Format},
+		{"pattern", base.Pattern, rev.Pattern},
+		{"nullable", base.Nullable, rev.Nullable},
+		{"readOnly", base.ReadOnly, rev.ReadOnly},
+		{"writeOnly", base.WriteOnly, rev.WriteOnly},
+		{"deprecated", base.Deprecated, rev.Deprecated},
+		{"uniqueItems", base.UniqueItems, rev.UniqueItems},
+		{"minimum", float64Value(base.Min), float64Value(rev.Min)},
+		{"maximum", float64Value(base.Max), float64Value(rev.Max)},
+		{"minLength", base.MinLength, rev.MinLength},
+		{"maxLength", uint64Value(base.MaxLength), uint64Value(rev.MaxLength)},
+		{"minItems", base.MinItems, rev.MinItems},
+		{"maxItems", uint64Value(base.MaxItems), uint64Value(rev.MaxItems)},
+		{"multipleOf", float64Value(base.MultipleOf), float64Value(rev.MultipleOf)},
+		{"exclusiveMinimum", base.ExclusiveMin, rev.ExclusiveMin},
+		{"exclusiveMaximum", base.ExclusiveMax, rev.ExclusiveMax},
+		{"minProperties", base.MinProps, rev.MinProps},
+		{"maxProperties", uint64Value(base.MaxProps), uint64Value(rev.MaxProps)},
+		{"additionalProperties", additionalPropertiesValue(base), additionalPropertiesValue(rev)},
+		{"not", schemaSetValue(base.Not), schemaSetValue(rev.Not)},
+	}
+	diffs := []fieldDiff{}
+	for _, fd := range fields {
+		if fd.oldVal != fd.newVal {
+			diffs = append(diffs, fd)
+		}
+	}
+	return diffs
+}
+
+// schemaValueSet is the field value for a schema that is set. Changes within
+// schemas set on both sides are reported at the schema pointer.
+const schemaValueSet = "schema"
+
+// additionalPropertiesValue returns `nil` if `additionalProperties` is not set,
+// its boolean value, or `schemaValueSet` for a schema.
+func additionalPropertiesValue(sch *oas3.Schema) interface{} {
+	if sch.AdditionalProperties != nil {
+		return schemaValueSet
+	} else if sch.AdditionalPropertiesAllowed != nil {
+		return *sch.AdditionalPropertiesAllowed
+	}
+	return nil
+}
+
+func schemaSetValue(schRef *oas3.SchemaRef) interface{} {
+	if schRef == nil {
+		return nil
+	}
+	return schemaValueSet
+}
+
+func float64Value(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+func uint64Value(u *uint64) interface{} {
+	if u == nil {
+		return nil
+	}
+	return *u
+}
+
+func enumStrings(enum []interface{}) []string {
+	strs := []string{}
+	seen := map[string]bool{}
+	for _, v := range enum {
+		s := fmt.Sprintf("%v", v)
+		if !seen[s] {
+			seen[s] = true
+			strs = append(strs, s)
+		}
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+func stringSet(strs []string) map[string]bool {
+	set := map[string]bool{}
+	for _, s := range strs {
+		set[s] = true
+	}
+	return set
+}
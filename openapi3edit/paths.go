@@ -195,3 +195,38 @@ func PathVarsToGeneric(input string) string {
 func PathMatchGeneric(path1, path2 string) bool {
 	return PathVarsToGeneric(path1) == PathVarsToGeneric(path2)
 }
+
+// PathSegments returns the path segments without leading and trailing slashes.
+func PathSegments(path string) []string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if len(path) == 0 {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+// PathSegmentHasVar returns true if a path segment contains a template variable.
+func PathSegmentHasVar(segment string) bool {
+	return rxPathVarToGeneric.MatchString(segment)
+}
+
+// PathMatchAmbiguous returns true if two paths can match the same request URL.
+// Trailing slashes are ignored and a segment with a template variable can match
+// any segment, e.g. `/users/{id}` matches `/users/me` and `/users/{userId}`.
+func PathMatchAmbiguous(path1, path2 string) bool {
+	if PathMatchGeneric(strings.TrimRight(path1, "/"), strings.TrimRight(path2, "/")) {
+		return true
+	}
+	segs1 := PathSegments(path1)
+	segs2 := PathSegments(path2)
+	if len(segs1) != len(segs2) {
+		return false
+	}
+	for i, seg1 := range segs1 {
+		seg2 := segs2[i]
+		if seg1 != seg2 && !PathSegmentHasVar(seg1) && !PathSegmentHasVar(seg2) {
+			return false
+		}
+	}
+	return true
+}
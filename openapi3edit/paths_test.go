@@ -21,3 +21,27 @@ func TestPathVarsToGeneric(t *testing.T) {
 		}
 	}
 }
+
+var pathMatchAmbiguousTests = []struct {
+	path1 string
+	path2 string
+	want  bool
+}{
+	{"/users/{id}", "/users/me", true},
+	{"/a/{x}", "/a/{y}", true},
+	{"/users/", "/users", true},
+	{"/users/{id}/email", "/users/me/phone", false},
+	{"/users/{id}", "/users/{id}/email", false},
+	{"/users/me", "/accounts/me", false},
+}
+
+// TestPathMatchAmbiguous ensures paths that can match the same URL are detected.
+func TestPathMatchAmbiguous(t *testing.T) {
+	for _, tt := range pathMatchAmbiguousTests {
+		got := PathMatchAmbiguous(tt.path1, tt.path2)
+		if got != tt.want {
+			t.Errorf("openapi3edit.PathMatchAmbiguous(\"%s\", \"%s\") Mismatch: want [%v], got [%v]",
+				tt.path1, tt.path2, tt.want, got)
+		}
+	}
+}
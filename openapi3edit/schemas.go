@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
 	"github.com/grokify/mogo/os/osutil"
 	"github.com/grokify/mogo/path/filepathutil"
 	"github.com/grokify/mogo/reflect/reflectutil"
@@ -47,7 +48,9 @@ func SpecAddSchemaDir(spec *openapi3.Spec, dir string, fileRx *regexp.Regexp) er
 
 // SpecModifySchemaRefs modifies `$ref` reference strings that match a supplied
 // `*regexp.Regexp` and replaces that with a string. It was originally
-// designed to convert `#schemas/` to `#components/schemas/`.
+// designed to convert `#schemas/` to `#components/schemas/`. Schemas in
+// components and in operation parameters, request bodies, responses and
+// headers are modified.
 func SpecModifySchemaRefs(spec *openapi3.Spec, rx *regexp.Regexp, repl string) {
 	if spec == nil || rx == nil {
 		return
@@ -61,6 +64,35 @@ func SpecModifySchemaRefs(spec *openapi3.Spec, rx *regexp.Regexp, repl string) {
 			SchemaRefModifyRefs(paramRef.Value.Schema, rx, repl)
 		}
 	}
+	for _, headerRef := range spec.Components.Headers {
+		headerRefModifyRefs(headerRef, rx, repl)
+	}
+	for _, reqBodyRef := range spec.Components.RequestBodies {
+		if reqBodyRef != nil && reqBodyRef.Value != nil {
+			contentModifyRefs(reqBodyRef.Value.Content, rx, repl)
+		}
+	}
+	for _, respRef := range spec.Components.Responses {
+		responseRefModifyRefs(respRef, rx, repl)
+	}
+	for _, pathItem := range spec.Paths {
+		if pathItem == nil {
+			continue
+		}
+		parametersModifyRefs(pathItem.Parameters, rx, repl)
+		for _, op := range pathItem.Operations() {
+			if op == nil {
+				continue
+			}
+			parametersModifyRefs(op.Parameters, rx, repl)
+			if op.RequestBody != nil && len(op.RequestBody.Ref) == 0 && op.RequestBody.Value != nil {
+				contentModifyRefs(op.RequestBody.Value.Content, rx, repl)
+			}
+			for _, respRef := range op.Responses {
+				responseRefModifyRefs(respRef, rx, repl)
+			}
+		}
+	}
 
 	for _, schRef := range spec.Components.Schemas {
 		if schRef == nil {
@@ -120,10 +152,10 @@ func SchemaRefModifyRefs(schRef *oas3.SchemaRef, rx *regexp.Regexp, repl string)
 	if schRef.Value.Not != nil {
 		SchemaRefModifyRefs(schRef.Value.Not, rx, repl)
 	}
-	for _, allOf := range schRef.Value.OneOf {
+	for _, allOf := range schRef.Value.AllOf {
 		SchemaRefModifyRefs(allOf, rx, repl)
 	}
-	for _, anyOf := range schRef.Value.OneOf {
+	for _, anyOf := range schRef.Value.AnyOf {
 		SchemaRefModifyRefs(anyOf, rx, repl)
 	}
 	for _, oneOf := range schRef.Value.OneOf {
@@ -131,6 +163,76 @@ func SchemaRefModifyRefs(schRef *oas3.SchemaRef, rx *regexp.Regexp, repl string)
 	}
 }
 
+func parametersModifyRefs(params oas3.Parameters, rx *regexp.Regexp, repl string) {
+	for _, paramRef := range params {
+		if paramRef == nil || len(paramRef.Ref) > 0 || paramRef.Value == nil {
+			continue
+		}
+		SchemaRefModifyRefs(paramRef.Value.Schema, rx, repl)
+		contentModifyRefs(paramRef.Value.Content, rx, repl)
+	}
+}
+
+func headerRefModifyRefs(headerRef *oas3.HeaderRef, rx *regexp.Regexp, repl string) {
+	if headerRef == nil || len(headerRef.Ref) > 0 || headerRef.Value == nil {
+		return
+	}
+	SchemaRefModifyRefs(headerRef.Value.Schema, rx, repl)
+	contentModifyRefs(headerRef.Value.Content, rx, repl)
+}
+
+func responseRefModifyRefs(respRef *oas3.ResponseRef, rx *regexp.Regexp, repl string) {
+	if respRef == nil || len(respRef.Ref) > 0 || respRef.Value == nil {
+		return
+	}
+	for _, headerRef := range respRef.Value.Headers {
+		headerRefModifyRefs(headerRef, rx, repl)
+	}
+	contentModifyRefs(respRef.Value.Content, rx, repl)
+}
+
+func contentModifyRefs(content oas3.Content, rx *regexp.Regexp, repl string) {
+	for _, mt := range content {
+		if mt != nil {
+			SchemaRefModifyRefs(mt.Schema, rx, repl)
+		}
+	}
+}
+
+// SpecCollapseSchemas replaces component schemas in `schemaNames` with the
+// `keep` schema, such as a group from `SpecMore.SchemaDuplicates()`. The other
+// schemas are deleted and all references to them are rewritten to `keep`
+// using `SpecModifySchemaRefs()`.
+func SpecCollapseSchemas(spec *openapi3.Spec, keep string, schemaNames []string) error {
+	if spec == nil {
+		return openapi3.ErrSpecNotSet
+	}
+	if _, ok := spec.Components.Schemas[keep]; !ok {
+		return fmt.Errorf("schema [%s] not found", keep)
+	}
+	alts := []string{}
+	for _, name := range schemaNames {
+		if name == keep {
+			continue
+		}
+		if _, ok := spec.Components.Schemas[name]; !ok {
+			return fmt.Errorf("schema [%s] not found", name)
+		}
+		alts = append(alts, regexp.QuoteMeta(jsonpointer.PropertyNameEscape(name)))
+	}
+	if len(alts) == 0 {
+		return nil
+	}
+	for _, name := range schemaNames {
+		if name != keep {
+			delete(spec.Components.Schemas, name)
+		}
+	}
+	rx := regexp.MustCompile(`^` + regexp.QuoteMeta(openapi3.PointerComponentsSchemas+"/") + `(` + strings.Join(alts, "|") + `)$`)
+	SpecModifySchemaRefs(spec, rx, openapi3.PointerComponentsSchemas+"/"+jsonpointer.PropertyNameEscape(keep))
+	return nil
+}
+
 func SpecSchemaSetAdditionalPropertiesTrue(spec *openapi3.Spec, pointerBase string) []string {
 	mods := []string{}
 	for schName, schRef := range spec.Components.Schemas {
@@ -0,0 +1,36 @@
+package openapi3edit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+)
+
+// TestSpecCollapseSchemas ensures duplicate schemas are removed and references
+// to them, including in operations, are rewritten to the kept schema.
+func TestSpecCollapseSchemas(t *testing.T) {
+	spec, err := openapi3.ReadFile("../openapi3lint/testdata/spec_rules_duplicates.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	if err := SpecCollapseSchemas(spec, "Address", []string{"Address", "Location"}); err != nil {
+		t.Fatalf("SpecCollapseSchemas() Error [%s]", err.Error())
+	}
+	if _, ok := spec.Components.Schemas["Location"]; ok {
+		t.Errorf("SpecCollapseSchemas() Mismatch: want schema [Location] deleted")
+	}
+	bytes, err := spec.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Spec.MarshalJSON() Error [%s]", err.Error())
+	}
+	if strings.Contains(string(bytes), "#/components/schemas/Location") {
+		t.Errorf("SpecCollapseSchemas() Mismatch: want no references to [Location], got [%s]", string(bytes))
+	}
+	if got := strings.Count(string(bytes), `"#/components/schemas/Address"`); got != 4 {
+		t.Errorf("SpecCollapseSchemas() Mismatch: want [4] references to [Address], got [%d]", got)
+	}
+	if err := SpecCollapseSchemas(spec, "Address", []string{"Missing"}); err == nil {
+		t.Errorf("SpecCollapseSchemas() Mismatch: want error for missing schema, got [nil]")
+	}
+}
@@ -0,0 +1,190 @@
+package openapi3edit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3diff"
+)
+
+const (
+	VersionBumpMajor = "major"
+	VersionBumpMinor = "minor"
+	VersionBumpPatch = "patch"
+)
+
+// VersionBump is a suggested semantic version bump from a released spec.
+type VersionBump struct {
+	Bump            string                          `json:"bump"`
+	ReleasedVersion string                          `json:"releasedVersion"`
+	Version         string                          `json:"version"`
+	Changes         []openapi3diff.ClassifiedChange `json:"changes"`
+}
+
+// SpecSuggestVersionBump compares `spec` against the previously `released`
+// spec and suggests a version bump. Breaking changes require a major bump,
+// or a minor bump before `1.0.0`. Added, removed and newly deprecated
+// objects require a minor bump. Other changes require a patch bump. If there
+// are no changes, `Bump` is empty and `Version` is the released version. If
+// `cfg` is nil, `openapi3diff.DefaultBreakingConfig()` is used.
+func SpecSuggestVersionBump(released, spec *openapi3.Spec, cfg *openapi3diff.BreakingConfig) (*VersionBump, error) {
+	if released == nil || spec == nil {
+		return nil, openapi3.ErrSpecNotSet
+	} else if released.Info == nil {
+		return nil, fmt.Errorf("released spec info is not set")
+	}
+	report, err := openapi3diff.CheckBreaking(released, spec, cfg)
+	if err != nil {
+		return nil, err
+	}
+	vb := &VersionBump{
+		ReleasedVersion: released.Info.Version,
+		Changes:         report.Changes}
+	if len(report.Changes) == 0 {
+		vb.Version = released.Info.Version
+		return vb, nil
+	}
+	vb.Bump = VersionBumpPatch
+	for _, cc := range report.Changes {
+		if cc.Breaking {
+			vb.Bump = VersionBumpMajor
+			break
+		} else if cc.Type != openapi3diff.ChangeTypeModified ||
+			(cc.Field == "deprecated" && cc.New == true) {
+			vb.Bump = VersionBumpMinor
+		}
+	}
+	releasedVersion, err := ParseVersion(released.Info.Version)
+	if err != nil {
+		return nil, err
+	}
+	vb.Version = releasedVersion.Bump(vb.Bump).String()
+	return vb, nil
+}
+
+// ChangelogEntry is an entry in the `x-changelog` extension.
+type ChangelogEntry struct {
+	Version  string   `json:"version"`
+	Date     string   `json:"date,omitempty"`
+	Bump     string   `json:"bump,omitempty"`
+	Breaking []string `json:"breaking,omitempty"`
+	Changes  []string `json:"changes,omitempty"`
+}
+
+// ChangelogEntry returns a changelog entry for the bump with breaking and
+// other changes listed separately.
+func (vb *VersionBump) ChangelogEntry(date string) ChangelogEntry {
+	entry := ChangelogEntry{Version: vb.Version, Date: date, Bump: vb.Bump}
+	for _, cc := range vb.Changes {
+		if cc.Breaking {
+			entry.Breaking = append(entry.Breaking, cc.Change.String())
+		} else {
+			entry.Changes = append(entry.Changes, cc.Change.String())
+		}
+	}
+	return entry
+}
+
+// SpecSetVersionBump sets `info.version` to the suggested version. If
+// `addChangelog` is true and `Bump` is set, a changelog entry is added to the
+// start of the spec's `x-changelog` extension.
+func SpecSetVersionBump(spec *openapi3.Spec, vb *VersionBump, addChangelog bool, date string) error {
+	if spec == nil {
+		return openapi3.ErrSpecNotSet
+	} else if spec.Info == nil {
+		return fmt.Errorf("spec info is not set")
+	} else if vb == nil {
+		return fmt.Errorf("version bump is nil")
+	}
+	spec.Info.Version = vb.Version
+	if !addChangelog || len(vb.Bump) == 0 {
+		return nil
+	}
+	changelog := []ChangelogEntry{}
+	if _, err := openapi3.GetExtensionPropUnmarshal(spec.ExtensionProps, openapi3.XChangelog, &changelog); err != nil {
+		return err
+	}
+	if spec.ExtensionProps.Extensions == nil {
+		spec.ExtensionProps.Extensions = map[string]interface{}{}
+	}
+	spec.ExtensionProps.Extensions[openapi3.XChangelog] = append([]ChangelogEntry{vb.ChangelogEntry(date)}, changelog...)
+	return nil
+}
+
+// Version is a semantic version. `Prefix` is set for versions such as `v1.2.3`.
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+}
+
+// ParseVersion parses versions such as `1.2.3`, `v1.2` and `1.2.3-beta.1`.
+// Build metadata is ignored and missing minor and patch numbers are zero.
+func ParseVersion(s string) (Version, error) {
+	v := Version{}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		v.Prefix = s[:1]
+		s = s[1:]
+	}
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		v.PreRelease = s[i+1:]
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, fmt.Errorf("version [%s] is not a semantic version", s)
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		num, err := strconv.Atoi(part)
+		if err != nil || num < 0 {
+			return v, fmt.Errorf("version [%s] is not a semantic version", s)
+		}
+		*nums[i] = num
+	}
+	return v, nil
+}
+
+// Bump returns the next version for a bump. A major bump of a `0.x` version
+// increments the minor version. A patch bump of a pre-release version
+// returns the release version.
+func (v Version) Bump(bump string) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if len(v.PreRelease) > 0 && bump == VersionBumpPatch {
+		return next
+	}
+	switch bump {
+	case VersionBumpMajor:
+		if v.Major == 0 {
+			next.Minor++
+			next.Patch = 0
+		} else {
+			next.Major++
+			next.Minor = 0
+			next.Patch = 0
+		}
+	case VersionBumpMinor:
+		next.Minor++
+		next.Patch = 0
+	default:
+		next.Patch++
+	}
+	return next
+}
+
+// String returns the version with its prefix and pre-release.
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + v.PreRelease
+	}
+	return s
+}
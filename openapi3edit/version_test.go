@@ -0,0 +1,114 @@
+package openapi3edit
+
+import (
+	"testing"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/spectrum/openapi3"
+)
+
+var versionBumpTests = []struct {
+	version string
+	bump    string
+	want    string
+}{
+	{"1.2.3", VersionBumpMajor, "2.0.0"},
+	{"1.2.3", VersionBumpMinor, "1.3.0"},
+	{"1.2.3", VersionBumpPatch, "1.2.4"},
+	{"v1.2", VersionBumpPatch, "v1.2.1"},
+	{"0.4.1", VersionBumpMajor, "0.5.0"},
+	{"1.0.0-beta.1", VersionBumpPatch, "1.0.0"},
+	{"1.0.0+build.5", VersionBumpMinor, "1.1.0"},
+}
+
+// TestVersionBump ensures versions are parsed and bumped per semantic
+// versioning.
+func TestVersionBump(t *testing.T) {
+	for _, tt := range versionBumpTests {
+		v, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("ParseVersion() Error [%s]", err.Error())
+		}
+		if got := v.Bump(tt.bump).String(); got != tt.want {
+			t.Errorf("Version.Bump() Mismatch: version [%s] bump [%s] want [%s], got [%s]",
+				tt.version, tt.bump, tt.want, got)
+		}
+	}
+	if _, err := ParseVersion("latest"); err == nil {
+		t.Errorf("ParseVersion() Mismatch: want error for [latest], got [nil]")
+	}
+}
+
+// TestSpecSuggestVersionBump ensures breaking changes suggest a major bump,
+// additions a minor bump and no changes no bump.
+func TestSpecSuggestVersionBump(t *testing.T) {
+	released, err := openapi3.ReadFile("../openapi3diff/testdata/base.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	breaking, err := openapi3.ReadFile("../openapi3diff/testdata/revision.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	additive, err := openapi3.ReadFile("../openapi3diff/testdata/base.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	additive.Paths["/health"] = &oas3.PathItem{Get: &oas3.Operation{OperationID: "getHealth"}}
+	for _, tt := range []struct {
+		spec    *openapi3.Spec
+		bump    string
+		version string
+	}{
+		{breaking, VersionBumpMajor, "2.0.0"},
+		{additive, VersionBumpMinor, "1.1.0"},
+		{released, "", "1.0.0"},
+	} {
+		vb, err := SpecSuggestVersionBump(released, tt.spec, nil)
+		if err != nil {
+			t.Fatalf("SpecSuggestVersionBump() Error [%s]", err.Error())
+		}
+		if vb.Bump != tt.bump || vb.Version != tt.version {
+			t.Errorf("SpecSuggestVersionBump() Mismatch: want [%s,%s], got [%s,%s]",
+				tt.bump, tt.version, vb.Bump, vb.Version)
+		}
+	}
+}
+
+// TestSpecSetVersionBump ensures `info.version` is set and changelog entries
+// are added with the newest first and not added without a bump.
+func TestSpecSetVersionBump(t *testing.T) {
+	released, err := openapi3.ReadFile("../openapi3diff/testdata/base.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	spec, err := openapi3.ReadFile("../openapi3diff/testdata/revision.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	vb, err := SpecSuggestVersionBump(released, spec, nil)
+	if err != nil {
+		t.Fatalf("SpecSuggestVersionBump() Error [%s]", err.Error())
+	}
+	if err := SpecSetVersionBump(spec, &VersionBump{Version: "1.0.1", Bump: VersionBumpPatch}, true, "2023-01-01"); err != nil {
+		t.Fatalf("SpecSetVersionBump() Error [%s]", err.Error())
+	}
+	if err := SpecSetVersionBump(spec, vb, true, "2023-02-01"); err != nil {
+		t.Fatalf("SpecSetVersionBump() Error [%s]", err.Error())
+	}
+	if err := SpecSetVersionBump(spec, &VersionBump{Version: "2.0.0"}, true, "2023-03-01"); err != nil {
+		t.Fatalf("SpecSetVersionBump() Error [%s]", err.Error())
+	}
+	if spec.Info.Version != "2.0.0" {
+		t.Errorf("SpecSetVersionBump() Mismatch: want version [2.0.0], got [%s]", spec.Info.Version)
+	}
+	changelog := []ChangelogEntry{}
+	if _, err := openapi3.GetExtensionPropUnmarshal(spec.ExtensionProps, openapi3.XChangelog, &changelog); err != nil {
+		t.Fatalf("openapi3.GetExtensionPropUnmarshal() Error [%s]", err.Error())
+	}
+	if len(changelog) != 2 || changelog[0].Version != "2.0.0" || changelog[1].Version != "1.0.1" {
+		t.Errorf("SpecSetVersionBump() Mismatch: want changelog versions [2.0.0,1.0.1], got [%v]", changelog)
+	} else if len(changelog[0].Breaking) != 13 {
+		t.Errorf("SpecSetVersionBump() Mismatch: want [13] breaking changes, got [%d]", len(changelog[0].Breaking))
+	}
+}
@@ -27,6 +27,10 @@ func (rule RuleTagHasGroup) Name() string {
 	return RuleName
 }
 
+func (rule RuleTagHasGroup) Description() string {
+	return "Tags must belong to a tag group defined in `x-tagGroups`."
+}
+
 func (rule RuleTagHasGroup) Scope() string {
 	return lintutil.ScopeOperation
 }
@@ -0,0 +1,334 @@
+package openapi3lint
+
+import (
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/ext/taggroups"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	FixReasonConflict        = "conflicting fixes for the same location"
+	FixReasonStale           = "current value does not match fix old value"
+	FixReasonNotFound        = "fix target not found"
+	FixReasonCollision       = "new value collides with an existing value"
+	FixReasonReferencedParam = "path parameter is a `$ref` reference"
+	FixReasonUnknownFixType  = "unknown fix type"
+	FixReasonNoChange        = "old and new values are the same"
+	FixReasonEmptyNewValue   = "new value is empty"
+	FixReasonSuppressed      = "rule is suppressed by `x-lint-ignore`"
+	FixReasonTagGroups       = "`x-tag-groups` cannot be parsed"
+)
+
+// Fixer is implemented by rules that can fix their own violations. `Fixes()`
+// returns concrete edits for the spec which are applied by `Policy.FixSpec()`.
+type Fixer interface {
+	Fixes(spec *openapi3.Spec, pointerBase string) []lintutil.Fix
+}
+
+// FixSkipped is a fix that was not applied along with the reason.
+type FixSkipped struct {
+	Fix    lintutil.Fix `json:"fix"`
+	Reason string       `json:"reason"`
+}
+
+// FixResult contains the fixes that were applied and skipped.
+type FixResult struct {
+	Applied []lintutil.Fix `json:"applied"`
+	Skipped []FixSkipped   `json:"skipped"`
+}
+
+func (res *FixResult) skip(fix lintutil.Fix, reason string) {
+	res.Skipped = append(res.Skipped, FixSkipped{Fix: fix, Reason: reason})
+}
+
+// Fixes returns the fixes for all rules in the policy that implement
+// `Fixer` and are included by `filterSeverity`, without applying them. Fixes
+// from operation scope rules are included by the operation severity after
+// policy overrides, as in `ValidateSpec()`.
+func (pol *Policy) Fixes(spec *openapi3.Spec, pointerBase, filterSeverity string) ([]lintutil.Fix, error) {
+	fixes := []lintutil.Fix{}
+	for _, ruleName := range pol.RuleNames() {
+		policyRule := pol.policyRules[ruleName]
+		fixer, ok := policyRule.Rule.(Fixer)
+		if !ok {
+			continue
+		}
+		// operation scope rules are checked per fix with policy overrides.
+		opScope := lintutil.ScopeMatch(lintutil.ScopeOperation, policyRule.Rule.Scope())
+		if !opScope {
+			inclRule, err := severity.SeverityInclude(filterSeverity, policyRule.Severity)
+			if err != nil {
+				return fixes, err
+			} else if !inclRule {
+				continue
+			}
+		}
+		// as in `ValidateSpecContext()`, `pointerBase` is added after the rule
+		// so it is not escaped.
+		for _, fix := range fixer.Fixes(spec, "") {
+			if opScope {
+				inclFix, err := severity.SeverityInclude(filterSeverity, pol.fixSeverity(spec, policyRule, fix))
+				if err != nil {
+					return fixes, err
+				} else if !inclFix {
+					continue
+				}
+			}
+			if len(fix.RuleName) == 0 {
+				fix.RuleName = ruleName
+			}
+			fix.Location = pointerBase + fix.Location
+			fixes = append(fixes, fix)
+		}
+	}
+	sort.SliceStable(fixes, func(i, j int) bool {
+		if fixes[i].Location != fixes[j].Location {
+			return fixes[i].Location < fixes[j].Location
+		}
+		return fixes[i].RuleName < fixes[j].RuleName
+	})
+	return fixes, nil
+}
+
+// fixSeverity returns the severity of an operation scope rule for the
+// operation of a fix after applying policy overrides.
+func (pol *Policy) fixSeverity(spec *openapi3.Spec, policyRule PolicyRule, fix lintutil.Fix) string {
+	if spec == nil {
+		return policyRule.Severity
+	}
+	pathItem, ok := spec.Paths[fix.Path]
+	if !ok || pathItem == nil {
+		return policyRule.Severity
+	}
+	op := pathItem.GetOperation(strings.ToUpper(fix.Method))
+	if op == nil {
+		return policyRule.Severity
+	}
+	return pol.operationSeverity(policyRule, fix.Path, op)
+}
+
+// FixSpec applies fixes from rules that implement `Fixer`. Fixes are applied
+// only when the current value matches the fix's old value and the new value
+// does not collide with existing values. Multiple fixes for the same target
+// with different new values are skipped. Renames are propagated, e.g. path
+// parameter renames update the path, path item parameters and operation
+// parameters, and tag renames update the top level tags and operations.
+func (pol *Policy) FixSpec(spec *openapi3.Spec, pointerBase, filterSeverity string) (FixResult, error) {
+	res := FixResult{Applied: []lintutil.Fix{}, Skipped: []FixSkipped{}}
+	if spec == nil {
+		return res, openapi3.ErrSpecNotSet
+	}
+	fixes, err := pol.Fixes(spec, pointerBase, filterSeverity)
+	if err != nil {
+		return res, err
+	}
+	sups, err := SpecSuppressions(spec)
+	if err != nil {
+		return res, err
+	}
+
+	conflicts := fixConflicts(fixes)
+
+	applied := map[string]bool{}
+	pathRenames := map[string]string{}
+	for _, fix := range fixes {
+		key := fix.Key()
+		if len(sups.Reason(lintutil.PolicyViolation{RuleName: fix.RuleName, Location: fix.Location})) > 0 {
+			res.skip(fix, FixReasonSuppressed)
+			continue
+		} else if conflicts[key] {
+			res.skip(fix, FixReasonConflict)
+			continue
+		} else if applied[key] {
+			// duplicate of an applied fix from another rule.
+			continue
+		}
+		if reason := applyFix(spec, fix, pathRenames); len(reason) > 0 {
+			res.skip(fix, reason)
+			continue
+		}
+		applied[key] = true
+		res.Applied = append(res.Applied, fix)
+	}
+	return res, nil
+}
+
+// fixConflicts returns the keys of fixes that edit the same value with
+// different new values.
+func fixConflicts(fixes []lintutil.Fix) map[string]bool {
+	fixesByKey := map[string][]lintutil.Fix{}
+	for _, fix := range fixes {
+		fixesByKey[fix.Key()] = append(fixesByKey[fix.Key()], fix)
+	}
+	conflicts := map[string]bool{}
+	for key, keyFixes := range fixesByKey {
+		for _, fix := range keyFixes[1:] {
+			if fix.NewValue != keyFixes[0].NewValue {
+				conflicts[key] = true
+			}
+		}
+	}
+	return conflicts
+}
+
+// ApplyFix applies a single fix, checking the fix as `Policy.FixSpec()` does.
+// It returns an empty string if applied or the reason the fix was skipped.
+func ApplyFix(spec *openapi3.Spec, fix lintutil.Fix) string {
+	if spec == nil {
+		return FixReasonNotFound
+	}
+	return applyFix(spec, fix, map[string]string{})
+}
+
+func applyFix(spec *openapi3.Spec, fix lintutil.Fix, pathRenames map[string]string) string {
+	if fix.OldValue == fix.NewValue {
+		return FixReasonNoChange
+	} else if len(strings.TrimSpace(fix.NewValue)) == 0 {
+		return FixReasonEmptyNewValue
+	}
+	switch fix.Type {
+	case lintutil.FixTypeOperationID, lintutil.FixTypeOperationSummary:
+		return applyFixOperation(spec, fix, pathRenames)
+	case lintutil.FixTypePathParamRename:
+		return applyFixPathParamRename(spec, fix, pathRenames)
+	case lintutil.FixTypeTagRename:
+		return applyFixTagRename(spec, fix)
+	}
+	return FixReasonUnknownFixType
+}
+
+func currentPath(path string, pathRenames map[string]string) string {
+	if cur, ok := pathRenames[path]; ok {
+		return cur
+	}
+	return path
+}
+
+func applyFixOperation(spec *openapi3.Spec, fix lintutil.Fix, pathRenames map[string]string) string {
+	pathItem, ok := spec.Paths[currentPath(fix.Path, pathRenames)]
+	if !ok || pathItem == nil {
+		return FixReasonNotFound
+	}
+	op := pathItem.GetOperation(strings.ToUpper(fix.Method))
+	if op == nil {
+		return FixReasonNotFound
+	}
+	switch fix.Type {
+	case lintutil.FixTypeOperationID:
+		if op.OperationID != fix.OldValue {
+			return FixReasonStale
+		}
+		sm := openapi3.SpecMore{Spec: spec}
+		if _, ok := sm.OperationIDsCounts()[fix.NewValue]; ok {
+			return FixReasonCollision
+		}
+		op.OperationID = fix.NewValue
+	case lintutil.FixTypeOperationSummary:
+		if op.Summary != fix.OldValue {
+			return FixReasonStale
+		}
+		op.Summary = fix.NewValue
+	}
+	return ""
+}
+
+func applyFixPathParamRename(spec *openapi3.Spec, fix lintutil.Fix, pathRenames map[string]string) string {
+	curPath := currentPath(fix.Path, pathRenames)
+	pathItem, ok := spec.Paths[curPath]
+	if !ok || pathItem == nil {
+		return FixReasonNotFound
+	}
+	varNames := openapi3edit.ParsePathParametersParens(curPath)
+	hasOld := false
+	for _, varName := range varNames {
+		if varName == fix.NewValue {
+			return FixReasonCollision
+		} else if varName == fix.OldValue {
+			hasOld = true
+		}
+	}
+	if !hasOld {
+		return FixReasonStale
+	}
+	newPath := strings.ReplaceAll(curPath, "{"+fix.OldValue+"}", "{"+fix.NewValue+"}")
+	if _, ok := spec.Paths[newPath]; ok {
+		return FixReasonCollision
+	}
+
+	// check all parameters can be renamed before modifying the spec.
+	paramSets := []oas3.Parameters{pathItem.Parameters}
+	for _, method := range openapi3edit.PathMethods(pathItem) {
+		paramSets = append(paramSets, pathItem.GetOperation(method).Parameters)
+	}
+	renames := []*oas3.Parameter{}
+	for _, params := range paramSets {
+		for _, paramRef := range params {
+			if paramRef == nil {
+				continue
+			}
+			if len(paramRef.Ref) > 0 {
+				if paramRef.Value == nil || paramRef.Value.In == oas3.ParameterInPath {
+					return FixReasonReferencedParam
+				}
+				continue
+			}
+			if paramRef.Value == nil || paramRef.Value.In != oas3.ParameterInPath {
+				continue
+			}
+			if paramRef.Value.Name == fix.NewValue {
+				return FixReasonCollision
+			} else if paramRef.Value.Name == fix.OldValue {
+				renames = append(renames, paramRef.Value)
+			}
+		}
+	}
+
+	for _, param := range renames {
+		param.Name = fix.NewValue
+	}
+	spec.Paths[newPath] = pathItem
+	delete(spec.Paths, curPath)
+	for origPath, renamedPath := range pathRenames {
+		if renamedPath == curPath {
+			pathRenames[origPath] = newPath
+		}
+	}
+	pathRenames[fix.Path] = newPath
+	return ""
+}
+
+func applyFixTagRename(spec *openapi3.Spec, fix lintutil.Fix) string {
+	sm := openapi3.SpecMore{Spec: spec}
+	tagsMap := sm.TagsMap(true, true)
+	if _, ok := tagsMap[fix.OldValue]; !ok {
+		return FixReasonStale
+	}
+	if _, ok := tagsMap[fix.NewValue]; ok {
+		return FixReasonCollision
+	}
+	if _, err := taggroups.SpecRenameTag(spec, fix.OldValue, fix.NewValue); err != nil {
+		return FixReasonTagGroups
+	}
+	for _, tag := range spec.Tags {
+		if tag != nil && tag.Name == fix.OldValue {
+			tag.Name = fix.NewValue
+		}
+	}
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil {
+			return
+		}
+		for i, tagName := range op.Tags {
+			if tagName == fix.OldValue {
+				op.Tags[i] = fix.NewValue
+			}
+		}
+	})
+	return ""
+}
@@ -0,0 +1,279 @@
+package openapi3lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/ext/taggroups"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const FixReasonSourceEdit = "fix cannot be applied as a source edit"
+
+// SourceEdit replaces `OldValue` at a 0-based line and rune offset in JSON or
+// YAML source text.
+type SourceEdit struct {
+	Line     int
+	Start    int
+	OldValue string
+	NewValue string
+}
+
+// FixSource applies fixes as edits to the JSON or YAML source of a spec so
+// comments, key order and formatting are preserved. Fixes are selected and
+// checked as in `FixSpec()`. Fixes that cannot be applied as edits to single
+// line scalar keys and values are skipped with `FixReasonSourceEdit`.
+func (pol *Policy) FixSource(data []byte, pointerBase, filterSeverity string) ([]byte, FixResult, error) {
+	res := FixResult{Applied: []lintutil.Fix{}, Skipped: []FixSkipped{}}
+	spec, err := openapi3.Parse(data)
+	if err != nil {
+		return data, res, err
+	}
+	fixes, err := pol.Fixes(spec, pointerBase, filterSeverity)
+	if err != nil {
+		return data, res, err
+	}
+	sups, err := SpecSuppressions(spec)
+	if err != nil {
+		return data, res, err
+	}
+	conflicts := fixConflicts(fixes)
+
+	text := string(data)
+	applied := map[string]bool{}
+	pathRenames := map[string]string{}
+	for _, fix := range fixes {
+		key := fix.Key()
+		if len(sups.Reason(lintutil.PolicyViolation{RuleName: fix.RuleName, Location: fix.Location})) > 0 {
+			res.skip(fix, FixReasonSuppressed)
+			continue
+		} else if conflicts[key] {
+			res.skip(fix, FixReasonConflict)
+			continue
+		} else if applied[key] {
+			// duplicate of an applied fix from another rule.
+			continue
+		}
+		cur := fix
+		cur.Path = currentPath(fix.Path, pathRenames)
+		spec, err := openapi3.Parse([]byte(text))
+		if err != nil {
+			return data, res, err
+		}
+		if reason := ApplyFix(spec, cur); len(reason) > 0 {
+			res.skip(fix, reason)
+			continue
+		}
+		edits, ok := FixSourceEdits(text, cur)
+		if !ok {
+			res.skip(fix, FixReasonSourceEdit)
+			continue
+		}
+		text = ApplySourceEdits(text, edits)
+		if fix.Type == lintutil.FixTypePathParamRename {
+			newPath := strings.ReplaceAll(cur.Path, "{"+fix.OldValue+"}", "{"+fix.NewValue+"}")
+			for origPath, renamedPath := range pathRenames {
+				if renamedPath == cur.Path {
+					pathRenames[origPath] = newPath
+				}
+			}
+			pathRenames[fix.Path] = newPath
+		}
+		applied[key] = true
+		res.Applied = append(res.Applied, fix)
+	}
+	return []byte(text), res, nil
+}
+
+// FixSourceEdits returns the source edits for a fix, following the changes made
+// by `ApplyFix()`. `ok` is `false` if the fix cannot be applied as edits to
+// single line scalar keys and values or if the edited text does not parse to
+// the same spec as `ApplyFix()`.
+func FixSourceEdits(text string, fix lintutil.Fix) (edits []SourceEdit, ok bool) {
+	spec, err := openapi3.Parse([]byte(text))
+	if err != nil {
+		return nil, false
+	}
+	idx, err := openapi3.NewSourceIndex("", []byte(text))
+	if err != nil {
+		return nil, false
+	}
+	edits, ok = fixSourceEdits(spec, idx, sourceLines(text), fix)
+	if !ok || !sourceEditsMatchFix(text, edits, fix) {
+		return nil, false
+	}
+	return edits, true
+}
+
+// ApplySourceEdits returns the text with edits applied. Line endings are
+// preserved if the text uses `\r\n` consistently.
+func ApplySourceEdits(text string, edits []SourceEdit) string {
+	out := sourceLines(text)
+	sorted := make([]SourceEdit, len(edits))
+	copy(sorted, edits)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Line != sorted[j].Line {
+			return sorted[i].Line > sorted[j].Line
+		}
+		return sorted[i].Start > sorted[j].Start
+	})
+	for _, edit := range sorted {
+		runes := []rune(out[edit.Line])
+		end := edit.Start + len([]rune(edit.OldValue))
+		out[edit.Line] = string(runes[:edit.Start]) + edit.NewValue + string(runes[end:])
+	}
+	sep := "\n"
+	if strings.Count(text, "\r\n") == strings.Count(text, "\n") && strings.Contains(text, "\r\n") {
+		sep = "\r\n"
+	}
+	return strings.Join(out, sep)
+}
+
+// sourceLines splits text into lines without line endings.
+func sourceLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+func fixSourceEdits(spec *openapi3.Spec, idx *openapi3.SourceIndex, lines []string, fix lintutil.Fix) ([]SourceEdit, bool) {
+	type target struct {
+		pointer  string
+		key      bool
+		newValue string
+	}
+	targets := []target{}
+	pathPointer := "#/paths/" + jsonpointer.PropertyNameEscape(fix.Path)
+	switch fix.Type {
+	case lintutil.FixTypeOperationID, lintutil.FixTypeOperationSummary:
+		property := openapi3.PropertyOperationID
+		if fix.Type == lintutil.FixTypeOperationSummary {
+			property = openapi3.PropertySummary
+		}
+		targets = append(targets, target{
+			pointer:  pathPointer + "/" + strings.ToLower(fix.Method) + "/" + property,
+			newValue: fix.NewValue})
+	case lintutil.FixTypePathParamRename:
+		pathItem, ok := spec.Paths[fix.Path]
+		if !ok || pathItem == nil {
+			return nil, false
+		}
+		targets = append(targets, target{
+			pointer:  pathPointer,
+			key:      true,
+			newValue: strings.ReplaceAll(fix.Path, "{"+fix.OldValue+"}", "{"+fix.NewValue+"}")})
+		paramSets := map[string]oas3.Parameters{pathPointer: pathItem.Parameters}
+		for _, method := range openapi3edit.PathMethods(pathItem) {
+			paramSets[pathPointer+"/"+strings.ToLower(method)] = pathItem.GetOperation(method).Parameters
+		}
+		for paramsPointer, params := range paramSets {
+			for i, paramRef := range params {
+				if paramRef != nil && len(paramRef.Ref) == 0 && paramRef.Value != nil &&
+					paramRef.Value.In == oas3.ParameterInPath && paramRef.Value.Name == fix.OldValue {
+					targets = append(targets, target{
+						pointer:  fmt.Sprintf("%s/parameters/%d/name", paramsPointer, i),
+						newValue: fix.NewValue})
+				}
+			}
+		}
+	case lintutil.FixTypeTagRename:
+		for i, tag := range spec.Tags {
+			if tag != nil && tag.Name == fix.OldValue {
+				targets = append(targets, target{
+					pointer:  fmt.Sprintf("#/tags/%d/name", i),
+					newValue: fix.NewValue})
+			}
+		}
+		openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+			if op == nil {
+				return
+			}
+			for i, tagName := range op.Tags {
+				if tagName == fix.OldValue {
+					targets = append(targets, target{
+						pointer: fmt.Sprintf("#/paths/%s/%s/tags/%d",
+							jsonpointer.PropertyNameEscape(path), strings.ToLower(method), i),
+						newValue: fix.NewValue})
+				}
+			}
+		})
+		tagGroups, err := taggroups.SpecTagGroups(spec)
+		if err != nil {
+			return nil, false
+		}
+		for i, tg := range tagGroups.TagGroups {
+			for j, tagName := range tg.Tags {
+				if tagName == fix.OldValue {
+					targets = append(targets, target{
+						pointer:  fmt.Sprintf("#/%s/%d/tags/%d", taggroups.XTagGroupsPropertyName, i, j),
+						newValue: fix.NewValue})
+				}
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	edits := []SourceEdit{}
+	for _, tgt := range targets {
+		pos, value, quoted, ok := idx.ScalarPosition(tgt.pointer, tgt.key)
+		if !ok || pos.Line < 1 || pos.Line > len(lines) {
+			return nil, false
+		}
+		edit := SourceEdit{
+			Line:     pos.Line - 1,
+			Start:    pos.Column - 1,
+			OldValue: value,
+			NewValue: tgt.newValue}
+		if quoted {
+			edit.Start++
+		}
+		// multi-line and escaped scalars do not match the source text and are not edited.
+		runes := []rune(lines[edit.Line])
+		end := edit.Start + len([]rune(value))
+		if edit.Start < 0 || end > len(runes) || string(runes[edit.Start:end]) != value {
+			return nil, false
+		}
+		edits = append(edits, edit)
+	}
+	return edits, len(edits) > 0
+}
+
+// sourceEditsMatchFix checks that the edited text parses to the same spec as
+// applying the fix with `ApplyFix()`. Specs are compared as decoded JSON as
+// extensions edited by `ApplyFix()`, such as `x-tag-groups`, can have a
+// different key order than the source.
+func sourceEditsMatchFix(text string, edits []SourceEdit, fix lintutil.Fix) bool {
+	want, err := openapi3.Parse([]byte(text))
+	if err != nil || len(ApplyFix(want, fix)) > 0 {
+		return false
+	}
+	got, err := openapi3.Parse([]byte(ApplySourceEdits(text, edits)))
+	if err != nil {
+		return false
+	}
+	wantJSON, err := want.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	gotJSON, err := got.MarshalJSON()
+	if err != nil {
+		return false
+	}
+	var wantAny, gotAny interface{}
+	if err := json.Unmarshal(wantJSON, &wantAny); err != nil {
+		return false
+	} else if err := json.Unmarshal(gotJSON, &gotAny); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(wantAny, gotAny)
+}
@@ -0,0 +1,225 @@
+package openapi3lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/ext/taggroups"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"github.com/grokify/spectrum/openapi3lint/ruleopidstyle"
+	"github.com/grokify/spectrum/openapi3lint/ruleopsummarystylefirstuppercase"
+	"github.com/grokify/spectrum/openapi3lint/rulepathparamstyle"
+	"github.com/grokify/spectrum/openapi3lint/ruletagstylefirstuppercase"
+)
+
+const testSpecFix = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "tags": [{"name": "users"}],
+  "x-tag-groups": [{"name": "Accounts", "popular": true, "tags": ["users"]}],
+  "paths": {
+    "/users/{user_id}": {
+      "parameters": [
+        {"name": "user_id", "in": "path", "required": true, "schema": {"type": "string"}}
+      ],
+      "get": {
+        "operationId": "GetUser",
+        "summary": "get user",
+        "tags": ["users"],
+        "parameters": [
+          {"name": "user_id", "in": "query", "schema": {"type": "string"}}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      },
+      "delete": {
+        "operationId": "deleteUser",
+        "tags": ["users"],
+        "parameters": [
+          {"name": "user_id", "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {"204": {"description": "No Content"}}
+      }
+    },
+    "/accounts/{accountId}/{account_id}": {
+      "get": {
+        "operationId": "getAccount",
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+// testFixPolicy returns a policy with rules that implement `Fixer`.
+func testFixPolicy(t *testing.T) Policy {
+	pol := NewPolicy()
+	opIDRule, err := ruleopidstyle.NewRule(stringcase.CamelCase)
+	if err != nil {
+		t.Fatalf("ruleopidstyle.NewRule() Error [%s]", err.Error())
+	}
+	pathParamRule, err := rulepathparamstyle.NewRule(stringcase.CamelCase)
+	if err != nil {
+		t.Fatalf("rulepathparamstyle.NewRule() Error [%s]", err.Error())
+	}
+	for _, rule := range []Rule{
+		opIDRule,
+		pathParamRule,
+		ruleopsummarystylefirstuppercase.NewRule(),
+		ruletagstylefirstuppercase.NewRule()} {
+		if err := pol.AddRule(rule, severity.SeverityError, true); err != nil {
+			t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+		}
+	}
+	return pol
+}
+
+// TestFixSpec ensures fixes are applied, renames are propagated, including
+// tag renames to `x-tag-groups`, and colliding fixes are skipped.
+func TestFixSpec(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecFix))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	pol := testFixPolicy(t)
+
+	res, err := pol.FixSpec(spec, "spec.json", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.FixSpec() Error [%s]", err.Error())
+	}
+	if len(res.Applied) != 4 {
+		t.Errorf("Policy.FixSpec() Applied Mismatch: want [%d], got [%d] %v", 4, len(res.Applied), res.Applied)
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0].Reason != FixReasonCollision {
+		t.Errorf("Policy.FixSpec() Skipped Mismatch: want [1 %s], got %v", FixReasonCollision, res.Skipped)
+	}
+
+	pathItem, ok := spec.Paths["/users/{userId}"]
+	if !ok {
+		t.Fatalf("Policy.FixSpec() path not renamed: [%s]", "/users/{userId}")
+	}
+	if _, ok := spec.Paths["/accounts/{accountId}/{account_id}"]; !ok {
+		t.Errorf("Policy.FixSpec() colliding path renamed: [%s]", "/accounts/{accountId}/{account_id}")
+	}
+	if name := pathItem.Parameters[0].Value.Name; name != "userId" {
+		t.Errorf("Policy.FixSpec() path item param Mismatch: want [%s], got [%s]", "userId", name)
+	}
+	if name := pathItem.Delete.Parameters[0].Value.Name; name != "userId" {
+		t.Errorf("Policy.FixSpec() operation path param Mismatch: want [%s], got [%s]", "userId", name)
+	}
+	if name := pathItem.Get.Parameters[0].Value.Name; name != "user_id" {
+		t.Errorf("Policy.FixSpec() query param renamed: want [%s], got [%s]", "user_id", name)
+	}
+	if pathItem.Get.OperationID != "getUser" {
+		t.Errorf("Policy.FixSpec() operationId Mismatch: want [%s], got [%s]", "getUser", pathItem.Get.OperationID)
+	}
+	if pathItem.Get.Summary != "Get user" {
+		t.Errorf("Policy.FixSpec() summary Mismatch: want [%s], got [%s]", "Get user", pathItem.Get.Summary)
+	}
+	if spec.Tags[0].Name != "Users" || pathItem.Delete.Tags[0] != "Users" {
+		t.Errorf("Policy.FixSpec() tag Mismatch: want [%s], got [%s] [%s]", "Users", spec.Tags[0].Name, pathItem.Delete.Tags[0])
+	}
+	tagGroups, err := taggroups.SpecTagGroups(spec)
+	if err != nil {
+		t.Fatalf("taggroups.SpecTagGroups() Error [%s]", err.Error())
+	}
+	if len(tagGroups.TagGroups) != 1 || strings.Join(tagGroups.TagGroups[0].Tags, ",") != "Users" || !tagGroups.TagGroups[0].Popular {
+		t.Errorf("Policy.FixSpec() x-tag-groups Mismatch: want [Accounts popular [Users]], got %v", tagGroups.TagGroups)
+	}
+
+	vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	if count := vsets.Count(); count != 1 {
+		t.Errorf("Policy.ValidateSpec() after fix Mismatch: want [%d], got [%d]", 1, count)
+	}
+}
+
+// TestFixSpecOverrides ensures operation fixes are not applied where policy
+// overrides disable the rule or lower its severity below the filter.
+func TestFixSpecOverrides(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecFix))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	pol := testFixPolicy(t)
+	err = pol.SetOverrides([]PolicyOverride{{
+		Paths: []string{"/users/*"},
+		Rules: map[string]RuleConfig{
+			lintutil.RulenameOpIdStyleCamelCase:           {Severity: "off"},
+			lintutil.RulenameOpSummaryStyleFirstUpperCase: {Severity: severity.SeverityWarning}}}})
+	if err != nil {
+		t.Fatalf("Policy.SetOverrides() Error [%s]", err.Error())
+	}
+	res, err := pol.FixSpec(spec, "spec.json", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.FixSpec() Error [%s]", err.Error())
+	}
+	for _, fix := range res.Applied {
+		if fix.Type == lintutil.FixTypeOperationID || fix.Type == lintutil.FixTypeOperationSummary {
+			t.Errorf("Policy.FixSpec() Mismatch: want no operation fixes, got [%v]", fix)
+		}
+	}
+	pathItem, ok := spec.Paths["/users/{userId}"]
+	if !ok {
+		t.Fatalf("Policy.FixSpec() path not renamed: [%s]", "/users/{userId}")
+	}
+	if pathItem.Get.OperationID != "GetUser" || pathItem.Get.Summary != "get user" {
+		t.Errorf("Policy.FixSpec() Mismatch: want operation [GetUser, get user] unchanged, got [%s, %s]",
+			pathItem.Get.OperationID, pathItem.Get.Summary)
+	}
+}
+
+const testSpecFixYAML = `# Test spec
+openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+tags:
+  - name: users # user management
+paths:
+  /users/{user_id}:
+    parameters:
+      - name: user_id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: GetUser
+      summary: 'get user'
+      tags:
+        - users
+      responses:
+        "200":
+          description: OK
+x-tag-groups:
+  - tags:
+      - users
+    name: Accounts
+`
+
+// TestFixSource ensures fixes are applied as source edits so comments and
+// formatting are preserved.
+func TestFixSource(t *testing.T) {
+	pol := testFixPolicy(t)
+	data, res, err := pol.FixSource([]byte(testSpecFixYAML), "spec.yaml", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.FixSource() Error [%s]", err.Error())
+	}
+	if len(res.Applied) != 4 || len(res.Skipped) != 0 {
+		t.Errorf("Policy.FixSource() Mismatch: want applied [4] skipped [0], got applied %v skipped %v", res.Applied, res.Skipped)
+	}
+	want := strings.NewReplacer(
+		"/users/{user_id}", "/users/{userId}",
+		"name: user_id", "name: userId",
+		"GetUser", "getUser",
+		"'get user'", "'Get user'",
+		"name: users", "name: Users",
+		"- users", "- Users").Replace(testSpecFixYAML)
+	if string(data) != want {
+		t.Errorf("Policy.FixSource() Mismatch: want [%s], got [%s]", want, string(data))
+	}
+}
@@ -0,0 +1,93 @@
+package lintcli
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/grokify/mogo/os/osutil"
+	"github.com/grokify/mogo/type/stringsutil"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/extensions"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign"
+	"github.com/grokify/spectrum/openapi3lint/security"
+)
+
+// AddRuleCollections adds the extensions, path design and security rule
+// collections used by the lint commands to a policy config.
+func AddRuleCollections(polCfg *openapi3lint.PolicyConfig) {
+	polCfg.AddRuleCollection(extensions.NewRuleCollectionExtensions())
+	polCfg.AddRuleCollection(pathdesign.NewRuleCollectionPathDesign())
+	polCfg.AddRuleCollection(security.NewRuleCollectionSecurity())
+}
+
+// NewPolicyConfig reads a policy config file and adds the rule collections used
+// by the lint commands. If `policyfile` is empty, the config includes the
+// standard rules.
+func NewPolicyConfig(policyfile string) (openapi3lint.PolicyConfig, error) {
+	polCfg := openapi3lint.PolicyConfig{IncludeStandardRules: true}
+	if policyfile = strings.TrimSpace(policyfile); len(policyfile) > 0 {
+		var err error
+		polCfg, err = openapi3lint.NewPolicyConfigFile(policyfile)
+		if err != nil {
+			return polCfg, err
+		}
+	}
+	AddRuleCollections(&polCfg)
+	return polCfg, nil
+}
+
+// NewPolicy returns the policy for a policy config file using `NewPolicyConfig()`.
+func NewPolicy(policyfile string) (openapi3lint.Policy, error) {
+	polCfg, err := NewPolicyConfig(policyfile)
+	if err != nil {
+		return openapi3lint.Policy{}, err
+	}
+	return polCfg.Policy()
+}
+
+// FilesFromInputs returns the spec files for a set of files, directories
+// and glob patterns. Directories include their JSON and YAML files. Files are
+// sorted and deduplicated.
+func FilesFromInputs(inputs []string) ([]string, error) {
+	rxSpec := regexp.MustCompile(`(?i)\.(json|yaml|yml)$`)
+	files := []string{}
+	for _, input := range inputs {
+		input = strings.TrimSpace(input)
+		if len(input) == 0 {
+			continue
+		}
+		matches := []string{input}
+		if strings.ContainsAny(input, "*?[") {
+			globMatches, err := filepath.Glob(input)
+			if err != nil {
+				return nil, err
+			}
+			if len(globMatches) == 0 {
+				return nil, fmt.Errorf("no files match [%s]", input)
+			}
+			matches = globMatches
+		}
+		for _, match := range matches {
+			isDir, err := osutil.IsDir(match)
+			if err != nil {
+				return nil, err
+			}
+			if !isDir {
+				files = append(files, match)
+				continue
+			}
+			entries, err := osutil.ReadDirMore(match, rxSpec, false, true, false)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, osutil.DirEntries(entries).Names(match, true)...)
+		}
+	}
+	files = stringsutil.SliceCondenseSpace(files, true, true)
+	if len(files) == 0 {
+		return nil, openapi3lint.ErrNoSpecFiles
+	}
+	return files, nil
+}
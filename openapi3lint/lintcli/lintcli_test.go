@@ -0,0 +1,52 @@
+package lintcli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3lint"
+	"golang.org/x/exp/slices"
+)
+
+// TestFilesFromInputs ensures files, directories and glob patterns are
+// expanded to sorted and deduplicated spec files.
+func TestFilesFromInputs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.yaml", "b.json", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600); err != nil {
+			t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+		}
+	}
+	files, err := FilesFromInputs([]string{dir, filepath.Join(dir, "*.yaml"), " "})
+	if err != nil {
+		t.Fatalf("lintcli.FilesFromInputs() Error [%s]", err.Error())
+	}
+	want := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.json")}
+	if !slices.Equal(files, want) {
+		t.Errorf("lintcli.FilesFromInputs() Mismatch: want [%v], got [%v]", want, files)
+	}
+	if _, err := FilesFromInputs([]string{filepath.Join(dir, "*.yml")}); err == nil {
+		t.Errorf("lintcli.FilesFromInputs() Mismatch: want error for unmatched glob, got none")
+	}
+	if _, err := FilesFromInputs([]string{}); !errors.Is(err, openapi3lint.ErrNoSpecFiles) {
+		t.Errorf("lintcli.FilesFromInputs() Mismatch: want [%v], got [%v]", openapi3lint.ErrNoSpecFiles, err)
+	}
+}
+
+// TestNewPolicyConfig ensures the rule collections used by the lint commands
+// are available.
+func TestNewPolicyConfig(t *testing.T) {
+	polCfg, err := NewPolicyConfig("")
+	if err != nil {
+		t.Fatalf("lintcli.NewPolicyConfig() Error [%s]", err.Error())
+	}
+	rcs, err := polCfg.AvailableRuleCollections()
+	if err != nil {
+		t.Fatalf("PolicyConfig.AvailableRuleCollections() Error [%s]", err.Error())
+	}
+	if len(rcs) != 4 {
+		t.Errorf("PolicyConfig.AvailableRuleCollections() Mismatch: want [4], got [%d]", len(rcs))
+	}
+}
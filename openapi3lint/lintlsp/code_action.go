@@ -0,0 +1,113 @@
+package lintlsp
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// CodeActions returns quick fixes for fixes with a location in `rng`. A fix is
+// only returned when it can be applied as source edits with
+// `openapi3lint.FixSourceEdits()`.
+func (srv *Server) CodeActions(uri, text string, rng Range, diags []Diagnostic) []CodeAction {
+	actions := []CodeAction{}
+	spec, err := openapi3.Parse([]byte(text))
+	if err != nil || len(strings.TrimSpace(spec.OpenAPI)) == 0 {
+		return actions
+	}
+	idx, err := openapi3.NewSourceIndex("", []byte(text))
+	if err != nil {
+		return actions
+	}
+	fixes, err := srv.pol.Fixes(spec, "", srv.filterSeverity)
+	if err != nil {
+		return actions
+	}
+	sups, err := openapi3lint.SpecSuppressions(spec)
+	if err != nil {
+		return actions
+	}
+	lines := newTextLines(text)
+	for _, fix := range fixes {
+		if len(sups.Reason(lintutil.PolicyViolation{RuleName: fix.RuleName, Location: fix.Location})) > 0 {
+			continue
+		}
+		pos, _ := idx.Position(fix.Location)
+		line := pos.Line - 1
+		if line < rng.Start.Line || line > rng.End.Line {
+			continue
+		}
+		edits, ok := openapi3lint.FixSourceEdits(text, fix)
+		if !ok {
+			continue
+		}
+		action := CodeAction{
+			Title:       fmt.Sprintf("Fix %s: change `%s` to `%s`", fix.RuleName, fix.OldValue, fix.NewValue),
+			Kind:        CodeActionKindQuickFix,
+			Diagnostics: []Diagnostic{},
+			IsPreferred: true,
+			Edit:        &WorkspaceEdit{Changes: map[string][]TextEdit{uri: {}}}}
+		for _, diag := range diags {
+			if diag.Code == fix.RuleName && diag.Range.Start.Line == line {
+				action.Diagnostics = append(action.Diagnostics, diag)
+			}
+		}
+		for _, edit := range edits {
+			action.Edit.Changes[uri] = append(action.Edit.Changes[uri], lines.textEdit(edit))
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// textLines is document text split into lines without line endings.
+type textLines []string
+
+func newTextLines(text string) textLines {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// character converts a rune offset in a line to a UTF-16 offset.
+func (lines textLines) character(line, runeOffset int) int {
+	if line < 0 || line >= len(lines) {
+		return 0
+	}
+	runes := []rune(lines[line])
+	if runeOffset > len(runes) {
+		runeOffset = len(runes)
+	} else if runeOffset < 0 {
+		runeOffset = 0
+	}
+	return len(utf16.Encode(runes[:runeOffset]))
+}
+
+// rangeToLineEnd returns a range from a 0-based line and rune offset to the
+// end of the line.
+func (lines textLines) rangeToLineEnd(line, runeOffset int) Range {
+	if line < 0 {
+		line, runeOffset = 0, 0
+	}
+	end := 0
+	if line < len(lines) {
+		end = len([]rune(lines[line]))
+	}
+	return Range{
+		Start: Position{Line: line, Character: lines.character(line, runeOffset)},
+		End:   Position{Line: line, Character: lines.character(line, end)}}
+}
+
+func (lines textLines) textEdit(edit openapi3lint.SourceEdit) TextEdit {
+	return TextEdit{
+		Range: Range{
+			Start: Position{Line: edit.Line, Character: lines.character(edit.Line, edit.Start)},
+			End:   Position{Line: edit.Line, Character: lines.character(edit.Line, edit.Start+len([]rune(edit.OldValue)))}},
+		NewText: edit.NewValue}
+}
@@ -0,0 +1,121 @@
+package lintlsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	CodeParseError     = -32700
+	CodeInvalidParams  = -32602
+	CodeMethodNotFound = -32601
+	CodeInternalError  = -32603
+)
+
+// Message is a JSON-RPC 2.0 request, notification or response. Requests and
+// responses have an `ID`. Notifications have a `Method` and no `ID`.
+type Message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+// ResponseError is a JSON-RPC 2.0 error.
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("jsonrpc error [%d]: %s", e.Code, e.Message)
+}
+
+// Conn reads and writes JSON-RPC messages with LSP `Content-Length` framing.
+// Writes are safe for concurrent use.
+type Conn struct {
+	r     *bufio.Reader
+	w     io.Writer
+	mutex sync.Mutex
+}
+
+func NewConn(r io.Reader, w io.Writer) *Conn {
+	return &Conn{r: bufio.NewReader(r), w: w}
+}
+
+// Read reads the next message.
+func (c *Conn) Read() (Message, error) {
+	msg := Message{}
+	hdr, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		return msg, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(hdr.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return msg, fmt.Errorf("invalid Content-Length [%s]", hdr.Get("Content-Length"))
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return msg, err
+	}
+	return msg, json.Unmarshal(body, &msg)
+}
+
+// Write writes a message.
+func (c *Conn) Write(msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+// Notify writes a notification.
+func (c *Conn) Notify(method string, params interface{}) error {
+	return c.Write(struct {
+		JSONRPC string      `json:"jsonrpc"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params"`
+	}{"2.0", method, params})
+}
+
+// Call writes a request.
+func (c *Conn) Call(id int, method string, params interface{}) error {
+	return c.Write(struct {
+		JSONRPC string      `json:"jsonrpc"`
+		ID      int         `json:"id"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params"`
+	}{"2.0", id, method, params})
+}
+
+// Reply writes a response with a result, or an error if `respErr` is not `nil`.
+func (c *Conn) Reply(id *json.RawMessage, result interface{}, respErr *ResponseError) error {
+	if respErr != nil {
+		return c.Write(struct {
+			JSONRPC string           `json:"jsonrpc"`
+			ID      *json.RawMessage `json:"id"`
+			Error   *ResponseError   `json:"error"`
+		}{"2.0", id, respErr})
+	}
+	return c.Write(struct {
+		JSONRPC string           `json:"jsonrpc"`
+		ID      *json.RawMessage `json:"id"`
+		Result  interface{}      `json:"result"`
+	}{"2.0", id, result})
+}
@@ -0,0 +1,280 @@
+package lintlsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint"
+)
+
+// ServerName is the name returned in the `initialize` response.
+const ServerName = "oas3lsp"
+
+// ErrExitWithoutShutdown is returned by `Server.Serve()` when `exit` is
+// received before `shutdown`.
+var ErrExitWithoutShutdown = errors.New("exit received before shutdown")
+
+// Server is a Language Server Protocol server that lints OpenAPI 3 documents
+// with a `openapi3lint.Policy`. Documents are linted when opened, changed or
+// saved and violations are published as diagnostics. Fixes from rules that
+// implement `openapi3lint.Fixer` are provided as quick fix code actions. The
+// server does not make network requests.
+type Server struct {
+	pol            openapi3lint.Policy
+	filterSeverity string
+	conn           *Conn
+	docs           map[string]*document
+	shutdown       bool
+}
+
+type document struct {
+	uri     string
+	version int
+	text    string
+}
+
+// NewServer returns a server for `pol` that reports violations at or above `filterSeverity`.
+func NewServer(pol openapi3lint.Policy, filterSeverity string) *Server {
+	if len(strings.TrimSpace(filterSeverity)) == 0 {
+		filterSeverity = severity.SeverityInformational
+	}
+	return &Server{
+		pol:            pol,
+		filterSeverity: filterSeverity,
+		docs:           map[string]*document{}}
+}
+
+// Serve reads messages from `r` and writes messages to `w`, such as stdin and
+// stdout, until `exit` is received, `r` is closed or `ctx` is canceled.
+func (srv *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	srv.conn = NewConn(r, w)
+	type readResult struct {
+		msg Message
+		err error
+	}
+	msgs := make(chan readResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			msg, err := srv.conn.Read()
+			select {
+			case msgs <- readResult{msg, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res := <-msgs:
+			if res.err != nil {
+				if errors.Is(res.err, io.EOF) {
+					return nil
+				}
+				return res.err
+			}
+			if res.msg.Method == MethodExit {
+				if !srv.shutdown {
+					return ErrExitWithoutShutdown
+				}
+				return nil
+			}
+			if err := srv.handle(res.msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle dispatches a message. Errors are only returned for write failures.
+func (srv *Server) handle(msg Message) error {
+	result, respErr := srv.dispatch(msg)
+	if msg.ID == nil {
+		return nil
+	}
+	return srv.conn.Reply(msg.ID, result, respErr)
+}
+
+func (srv *Server) dispatch(msg Message) (interface{}, *ResponseError) {
+	switch msg.Method {
+	case MethodInitialize:
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync: TextDocumentSyncOptions{
+					OpenClose: true,
+					Change:    TextDocumentSyncKindFull,
+					Save:      true},
+				CodeActionProvider: true},
+			ServerInfo: ServerInfo{Name: ServerName}}, nil
+	case MethodInitialized:
+		return nil, nil
+	case MethodShutdown:
+		srv.shutdown = true
+		return nil, nil
+	case MethodDidOpen:
+		params := DidOpenTextDocumentParams{}
+		if respErr := unmarshalParams(msg.Params, &params); respErr != nil {
+			return nil, respErr
+		}
+		srv.docs[params.TextDocument.URI] = &document{
+			uri:     params.TextDocument.URI,
+			version: params.TextDocument.Version,
+			text:    params.TextDocument.Text}
+		return nil, srv.publish(params.TextDocument.URI)
+	case MethodDidChange:
+		params := DidChangeTextDocumentParams{}
+		if respErr := unmarshalParams(msg.Params, &params); respErr != nil {
+			return nil, respErr
+		}
+		doc, ok := srv.docs[params.TextDocument.URI]
+		if !ok || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		doc.version = params.TextDocument.Version
+		doc.text = params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, srv.publish(doc.uri)
+	case MethodDidSave:
+		params := DidSaveTextDocumentParams{}
+		if respErr := unmarshalParams(msg.Params, &params); respErr != nil {
+			return nil, respErr
+		}
+		doc, ok := srv.docs[params.TextDocument.URI]
+		if !ok {
+			return nil, nil
+		}
+		if params.Text != nil {
+			doc.text = *params.Text
+		}
+		return nil, srv.publish(doc.uri)
+	case MethodDidClose:
+		params := DidCloseTextDocumentParams{}
+		if respErr := unmarshalParams(msg.Params, &params); respErr != nil {
+			return nil, respErr
+		}
+		delete(srv.docs, params.TextDocument.URI)
+		if err := srv.conn.Notify(MethodPublishDiagnostics, PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{}}); err != nil {
+			return nil, &ResponseError{Code: CodeInternalError, Message: err.Error()}
+		}
+		return nil, nil
+	case MethodCodeAction:
+		params := CodeActionParams{}
+		if respErr := unmarshalParams(msg.Params, &params); respErr != nil {
+			return nil, respErr
+		}
+		doc, ok := srv.docs[params.TextDocument.URI]
+		if !ok {
+			return []CodeAction{}, nil
+		}
+		return srv.CodeActions(doc.uri, doc.text, params.Range, params.Context.Diagnostics), nil
+	}
+	if msg.ID == nil {
+		// unhandled notifications, such as `$/cancelRequest`, are ignored.
+		return nil, nil
+	}
+	return nil, &ResponseError{
+		Code:    CodeMethodNotFound,
+		Message: fmt.Sprintf("method not found [%s]", msg.Method)}
+}
+
+func unmarshalParams(raw json.RawMessage, params interface{}) *ResponseError {
+	if err := json.Unmarshal(raw, params); err != nil {
+		return &ResponseError{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (srv *Server) publish(uri string) *ResponseError {
+	doc, ok := srv.docs[uri]
+	if !ok {
+		return nil
+	}
+	if err := srv.conn.Notify(MethodPublishDiagnostics, PublishDiagnosticsParams{
+		URI:         doc.uri,
+		Version:     doc.version,
+		Diagnostics: srv.Diagnostics(doc.text)}); err != nil {
+		return &ResponseError{Code: CodeInternalError, Message: err.Error()}
+	}
+	return nil
+}
+
+// Diagnostics lints a document and returns its violations as diagnostics.
+// Documents that cannot be parsed return a single diagnostic with the error
+// and documents without an `openapi` property are not linted.
+func (srv *Server) Diagnostics(text string) []Diagnostic {
+	diags := []Diagnostic{}
+	spec, err := openapi3.Parse([]byte(text))
+	if err != nil {
+		return append(diags, errorDiagnostic("cannot parse OpenAPI document: "+err.Error()))
+	} else if len(strings.TrimSpace(spec.OpenAPI)) == 0 {
+		return diags
+	}
+	idx, err := openapi3.NewSourceIndex("", []byte(text))
+	if err != nil {
+		return append(diags, errorDiagnostic("cannot parse OpenAPI document: "+err.Error()))
+	}
+	vsets, err := srv.pol.ValidateSpec(spec, "", srv.filterSeverity)
+	if err != nil {
+		return append(diags, errorDiagnostic("cannot lint OpenAPI document: "+err.Error()))
+	}
+	lines := newTextLines(text)
+	for _, vio := range vsets.Violations() {
+		pos, _ := idx.Position(vio.Location)
+		msg := vio.Violation
+		if len(msg) == 0 {
+			if policyRule, ok := srv.pol.PolicyRule(vio.RuleName); ok {
+				msg = openapi3lint.RuleDescription(policyRule.Rule)
+			}
+		}
+		if len(msg) == 0 {
+			msg = vio.RuleName
+		}
+		if len(vio.Value) > 0 {
+			msg += " [" + vio.Value + "]"
+		}
+		diags = append(diags, Diagnostic{
+			Range:    lines.rangeToLineEnd(pos.Line-1, pos.Column-1),
+			Severity: DiagnosticSeverity(vio.Severity),
+			Code:     vio.RuleName,
+			Source:   DiagnosticSource,
+			Message:  msg,
+			Data:     map[string]string{"location": vio.Location}})
+	}
+	return diags
+}
+
+func errorDiagnostic(msg string) Diagnostic {
+	return Diagnostic{
+		Severity: DiagnosticSeverityError,
+		Source:   DiagnosticSource,
+		Message:  msg}
+}
+
+// DiagnosticSeverity converts a `github.com/grokify/mogo/log/severity` level
+// to an LSP diagnostic severity.
+func DiagnosticSeverity(sev string) int {
+	switch sev {
+	case severity.SeverityEmergency, severity.SeverityAlert,
+		severity.SeverityCritical, severity.SeverityError:
+		return DiagnosticSeverityError
+	case severity.SeverityWarning:
+		return DiagnosticSeverityWarning
+	case severity.SeverityDebug:
+		return DiagnosticSeverityHint
+	default:
+		return DiagnosticSeverityInformation
+	}
+}
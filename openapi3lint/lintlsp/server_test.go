@@ -0,0 +1,229 @@
+package lintlsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const testURI = "file:///tmp/spec.yaml"
+
+const testSpecLSP = `openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+tags:
+  - name: users
+paths:
+  /users:
+    get:
+      operationId: list_users
+      tags:
+        - users
+      responses:
+        '200':
+          description: OK
+`
+
+// testClient is an in-process LSP client connected to a `Server` with pipes.
+type testClient struct {
+	t      *testing.T
+	conn   *Conn
+	msgs   chan Message
+	nextID int
+	errc   chan error
+}
+
+func newTestClient(t *testing.T, srv *Server) *testClient {
+	clientR, srvW := io.Pipe()
+	srvR, clientW := io.Pipe()
+	c := &testClient{
+		t:    t,
+		conn: NewConn(clientR, clientW),
+		msgs: make(chan Message, 16),
+		errc: make(chan error, 1)}
+	go func() {
+		c.errc <- srv.Serve(context.Background(), srvR, srvW)
+		srvW.Close()
+	}()
+	go func() {
+		for {
+			msg, err := c.conn.Read()
+			if err != nil {
+				close(c.msgs)
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	return c
+}
+
+func (c *testClient) next() Message {
+	select {
+	case msg, ok := <-c.msgs:
+		if !ok {
+			c.t.Fatalf("testClient.next() Error: connection closed")
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		c.t.Fatalf("testClient.next() Error: timeout")
+	}
+	return Message{}
+}
+
+func (c *testClient) call(method string, params, result interface{}) *ResponseError {
+	c.nextID++
+	if err := c.conn.Call(c.nextID, method, params); err != nil {
+		c.t.Fatalf("Conn.Call() Error [%s]", err.Error())
+	}
+	msg := c.next()
+	if msg.ID == nil || string(*msg.ID) != fmt.Sprintf("%d", c.nextID) {
+		c.t.Fatalf("testClient.call(\"%s\") Mismatch: want response, got [%v]", method, msg)
+	}
+	if msg.Error != nil {
+		return msg.Error
+	}
+	if result != nil {
+		if err := json.Unmarshal(msg.Result, result); err != nil {
+			c.t.Fatalf("json.Unmarshal() Error [%s]", err.Error())
+		}
+	}
+	return nil
+}
+
+func (c *testClient) notify(method string, params interface{}) {
+	if err := c.conn.Notify(method, params); err != nil {
+		c.t.Fatalf("Conn.Notify() Error [%s]", err.Error())
+	}
+}
+
+func (c *testClient) diagnostics() PublishDiagnosticsParams {
+	msg := c.next()
+	if msg.Method != MethodPublishDiagnostics {
+		c.t.Fatalf("testClient.diagnostics() Mismatch: want [%s], got [%s]", MethodPublishDiagnostics, msg.Method)
+	}
+	params := PublishDiagnosticsParams{}
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		c.t.Fatalf("json.Unmarshal() Error [%s]", err.Error())
+	}
+	return params
+}
+
+func newTestServer(t *testing.T) *Server {
+	polCfg := openapi3lint.PolicyConfig{
+		IncludeStandardRules: true,
+		Rules: map[string]openapi3lint.RuleConfig{
+			lintutil.RulenameOpIdStyleCamelCase:     {Severity: severity.SeverityWarning},
+			lintutil.RulenameTagStyleFirstUpperCase: {Severity: severity.SeverityError}}}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	return NewServer(pol, severity.SeverityInformational)
+}
+
+// TestServer ensures documents are linted on open and change, diagnostics are
+// mapped to source ranges and fixes are provided as code actions.
+func TestServer(t *testing.T) {
+	c := newTestClient(t, newTestServer(t))
+
+	initRes := InitializeResult{}
+	if respErr := c.call(MethodInitialize, map[string]interface{}{}, &initRes); respErr != nil {
+		t.Fatalf("initialize Error [%s]", respErr.Error())
+	}
+	if !initRes.Capabilities.CodeActionProvider || initRes.Capabilities.TextDocumentSync.Change != TextDocumentSyncKindFull {
+		t.Errorf("initialize Mismatch: got [%v]", initRes)
+	}
+	c.notify(MethodInitialized, map[string]interface{}{})
+
+	c.notify(MethodDidOpen, DidOpenTextDocumentParams{TextDocument: TextDocumentItem{
+		URI: testURI, LanguageID: "yaml", Version: 1, Text: testSpecLSP}})
+	pub := c.diagnostics()
+	got := []string{}
+	for _, diag := range pub.Diagnostics {
+		got = append(got, diagString(diag))
+	}
+	want := []string{
+		"operation-operationid-style-camelcase 2 9:6-9:29",
+		"tag-style-first-uppercase 1 11:10-11:15",
+		"tag-style-first-uppercase 1 5:4-5:15"}
+	if pub.URI != testURI || pub.Version != 1 || strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("publishDiagnostics Mismatch: want [%v], got [%s %d %v]", want, pub.URI, pub.Version, got)
+	}
+
+	actions := []CodeAction{}
+	if respErr := c.call(MethodCodeAction, CodeActionParams{
+		TextDocument: TextDocumentIdentifier{URI: testURI},
+		Range:        Range{Start: Position{Line: 9}, End: Position{Line: 9}},
+		Context:      CodeActionContext{Diagnostics: pub.Diagnostics}}, &actions); respErr != nil {
+		t.Fatalf("codeAction Error [%s]", respErr.Error())
+	}
+	if len(actions) != 1 || actions[0].Edit == nil || len(actions[0].Diagnostics) != 1 {
+		t.Fatalf("codeAction Mismatch: want [1] action with an edit and diagnostic, got [%v]", actions)
+	}
+	edits := actions[0].Edit.Changes[testURI]
+	if len(edits) != 1 || edits[0].NewText != "listUsers" ||
+		edits[0].Range != (Range{Start: Position{Line: 9, Character: 19}, End: Position{Line: 9, Character: 29}}) {
+		t.Errorf("codeAction edits Mismatch: got [%v]", edits)
+	}
+
+	// tag renames edit the top level tag and operation tags.
+	if respErr := c.call(MethodCodeAction, CodeActionParams{
+		TextDocument: TextDocumentIdentifier{URI: testURI},
+		Range:        Range{Start: Position{Line: 0}, End: Position{Line: 20}}}, &actions); respErr != nil {
+		t.Fatalf("codeAction Error [%s]", respErr.Error())
+	}
+	if len(actions) != 2 {
+		t.Fatalf("codeAction Mismatch: want [2] actions, got [%v]", actions)
+	}
+	for _, action := range actions {
+		if strings.Contains(action.Title, "tag-style-first-uppercase") && len(action.Edit.Changes[testURI]) != 2 {
+			t.Errorf("codeAction tag edits Mismatch: want [2], got [%v]", action.Edit.Changes[testURI])
+		}
+	}
+
+	fixed := strings.Replace(strings.Replace(testSpecLSP, "list_users", "listUsers", 1), "users\n", "Users\n", 2)
+	c.notify(MethodDidChange, DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: testURI, Version: 2},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: fixed}}})
+	if pub := c.diagnostics(); pub.Version != 2 || len(pub.Diagnostics) != 0 {
+		t.Errorf("publishDiagnostics after change Mismatch: want [0] diagnostics, got [%v]", pub.Diagnostics)
+	}
+
+	c.notify(MethodDidChange, DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: testURI, Version: 3},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: "openapi: [3.0.3\n"}}})
+	if pub := c.diagnostics(); len(pub.Diagnostics) != 1 || pub.Diagnostics[0].Severity != DiagnosticSeverityError {
+		t.Errorf("publishDiagnostics parse error Mismatch: want [1] error, got [%v]", pub.Diagnostics)
+	}
+
+	c.notify(MethodDidClose, DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: testURI}})
+	if pub := c.diagnostics(); len(pub.Diagnostics) != 0 {
+		t.Errorf("publishDiagnostics after close Mismatch: want [0] diagnostics, got [%v]", pub.Diagnostics)
+	}
+
+	if respErr := c.call("workspace/unknown", map[string]interface{}{}, nil); respErr == nil || respErr.Code != CodeMethodNotFound {
+		t.Errorf("unknown method Mismatch: want code [%d], got [%v]", CodeMethodNotFound, respErr)
+	}
+	if respErr := c.call(MethodShutdown, nil, nil); respErr != nil {
+		t.Fatalf("shutdown Error [%s]", respErr.Error())
+	}
+	c.notify(MethodExit, nil)
+	if err := <-c.errc; err != nil {
+		t.Errorf("Server.Serve() Error [%s]", err.Error())
+	}
+}
+
+func diagString(diag Diagnostic) string {
+	return fmt.Sprintf("%s %d %d:%d-%d:%d", diag.Code, diag.Severity,
+		diag.Range.Start.Line, diag.Range.Start.Character, diag.Range.End.Line, diag.Range.End.Character)
+}
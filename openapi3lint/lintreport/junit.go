@@ -0,0 +1,102 @@
+package lintreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// JUnitTestSuites is a JUnit XML report where each spec file is a test suite
+// and each rule is a test case. Each violation is reported as a failure.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []JUnitFailure `xml:"failure,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnit returns a JUnit report for a set of policy violations. `documents` is
+// an optional list of spec file pointer bases so that files without violations
+// are reported as passing test suites.
+func NewJUnit(pol *openapi3lint.Policy, vsets *lintutil.PolicyViolationsSets, documents []string) JUnitTestSuites {
+	byDocRule := map[string]map[string][]lintutil.PolicyViolation{}
+	for _, doc := range documents {
+		byDocRule[doc] = map[string][]lintutil.PolicyViolation{}
+	}
+	if vsets != nil {
+		for ruleName, vset := range vsets.ByRule {
+			for _, vio := range vset.Violations {
+				doc, _ := vio.LocationParts()
+				if _, ok := byDocRule[doc]; !ok {
+					byDocRule[doc] = map[string][]lintutil.PolicyViolation{}
+				}
+				byDocRule[doc][ruleName] = append(byDocRule[doc][ruleName], vio)
+			}
+		}
+	}
+	docs := []string{}
+	for doc := range byDocRule {
+		docs = append(docs, doc)
+	}
+	sort.Strings(docs)
+
+	ruleNames := ruleNamesAll(pol, vsets)
+	report := JUnitTestSuites{
+		Name:   ToolName,
+		Suites: []JUnitTestSuite{}}
+	for _, doc := range docs {
+		suite := JUnitTestSuite{
+			Name:      doc,
+			TestCases: []JUnitTestCase{}}
+		for _, ruleName := range ruleNames {
+			tc := JUnitTestCase{
+				Name:      ruleName,
+				ClassName: doc}
+			for _, vio := range violationsSorted(byDocRule[doc][ruleName]) {
+				tc.Failures = append(tc.Failures, JUnitFailure{
+					Message: ViolationMessage(vio),
+					Type:    vio.Severity,
+					Text:    vio.Location})
+			}
+			suite.Tests++
+			suite.Failures += len(tc.Failures)
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+// MarshalJUnit returns the JUnit XML encoding for a set of policy violations.
+func MarshalJUnit(pol *openapi3lint.Policy, vsets *lintutil.PolicyViolationsSets, documents []string, prefix, indent string) ([]byte, error) {
+	bytes, err := xml.MarshalIndent(NewJUnit(pol, vsets, documents), prefix, indent)
+	if err != nil {
+		return bytes, err
+	}
+	return []byte(fmt.Sprintf("%s%s\n", xml.Header, string(bytes))), nil
+}
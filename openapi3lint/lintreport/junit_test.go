@@ -0,0 +1,39 @@
+package lintreport
+
+import (
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// TestNewJUnit ensures violations are grouped into suites by document with
+// one test case per rule and one failure per violation.
+func TestNewJUnit(t *testing.T) {
+	vsets := lintutil.NewPolicyViolationsSets()
+	vsets.AddViolations([]lintutil.PolicyViolation{
+		{RuleName: "operation-summary-exist", Location: "a.yaml#/paths/~1users/get/summary"},
+		{RuleName: "operation-summary-exist", Location: "a.yaml#/paths/~1users/post/summary"},
+		{RuleName: "tag-style-first-uppercase", Location: "b.yaml#/tags/0/name"}})
+	report := NewJUnit(nil, vsets, []string{"a.yaml", "b.yaml", "c.yaml"})
+	if len(report.Suites) != 3 {
+		t.Fatalf("lintreport.NewJUnit() Suites Mismatch: want [3], got [%d]", len(report.Suites))
+	}
+	if report.Tests != 6 || report.Failures != 3 {
+		t.Errorf("lintreport.NewJUnit() Mismatch: want tests [6] failures [3], got tests [%d] failures [%d]",
+			report.Tests, report.Failures)
+	}
+	if report.Suites[0].Name != "a.yaml" || report.Suites[0].Failures != 2 {
+		t.Errorf("lintreport.NewJUnit() Suites[0] Mismatch: want [a.yaml] failures [2], got [%s] failures [%d]",
+			report.Suites[0].Name, report.Suites[0].Failures)
+	}
+	for _, tc := range report.Suites[0].TestCases {
+		if tc.Name == "operation-summary-exist" && len(tc.Failures) != 2 {
+			t.Errorf("lintreport.NewJUnit() TestCase Mismatch: want [2] failures, got [%d]", len(tc.Failures))
+		} else if tc.Name != "operation-summary-exist" && len(tc.Failures) != 0 {
+			t.Errorf("lintreport.NewJUnit() TestCase Mismatch: want [0] failures for [%s], got [%d]", tc.Name, len(tc.Failures))
+		}
+	}
+	if report.Suites[2].Failures != 0 {
+		t.Errorf("lintreport.NewJUnit() Suites[2] Mismatch: want failures [0], got [%d]", report.Suites[2].Failures)
+	}
+}
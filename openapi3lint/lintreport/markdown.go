@@ -0,0 +1,139 @@
+package lintreport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// MarkdownSummary returns a Markdown report with a summary table of violation
+// counts grouped by rule and violation severity, followed by violation
+// locations by rule. Rows are sorted by severity and then by rule name.
+func MarkdownSummary(pol *openapi3lint.Policy, vsets *lintutil.PolicyViolationsSets, inclLocations bool) string {
+	type ruleSummary struct {
+		name     string
+		severity string
+		vset     lintutil.PolicyViolationsSet
+	}
+	summaries := []ruleSummary{}
+	if vsets != nil {
+		for ruleName, vset := range vsets.ByRule {
+			bySeverity := map[string][]lintutil.PolicyViolation{}
+			for _, vio := range vset.Violations {
+				if len(vio.RuleName) == 0 {
+					vio.RuleName = ruleName
+				}
+				sev := violationSeverity(pol, vio)
+				bySeverity[sev] = append(bySeverity[sev], vio)
+			}
+			for sev, vios := range bySeverity {
+				summaries = append(summaries, ruleSummary{
+					name:     ruleName,
+					severity: sev,
+					vset:     lintutil.PolicyViolationsSet{RuleName: ruleName, Violations: vios}})
+			}
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		ri, rj := severityRank(summaries[i].severity), severityRank(summaries[j].severity)
+		if ri != rj {
+			return ri < rj
+		}
+		return summaries[i].name < summaries[j].name
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# OpenAPI Lint Report\n\n")
+	if len(summaries) == 0 {
+		sb.WriteString("No violations found.\n")
+		writeMarkdownSuppressed(&sb, vsets, inclLocations)
+		writeMarkdownBaseline(&sb, vsets, inclLocations)
+		return sb.String()
+	}
+	total := 0
+	sb.WriteString("| Severity | Rule | Violations |\n")
+	sb.WriteString("|----------|------|-----------:|\n")
+	for _, sum := range summaries {
+		total += len(sum.vset.Violations)
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %d |\n",
+			sum.severity, sum.name, len(sum.vset.Violations)))
+	}
+	sb.WriteString(fmt.Sprintf("| | **Total** | **%d** |\n", total))
+	if !inclLocations {
+		writeMarkdownSuppressed(&sb, vsets, inclLocations)
+		writeMarkdownBaseline(&sb, vsets, inclLocations)
+		return sb.String()
+	}
+	written := map[string]bool{}
+	for _, sum := range summaries {
+		if written[sum.name] {
+			continue
+		}
+		written[sum.name] = true
+		sb.WriteString(fmt.Sprintf("\n## `%s`\n\n", sum.name))
+		for _, vio := range violationsSorted(vsets.ByRule[sum.name].Violations) {
+			if len(vio.Value) > 0 {
+				sb.WriteString(fmt.Sprintf("1. `%s` `%s`\n", vio.Location, markdownEscapeCode(vio.Value)))
+			} else {
+				sb.WriteString(fmt.Sprintf("1. `%s`\n", vio.Location))
+			}
+		}
+	}
+	writeMarkdownSuppressed(&sb, vsets, inclLocations)
+	writeMarkdownBaseline(&sb, vsets, inclLocations)
+	return sb.String()
+}
+
+// writeMarkdownSuppressed writes a section for violations suppressed via
+// `x-lint-ignore` so they can be audited.
+func writeMarkdownSuppressed(sb *strings.Builder, vsets *lintutil.PolicyViolationsSets, inclLocations bool) {
+	if vsets == nil || vsets.SuppressedCount() == 0 {
+		return
+	}
+	ruleNames := []string{}
+	for ruleName := range vsets.Suppressed {
+		ruleNames = append(ruleNames, ruleName)
+	}
+	sort.Strings(ruleNames)
+	sb.WriteString("\n## Suppressed\n\n")
+	sb.WriteString("| Rule | Suppressed |\n")
+	sb.WriteString("|------|-----------:|\n")
+	for _, ruleName := range ruleNames {
+		sb.WriteString(fmt.Sprintf("| `%s` | %d |\n", ruleName, len(vsets.Suppressed[ruleName].Violations)))
+	}
+	sb.WriteString(fmt.Sprintf("| **Total** | **%d** |\n", vsets.SuppressedCount()))
+	if !inclLocations {
+		return
+	}
+	for _, ruleName := range ruleNames {
+		sb.WriteString(fmt.Sprintf("\n### `%s`\n\n", ruleName))
+		for _, vio := range violationsSorted(vsets.Suppressed[ruleName].Violations) {
+			sb.WriteString(fmt.Sprintf("1. `%s` %s\n", vio.Location, markdownEscapeCode(vio.SuppressionReason)))
+		}
+	}
+}
+
+func markdownEscapeCode(s string) string {
+	return strings.ReplaceAll(s, "`", "'")
+}
+
+// writeMarkdownBaseline writes a section with the number of baseline matches
+// and the baseline entries that have been fixed.
+func writeMarkdownBaseline(sb *strings.Builder, vsets *lintutil.PolicyViolationsSets, inclLocations bool) {
+	if vsets == nil || vsets.Baseline == nil {
+		return
+	}
+	sb.WriteString("\n## Baseline\n\n")
+	sb.WriteString(fmt.Sprintf("* Violations in baseline: %d\n", vsets.Baseline.Matched))
+	sb.WriteString(fmt.Sprintf("* Baseline entries fixed: %d\n", len(vsets.Baseline.Fixed)))
+	if !inclLocations || len(vsets.Baseline.Fixed) == 0 {
+		return
+	}
+	sb.WriteString("\n### Fixed\n\n")
+	for _, entry := range vsets.Baseline.Fixed {
+		sb.WriteString(fmt.Sprintf("1. `%s` `%s`\n", entry.RuleName, entry.Location))
+	}
+}
@@ -0,0 +1,29 @@
+package lintreport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// TestMarkdownSummary ensures rows are grouped by violation severity so
+// violations downgraded by policy overrides are counted at their own severity.
+func TestMarkdownSummary(t *testing.T) {
+	vsets := lintutil.NewPolicyViolationsSets()
+	vsets.AddViolations([]lintutil.PolicyViolation{
+		{RuleName: "operation-summary-exist", Location: "a.yaml#/paths/~1users/get/summary", Severity: "error"},
+		{RuleName: "operation-summary-exist", Location: "a.yaml#/paths/~1legacy/get/summary", Severity: "warning"},
+		{RuleName: "operation-summary-exist", Location: "a.yaml#/paths/~1legacy/post/summary", Severity: "warning"}})
+	md := MarkdownSummary(nil, vsets, true)
+	for _, want := range []string{
+		"| error | `operation-summary-exist` | 1 |\n| warning | `operation-summary-exist` | 2 |\n",
+		"| | **Total** | **3** |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("lintreport.MarkdownSummary() Mismatch: want [%s], got [%s]", want, md)
+		}
+	}
+	if count := strings.Count(md, "## `operation-summary-exist`"); count != 1 {
+		t.Errorf("lintreport.MarkdownSummary() Mismatch: want [1] rule section, got [%d]", count)
+	}
+}
@@ -0,0 +1,68 @@
+package lintreport
+
+import (
+	"sort"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// ruleNamesAll returns the sorted rule names in a policy and violations.
+func ruleNamesAll(pol *openapi3lint.Policy, vsets *lintutil.PolicyViolationsSets) []string {
+	names := map[string]int{}
+	if pol != nil {
+		for _, ruleName := range pol.RuleNames() {
+			names[ruleName]++
+		}
+	}
+	if vsets != nil {
+		for ruleName := range vsets.ByRule {
+			names[ruleName]++
+		}
+	}
+	ruleNames := []string{}
+	for ruleName := range names {
+		ruleNames = append(ruleNames, ruleName)
+	}
+	sort.Strings(ruleNames)
+	return ruleNames
+}
+
+// violationSeverity returns the severity of a violation, which can differ
+// from the rule severity due to policy overrides, falling back to the policy
+// severity for the rule.
+func violationSeverity(pol *openapi3lint.Policy, vio lintutil.PolicyViolation) string {
+	if len(vio.Severity) > 0 {
+		return vio.Severity
+	}
+	if pol != nil {
+		if policyRule, ok := pol.PolicyRule(vio.RuleName); ok {
+			return policyRule.Severity
+		}
+	}
+	return ""
+}
+
+// severityRank returns the rank of a severity where lower is more severe.
+func severityRank(sev string) int {
+	sevCanonical, err := severity.Parse(sev)
+	if err != nil {
+		return len(severity.Severities())
+	}
+	for i, sevTry := range severity.Severities() {
+		if sevTry == sevCanonical {
+			return i
+		}
+	}
+	return len(severity.Severities())
+}
+
+// violationsSorted returns a copy of the violations sorted by location.
+func violationsSorted(vios []lintutil.PolicyViolation) []lintutil.PolicyViolation {
+	sorted := append([]lintutil.PolicyViolation{}, vios...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Location < sorted[j].Location
+	})
+	return sorted
+}
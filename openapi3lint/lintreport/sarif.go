@@ -0,0 +1,258 @@
+package lintreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	SARIFVersion = "2.1.0"
+
+	SARIFLevelError   = "error"
+	SARIFLevelWarning = "warning"
+	SARIFLevelNote    = "note"
+	SARIFLevelNone    = "none"
+
+	ToolName           = "spectrum-oas3lint"
+	ToolInformationURI = "https://github.com/grokify/spectrum"
+)
+
+// SARIFLog is a SARIF 2.1.0 log file. Only the properties used
+// by `openapi3lint` are supported.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version,omitempty"`
+	InformationURI string                     `json:"informationUri,omitempty"`
+	Rules          []SARIFReportingDescriptor `json:"rules"`
+}
+
+type SARIFReportingDescriptor struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *SARIFMessage          `json:"shortDescription,omitempty"`
+	DefaultConfiguration SARIFReportingConfig   `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type SARIFReportingConfig struct {
+	Level string `json:"level"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Level        string                 `json:"level"`
+	Message      SARIFMessage           `json:"message"`
+	Locations    []SARIFLocation        `json:"locations"`
+	Suppressions []SARIFSuppression     `json:"suppressions,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFSuppression represents a result suppressed via `x-lint-ignore`.
+type SARIFSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type SARIFLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// SARIFLevel converts a `github.com/grokify/mogo/log/severity` level
+// to a SARIF result level.
+func SARIFLevel(sev string) string {
+	sevCanonical, err := severity.Parse(sev)
+	if err != nil {
+		return SARIFLevelWarning
+	}
+	switch sevCanonical {
+	case severity.SeverityEmergency, severity.SeverityAlert,
+		severity.SeverityCritical, severity.SeverityError:
+		return SARIFLevelError
+	case severity.SeverityWarning:
+		return SARIFLevelWarning
+	case severity.SeverityDisabled:
+		return SARIFLevelNone
+	default:
+		return SARIFLevelNote
+	}
+}
+
+// NewSARIF returns a SARIF log for a set of policy violations. All rules in
+// the `Policy` are listed as reporting descriptors, along with any rules that
+// are present in the violations but not in the policy.
+func NewSARIF(pol *openapi3lint.Policy, vsets *lintutil.PolicyViolationsSets, toolVersion string) SARIFLog {
+	ruleNames := []string{}
+	if pol != nil {
+		ruleNames = append(ruleNames, pol.RuleNames()...)
+	}
+	ruleIndexes := map[string]int{}
+	for i, ruleName := range ruleNames {
+		ruleIndexes[ruleName] = i
+	}
+	if vsets != nil {
+		vsetRuleNames := []string{}
+		for _, byRule := range []map[string]lintutil.PolicyViolationsSet{vsets.ByRule, vsets.Suppressed} {
+			for ruleName := range byRule {
+				if _, ok := ruleIndexes[ruleName]; !ok {
+					ruleIndexes[ruleName] = -1
+					vsetRuleNames = append(vsetRuleNames, ruleName)
+				}
+			}
+		}
+		sort.Strings(vsetRuleNames)
+		for _, ruleName := range vsetRuleNames {
+			ruleIndexes[ruleName] = len(ruleNames)
+			ruleNames = append(ruleNames, ruleName)
+		}
+	}
+
+	driver := SARIFDriver{
+		Name:           ToolName,
+		Version:        toolVersion,
+		InformationURI: ToolInformationURI,
+		Rules:          []SARIFReportingDescriptor{}}
+	for _, ruleName := range ruleNames {
+		desc := SARIFReportingDescriptor{
+			ID:                   ruleName,
+			Name:                 ruleName,
+			DefaultConfiguration: SARIFReportingConfig{Level: SARIFLevelWarning}}
+		if pol != nil {
+			if policyRule, ok := pol.PolicyRule(ruleName); ok {
+				desc.DefaultConfiguration.Level = SARIFLevel(policyRule.Severity)
+				desc.Properties = map[string]interface{}{
+					"severity": policyRule.Severity,
+					"scope":    policyRule.Rule.Scope()}
+			}
+		}
+		driver.Rules = append(driver.Rules, desc)
+	}
+
+	run := SARIFRun{
+		Tool:    SARIFTool{Driver: driver},
+		Results: []SARIFResult{}}
+	if vsets != nil {
+		for _, ruleName := range ruleNames {
+			vset, ok := vsets.ByRule[ruleName]
+			if !ok {
+				continue
+			}
+			for _, vio := range violationsSorted(vset.Violations) {
+				run.Results = append(run.Results, newSARIFResult(vio, ruleName, ruleIndexes[ruleName]))
+			}
+		}
+		for _, ruleName := range ruleNames {
+			vset, ok := vsets.Suppressed[ruleName]
+			if !ok {
+				continue
+			}
+			for _, vio := range violationsSorted(vset.Violations) {
+				res := newSARIFResult(vio, ruleName, ruleIndexes[ruleName])
+				res.Suppressions = []SARIFSuppression{{
+					Kind:          "inSource",
+					Justification: vio.SuppressionReason}}
+				run.Results = append(run.Results, res)
+			}
+		}
+	}
+
+	return SARIFLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs:    []SARIFRun{run}}
+}
+
+func newSARIFResult(vio lintutil.PolicyViolation, ruleName string, ruleIndex int) SARIFResult {
+	document, pointer := vio.LocationParts()
+	res := SARIFResult{
+		RuleID:    ruleName,
+		RuleIndex: ruleIndex,
+		Level:     SARIFLevel(vio.Severity),
+		Message:   SARIFMessage{Text: ViolationMessage(vio)},
+		Locations: []SARIFLocation{{}},
+		Properties: map[string]interface{}{
+			"jsonPointer": vio.Location}}
+	if len(vio.Severity) > 0 {
+		res.Properties["severity"] = vio.Severity
+	}
+	if len(vio.Value) > 0 {
+		res.Properties["value"] = vio.Value
+	}
+	if len(document) > 0 {
+		res.Locations[0].PhysicalLocation = &SARIFPhysicalLocation{
+			ArtifactLocation: SARIFArtifactLocation{URI: document}}
+		if vio.Line > 0 {
+			res.Locations[0].PhysicalLocation.Region = &SARIFRegion{
+				StartLine:   vio.Line,
+				StartColumn: vio.Column}
+		}
+	}
+	if len(pointer) > 0 {
+		res.Locations[0].LogicalLocations = []SARIFLogicalLocation{{
+			FullyQualifiedName: pointer,
+			Kind:               "object"}}
+	}
+	return res
+}
+
+// ViolationMessage returns a human readable message for a violation.
+func ViolationMessage(vio lintutil.PolicyViolation) string {
+	msg := vio.Violation
+	if len(msg) == 0 {
+		msg = fmt.Sprintf("violates rule [%s]", vio.RuleName)
+	}
+	if len(vio.Value) > 0 {
+		msg += fmt.Sprintf(" value [%s]", vio.Value)
+	}
+	return msg
+}
+
+// MarshalSARIF returns the SARIF JSON encoding for a set of policy violations.
+func MarshalSARIF(pol *openapi3lint.Policy, vsets *lintutil.PolicyViolationsSets, toolVersion, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(NewSARIF(pol, vsets, toolVersion), prefix, indent)
+}
@@ -0,0 +1,56 @@
+package lintreport
+
+import (
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+var sarifLevelTests = []struct {
+	severity string
+	level    string
+}{
+	{"critical", SARIFLevelError},
+	{"error", SARIFLevelError},
+	{"warn", SARIFLevelWarning},
+	{"info", SARIFLevelNote},
+	{"off", SARIFLevelNone},
+}
+
+// TestSARIFLevel ensures severities are mapped to SARIF levels.
+func TestSARIFLevel(t *testing.T) {
+	for _, tt := range sarifLevelTests {
+		got := SARIFLevel(tt.severity)
+		if got != tt.level {
+			t.Errorf("lintreport.SARIFLevel(\"%s\") Mismatch: want [%v], got [%v]",
+				tt.severity, tt.level, got)
+		}
+	}
+}
+
+// TestNewSARIF ensures violation locations are converted to SARIF locations.
+func TestNewSARIF(t *testing.T) {
+	vsets := lintutil.NewPolicyViolationsSets()
+	vsets.AddViolation(lintutil.PolicyViolation{
+		RuleName: "operation-summary-exist",
+		Severity: "err",
+		Location: "spec.yaml#/paths/~1users/get/summary"})
+	log := NewSARIF(nil, vsets, "")
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("lintreport.NewSARIF() Mismatch: want [1] result")
+	}
+	res := log.Runs[0].Results[0]
+	if res.Level != SARIFLevelError {
+		t.Errorf("SARIFResult.Level Mismatch: want [%v], got [%v]", SARIFLevelError, res.Level)
+	}
+	loc := res.Locations[0]
+	if loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation.URI != "spec.yaml" {
+		t.Errorf("SARIFResult.Locations[0].PhysicalLocation Mismatch: want [spec.yaml]")
+	}
+	if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].FullyQualifiedName != "/paths/~1users/get/summary" {
+		t.Errorf("SARIFResult.Locations[0].LogicalLocations Mismatch: want [/paths/~1users/get/summary]")
+	}
+	if len(log.Runs[0].Tool.Driver.Rules) != 1 {
+		t.Errorf("SARIFDriver.Rules Mismatch: want [1], got [%d]", len(log.Runs[0].Tool.Driver.Rules))
+	}
+}
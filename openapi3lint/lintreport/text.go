@@ -0,0 +1,86 @@
+package lintreport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// Text returns a human readable report with one line per violation, sorted
+// by document and line, followed by a summary of counts by severity. Lines
+// use the `document:line:column: severity rule location` format used by
+// compilers so editors can link to the source. `documents` maps the document
+// names in violation locations, e.g. file names, to display names, e.g. file paths.
+func Text(vsets *lintutil.PolicyViolationsSets, documents map[string]string) string {
+	var sb strings.Builder
+	if vsets == nil || vsets.Count() == 0 {
+		sb.WriteString("No violations found.\n")
+		writeTextSuppressed(&sb, vsets)
+		return sb.String()
+	}
+	vios := vsets.Violations()
+	sort.SliceStable(vios, func(i, j int) bool {
+		di, _ := vios[i].LocationParts()
+		dj, _ := vios[j].LocationParts()
+		if di != dj {
+			return di < dj
+		}
+		return vios[i].Line < vios[j].Line
+	})
+	counts := map[string]int{}
+	for _, vio := range vios {
+		document, pointer := vio.LocationParts()
+		if display, ok := documents[document]; ok {
+			document = display
+		}
+		if vio.Line > 0 {
+			document = fmt.Sprintf("%s:%d:%d", document, vio.Line, vio.Column)
+		}
+		sev := vio.Severity
+		if len(sev) == 0 {
+			sev = "unknown"
+		}
+		counts[sev]++
+		sb.WriteString(fmt.Sprintf("%s: %s %s #%s", document, sev, vio.RuleName, pointer))
+		if len(vio.Value) > 0 {
+			sb.WriteString(fmt.Sprintf(" [%s]", vio.Value))
+		}
+		if len(vio.Violation) > 0 {
+			sb.WriteString(": " + vio.Violation)
+		}
+		sb.WriteString("\n")
+	}
+	sevs := []string{}
+	for sev := range counts {
+		sevs = append(sevs, sev)
+	}
+	sort.Slice(sevs, func(i, j int) bool {
+		ri, rj := severityRank(sevs[i]), severityRank(sevs[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return sevs[i] < sevs[j]
+	})
+	parts := []string{}
+	for _, sev := range sevs {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[sev], sev))
+	}
+	sb.WriteString(fmt.Sprintf("\n%d violations (%s)\n", vsets.Count(), strings.Join(parts, ", ")))
+	writeTextSuppressed(&sb, vsets)
+	return sb.String()
+}
+
+func writeTextSuppressed(sb *strings.Builder, vsets *lintutil.PolicyViolationsSets) {
+	if vsets == nil {
+		return
+	}
+	if count := vsets.SuppressedCount(); count > 0 {
+		sb.WriteString(fmt.Sprintf("%d suppressed violations\n", count))
+	}
+	if vsets.Baseline != nil {
+		sb.WriteString(fmt.Sprintf("%d baseline violations, %d fixed\n",
+			vsets.Baseline.Matched, len(vsets.Baseline.Fixed)))
+	}
+}
@@ -0,0 +1,43 @@
+package lintreport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// TestText ensures violations are reported one per line with source positions
+// and a severity summary, and that `--fail-on` counts include higher severities.
+func TestText(t *testing.T) {
+	vsets := lintutil.NewPolicyViolationsSets()
+	vsets.AddViolations([]lintutil.PolicyViolation{
+		{RuleName: "operation-summary-exist", Severity: severity.SeverityError,
+			Location: "a.yaml#/paths/~1users/get/summary", Line: 12, Column: 5},
+		{RuleName: "tag-style-first-uppercase", Severity: severity.SeverityWarning,
+			Location: "a.yaml#/tags/0/name", Line: 4, Column: 7, Value: "users"}})
+	got := Text(vsets, map[string]string{"a.yaml": "specs/a.yaml"})
+	want := strings.Join([]string{
+		"specs/a.yaml:4:7: warning tag-style-first-uppercase #/tags/0/name [users]",
+		"specs/a.yaml:12:5: err operation-summary-exist #/paths/~1users/get/summary",
+		"",
+		"2 violations (1 err, 1 warning)",
+		""}, "\n")
+	if got != want {
+		t.Errorf("lintreport.Text() Mismatch: want [%s], got [%s]", want, got)
+	}
+
+	for sev, want := range map[string]uint{
+		severity.SeverityCritical: 0,
+		severity.SeverityError:    1,
+		severity.SeverityWarning:  2} {
+		count, err := vsets.CountSeverityInclude(sev)
+		if err != nil {
+			t.Fatalf("PolicyViolationsSets.CountSeverityInclude() Error [%s]", err.Error())
+		}
+		if count != want {
+			t.Errorf("PolicyViolationsSets.CountSeverityInclude(\"%s\") Mismatch: want [%d], got [%d]", sev, want, count)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package lintspectral
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"github.com/grokify/spectrum/openapi3lint/ruledeclarative"
+)
+
+// CoreRuleMap maps Spectral `spectral:oas` core rule names to standard
+// `openapi3lint` rule names.
+var CoreRuleMap = map[string]string{
+	"operation-description": lintutil.RuleOpDescExist,
+	"operation-operationId": lintutil.RuleOpIdExist,
+	"operation-summary":     lintutil.RulenameOpSummaryExist,
+	"operation-tag-defined": lintutil.RulenameOpTagsDefined,
+}
+
+// Untranslated is a Spectral rule or ruleset which could not be converted.
+type Untranslated struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Report describes the result of a ruleset conversion. `Mapped` maps Spectral
+// core rules to standard rule names, `Translated` lists custom rules converted
+// to policy config custom rules and `Disabled` lists rules turned off.
+type Report struct {
+	Mapped       map[string]string `json:"mapped"`
+	Translated   []string          `json:"translated"`
+	Disabled     []string          `json:"disabled"`
+	Untranslated []Untranslated    `json:"untranslated"`
+}
+
+func (rpt *Report) untranslated(name, reason string) {
+	rpt.Untranslated = append(rpt.Untranslated, Untranslated{Name: name, Reason: reason})
+}
+
+// Convert builds an `openapi3lint.PolicyConfig` from a Spectral ruleset. Core rules
+// are mapped to standard rules when the ruleset extends `spectral:oas` or sets their
+// severity. Custom rules with `given` and `then` using the `truthy`, `falsy`, `defined`,
+// `undefined`, `pattern`, `casing`, `enumeration` and `length` functions are converted
+// to `PolicyConfig.CustomRules`.
+func Convert(rs Ruleset) (openapi3lint.PolicyConfig, Report) {
+	polCfg := openapi3lint.PolicyConfig{
+		IncludeStandardRules: true,
+		Rules:                map[string]openapi3lint.RuleConfig{},
+		CustomRules:          map[string]ruledeclarative.RuleDefinition{}}
+	rpt := Report{
+		Mapped:       map[string]string{},
+		Translated:   []string{},
+		Disabled:     []string{},
+		Untranslated: []Untranslated{}}
+	stdRules := openapi3lint.NewRuleCollectionStandard()
+
+	coreEnabled := false
+	for _, ext := range rs.Extends {
+		if ext.Ruleset == ExtendsSpectralOAS {
+			if strings.ToLower(ext.Selection) != "off" {
+				coreEnabled = true
+			}
+			continue
+		}
+		rpt.untranslated(ext.Ruleset, "extended rulesets other than `spectral:oas` are not supported")
+	}
+	if coreEnabled {
+		for spectralName, ruleName := range CoreRuleMap {
+			if _, ok := rs.Rules[spectralName]; ok {
+				continue
+			} else if !stdRules.RuleExists(ruleName) {
+				rpt.untranslated(spectralName, fmt.Sprintf("standard rule [%s] is not available", ruleName))
+				continue
+			}
+			polCfg.Rules[ruleName] = openapi3lint.RuleConfig{Severity: severity.SeverityWarning}
+			rpt.Mapped[spectralName] = ruleName
+		}
+	}
+
+	for _, spectralName := range rulesetRuleNames(rs) {
+		rule := rs.Rules[spectralName]
+		sev, err := severity.Parse(rule.SeverityString())
+		if err != nil {
+			rpt.untranslated(spectralName, fmt.Sprintf("unknown severity [%v]", rule.Severity))
+			continue
+		}
+		if rule.Override {
+			ruleName, ok := CoreRuleMap[spectralName]
+			if !ok {
+				rpt.untranslated(spectralName, "no equivalent standard rule")
+				continue
+			} else if !stdRules.RuleExists(ruleName) {
+				rpt.untranslated(spectralName, fmt.Sprintf("standard rule [%s] is not available", ruleName))
+				continue
+			}
+			if sev == severity.SeverityDisabled {
+				delete(polCfg.Rules, ruleName)
+				delete(rpt.Mapped, spectralName)
+				rpt.Disabled = append(rpt.Disabled, spectralName)
+				continue
+			}
+			polCfg.Rules[ruleName] = openapi3lint.RuleConfig{Severity: sev}
+			rpt.Mapped[spectralName] = ruleName
+			continue
+		}
+		if sev == severity.SeverityDisabled {
+			rpt.Disabled = append(rpt.Disabled, spectralName)
+			continue
+		}
+		defs, reason := convertRule(rule, sev)
+		if len(reason) > 0 {
+			rpt.untranslated(spectralName, reason)
+			continue
+		}
+		for i, def := range defs {
+			ruleName := ruleNameKebab(spectralName)
+			if len(defs) > 1 {
+				ruleName += fmt.Sprintf("-%d", i+1)
+			}
+			if _, err := ruledeclarative.NewRule(ruleName, def); err != nil {
+				rpt.untranslated(spectralName, err.Error())
+				continue
+			}
+			polCfg.CustomRules[ruleName] = def
+			rpt.Translated = append(rpt.Translated, ruleName)
+		}
+	}
+	sort.Strings(rpt.Translated)
+	sort.Strings(rpt.Disabled)
+	sort.Slice(rpt.Untranslated, func(i, j int) bool {
+		return rpt.Untranslated[i].Name < rpt.Untranslated[j].Name
+	})
+	return polCfg, rpt
+}
+
+func rulesetRuleNames(rs Ruleset) []string {
+	names := []string{}
+	for name := range rs.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var rxNonKebab = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ruleNameKebab converts a Spectral rule name to a kebab case rule name.
+func ruleNameKebab(name string) string {
+	kebab, err := lintutil.ToCase(stringcase.KebabCase, name)
+	if err != nil {
+		kebab = name
+	}
+	return strings.Trim(rxNonKebab.ReplaceAllString(strings.ToLower(kebab), "-"), "-")
+}
+
+// convertRule converts a custom Spectral rule into one declarative rule
+// per `then`. A non-empty reason is returned if it cannot be converted.
+func convertRule(rule *Rule, sev string) ([]ruledeclarative.RuleDefinition, string) {
+	givens := rule.Givens()
+	if len(givens) != 1 {
+		return nil, "rules must have a single `given`"
+	}
+	target, givenField, ok := ParseGiven(givens[0])
+	if !ok {
+		return nil, fmt.Sprintf("unsupported given [%s]", givens[0])
+	}
+	thens, err := rule.Thens()
+	if err != nil || len(thens) == 0 {
+		return nil, "invalid `then`"
+	}
+	desc := rule.Description
+	if len(desc) == 0 {
+		desc = rule.Message
+	}
+	defs := []ruledeclarative.RuleDefinition{}
+	for _, then := range thens {
+		field := givenField
+		if len(then.Field) > 0 {
+			if len(field) > 0 {
+				return nil, fmt.Sprintf("unsupported nested field [%s.%s]", field, then.Field)
+			}
+			field = then.Field
+		}
+		if field == "@key" {
+			if target != ruledeclarative.TargetSchemaProperty {
+				return nil, "`@key` is only supported for schema properties"
+			}
+			field = ruledeclarative.FieldName
+		}
+		if len(field) == 0 {
+			return nil, "a `field` is required"
+		}
+		assert, reason := convertFunction(then)
+		if len(reason) > 0 {
+			return nil, reason
+		}
+		defs = append(defs, ruledeclarative.RuleDefinition{
+			Description: desc,
+			Severity:    sev,
+			Target:      target,
+			Field:       field,
+			Assert:      assert})
+	}
+	return defs, ""
+}
+
+var (
+	rxGivenOperation      = regexp.MustCompile(`^\$\.paths\[\*\](\[\*\]|\[(get|put|post|delete|options|head|patch|trace|,|'|")+\])(\.(.+))?$`)
+	rxGivenParameter      = regexp.MustCompile(`^\$(\.\.|\.paths\[\*\]\[\*\]\.|\.paths\[\*\]\.|\.components\.)parameters\[\*\](\.(.+))?$`)
+	rxGivenSchemaProperty = regexp.MustCompile(`^\$(\.\.|\.components\.schemas\[\*\]\.)properties\[\*\](\.(.+))?$`)
+	rxGivenTag            = regexp.MustCompile(`^\$\.tags\[\*\](\.(.+))?$`)
+	rxGivenWildcard       = regexp.MustCompile(`\.\*`)
+)
+
+// ParseGiven converts a Spectral `given` JSONPath expression into a declarative
+// rule target and optional field. `ok` is false if the expression is not supported.
+// A field of `@key` indicates the property name.
+func ParseGiven(given string) (target, field string, ok bool) {
+	given = strings.ReplaceAll(strings.TrimSpace(given), " ", "")
+	given = rxGivenWildcard.ReplaceAllString(given, "[*]")
+	if strings.HasSuffix(given, "~") {
+		// `~` selects property names which are only supported for schema properties.
+		if m := rxGivenSchemaProperty.FindStringSubmatch(strings.TrimSuffix(given, "~")); len(m) > 0 && len(m[len(m)-1]) == 0 {
+			return ruledeclarative.TargetSchemaProperty, "@key", true
+		}
+		return "", "", false
+	}
+	if m := rxGivenOperation.FindStringSubmatch(given); len(m) > 0 {
+		return ruledeclarative.TargetOperation, m[len(m)-1], true
+	} else if m := rxGivenParameter.FindStringSubmatch(given); len(m) > 0 {
+		return ruledeclarative.TargetParameter, m[len(m)-1], true
+	} else if m := rxGivenSchemaProperty.FindStringSubmatch(given); len(m) > 0 {
+		return ruledeclarative.TargetSchemaProperty, m[len(m)-1], true
+	} else if m := rxGivenTag.FindStringSubmatch(given); len(m) > 0 {
+		return ruledeclarative.TargetTag, m[len(m)-1], true
+	}
+	return "", "", false
+}
+
+var spectralCasings = map[string]string{
+	"camel":  stringcase.CamelCase,
+	"kebab":  stringcase.KebabCase,
+	"pascal": stringcase.PascalCase,
+	"snake":  stringcase.SnakeCase,
+}
+
+// convertFunction converts a Spectral function into an assertion. A non-empty
+// reason is returned if the function or its options are not supported.
+func convertFunction(then Then) (ruledeclarative.Assertion, string) {
+	assert := ruledeclarative.Assertion{}
+	yes, no := true, false
+	opts := then.FunctionOptions
+	switch then.Function {
+	case FunctionTruthy, FunctionDefined:
+		assert.Exists = &yes
+	case FunctionFalsy, FunctionUndefined:
+		assert.Exists = &no
+	case FunctionPattern:
+		if _, ok := opts["notMatch"]; ok {
+			return assert, "pattern `notMatch` is not supported"
+		}
+		match, ok := opts["match"].(string)
+		if !ok || len(match) == 0 {
+			return assert, "pattern `match` is required"
+		}
+		pattern, err := convertPattern(match)
+		if err != nil {
+			return assert, err.Error()
+		}
+		assert.Pattern = pattern
+	case FunctionCasing:
+		casingType, _ := opts["type"].(string)
+		casing, ok := spectralCasings[casingType]
+		if !ok {
+			return assert, fmt.Sprintf("casing type [%s] is not supported", casingType)
+		}
+		for key := range opts {
+			if key != "type" {
+				return assert, fmt.Sprintf("casing option [%s] is not supported", key)
+			}
+		}
+		assert.Casing = casing
+	case FunctionEnumeration:
+		values, ok := opts["values"].([]interface{})
+		if !ok || len(values) == 0 {
+			return assert, "enumeration `values` is required"
+		}
+		for _, val := range values {
+			assert.Enum = append(assert.Enum, fmt.Sprintf("%v", val))
+		}
+	case FunctionLength:
+		if min, ok := opts["min"].(float64); ok {
+			minInt := int(min)
+			assert.MinLength = &minInt
+		}
+		if max, ok := opts["max"].(float64); ok {
+			maxInt := int(max)
+			assert.MaxLength = &maxInt
+		}
+		if assert.MinLength == nil && assert.MaxLength == nil {
+			return assert, "length `min` or `max` is required"
+		}
+	default:
+		return assert, fmt.Sprintf("function [%s] is not supported", then.Function)
+	}
+	return assert, ""
+}
+
+var rxPatternSlashes = regexp.MustCompile(`^/(.*)/([a-z]*)$`)
+
+// convertPattern converts a Spectral pattern, which may use the `/regex/flags`
+// form, into a Go regular expression.
+func convertPattern(match string) (string, error) {
+	pattern := match
+	if m := rxPatternSlashes.FindStringSubmatch(match); len(m) > 0 {
+		pattern = m[1]
+		flags := strings.NewReplacer("g", "", "u", "").Replace(m[2])
+		if len(flags) > 0 {
+			pattern = "(?" + flags + ")" + pattern
+		}
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return pattern, fmt.Errorf("pattern [%s] is not supported: %s", match, err.Error())
+	}
+	return pattern, nil
+}
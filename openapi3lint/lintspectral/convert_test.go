@@ -0,0 +1,111 @@
+package lintspectral
+
+import (
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"github.com/grokify/spectrum/openapi3lint/ruledeclarative"
+)
+
+const testRuleset = `extends: [[spectral:oas, recommended]]
+rules:
+  operation-summary: error
+  operation-tag-defined: off
+  info-contact: warn
+  paths-kebab-case:
+    given: $.paths[*]~
+    then:
+      function: pattern
+      functionOptions:
+        match: "^(/[a-z0-9-{}]+)+$"
+  operation-id-camel:
+    description: operationId must be camel case.
+    severity: 0
+    given: $.paths.*[get,post,put,delete,patch]
+    then:
+      field: operationId
+      function: casing
+      functionOptions:
+        type: camel
+  summary-uppercase:
+    given: $.paths[*][*].summary
+    then:
+      function: pattern
+      functionOptions:
+        match: /^[A-Z]/
+  tag-description:
+    given: $.tags[*]
+    then:
+      field: description
+      function: truthy
+  property-names-snake:
+    given: $.components.schemas[*].properties[*]~
+    then:
+      function: casing
+      functionOptions:
+        type: snake
+  no-x-internal:
+    given: $.paths[*][*]
+    then:
+      field: x-internal
+      function: schema
+`
+
+// TestConvert ensures core rules are mapped, simple custom rules are translated
+// and other rules are reported as untranslated.
+func TestConvert(t *testing.T) {
+	rs, err := ParseRuleset([]byte(testRuleset))
+	if err != nil {
+		t.Fatalf("ParseRuleset() Error [%s]", err.Error())
+	}
+	polCfg, rpt := Convert(rs)
+
+	if cfg, ok := polCfg.Rules[lintutil.RulenameOpSummaryExist]; !ok || cfg.Severity != severity.SeverityError {
+		t.Errorf("Convert() operation-summary Mismatch: want [%s], got [%v]", severity.SeverityError, cfg)
+	}
+	if _, ok := polCfg.Rules[lintutil.RulenameOpTagsDefined]; ok {
+		t.Errorf("Convert() operation-tag-defined Mismatch: want disabled")
+	}
+	if len(rpt.Disabled) != 1 || rpt.Disabled[0] != "operation-tag-defined" {
+		t.Errorf("Convert() Disabled Mismatch: got [%v]", rpt.Disabled)
+	}
+
+	opID, ok := polCfg.CustomRules["operation-id-camel"]
+	if !ok || opID.Target != ruledeclarative.TargetOperation || opID.Field != "operationId" ||
+		opID.Assert.Casing != stringcase.CamelCase || opID.Severity != severity.SeverityError {
+		t.Errorf("Convert() operation-id-camel Mismatch: got [%v]", opID)
+	}
+	summary, ok := polCfg.CustomRules["summary-uppercase"]
+	if !ok || summary.Field != "summary" || summary.Assert.Pattern != "^[A-Z]" {
+		t.Errorf("Convert() summary-uppercase Mismatch: got [%v]", summary)
+	}
+	if tag, ok := polCfg.CustomRules["tag-description"]; !ok || tag.Assert.Exists == nil || !*tag.Assert.Exists {
+		t.Errorf("Convert() tag-description Mismatch: got [%v]", tag)
+	}
+
+	if prop, ok := polCfg.CustomRules["property-names-snake"]; !ok || prop.Target != ruledeclarative.TargetSchemaProperty ||
+		prop.Field != ruledeclarative.FieldName || prop.Assert.Casing != stringcase.SnakeCase {
+		t.Errorf("Convert() property-names-snake Mismatch: got [%v]", prop)
+	}
+
+	untranslated := map[string]string{}
+	for _, item := range rpt.Untranslated {
+		untranslated[item.Name] = item.Reason
+	}
+	for _, name := range []string{"info-contact", "no-x-internal", "paths-kebab-case"} {
+		if _, ok := untranslated[name]; !ok {
+			t.Errorf("Convert() Untranslated Mismatch: want [%s], got [%v]", name, rpt.Untranslated)
+		}
+	}
+	for _, name := range rpt.Translated {
+		if _, ok := untranslated[name]; ok {
+			t.Errorf("Convert() rule both translated and untranslated [%s]", name)
+		}
+	}
+
+	if _, err := polCfg.Policy(); err != nil {
+		t.Errorf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+}
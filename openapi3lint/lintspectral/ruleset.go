@@ -0,0 +1,211 @@
+package lintspectral
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ExtendsSpectralOAS = "spectral:oas"
+
+	FunctionCasing      = "casing"
+	FunctionDefined     = "defined"
+	FunctionEnumeration = "enumeration"
+	FunctionFalsy       = "falsy"
+	FunctionLength      = "length"
+	FunctionPattern     = "pattern"
+	FunctionTruthy      = "truthy"
+	FunctionUndefined   = "undefined"
+)
+
+// Ruleset is a Spectral ruleset such as `.spectral.yaml`.
+type Ruleset struct {
+	Extends []Extends        `json:"-"`
+	Rules   map[string]*Rule `json:"-"`
+}
+
+// Extends is a ruleset extended by a Spectral ruleset with an optional
+// `recommended`, `all` or `off` rule set selection.
+type Extends struct {
+	Ruleset   string
+	Selection string
+}
+
+// Rule is a Spectral rule. Rules defined as a severity or boolean have
+// `Override` set and no `Given` or `Then`.
+type Rule struct {
+	Description string      `json:"description,omitempty"`
+	Message     string      `json:"message,omitempty"`
+	Severity    interface{} `json:"severity,omitempty"`
+	Recommended *bool       `json:"recommended,omitempty"`
+	Given       interface{} `json:"given,omitempty"`
+	Then        interface{} `json:"then,omitempty"`
+	Override    bool        `json:"-"`
+}
+
+// Then is a Spectral rule `then` item.
+type Then struct {
+	Field           string                 `json:"field,omitempty"`
+	Function        string                 `json:"function"`
+	FunctionOptions map[string]interface{} `json:"functionOptions,omitempty"`
+}
+
+// ReadRulesetFile reads a Spectral ruleset in YAML or JSON format.
+func ReadRulesetFile(filename string) (Ruleset, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return Ruleset{}, err
+	}
+	return ParseRuleset(bytes)
+}
+
+// ParseRuleset parses a Spectral ruleset in YAML or JSON format.
+func ParseRuleset(data []byte) (Ruleset, error) {
+	rs := Ruleset{Extends: []Extends{}, Rules: map[string]*Rule{}}
+	jsonBytes, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return rs, err
+	}
+	raw := struct {
+		Extends json.RawMessage            `json:"extends"`
+		Rules   map[string]json.RawMessage `json:"rules"`
+	}{}
+	if err := json.Unmarshal(jsonBytes, &raw); err != nil {
+		return rs, err
+	}
+	if len(raw.Extends) > 0 {
+		if rs.Extends, err = parseExtends(raw.Extends); err != nil {
+			return rs, err
+		}
+	}
+	for ruleName, ruleRaw := range raw.Rules {
+		rule, err := parseRule(ruleRaw)
+		if err != nil {
+			return rs, fmt.Errorf("invalid rule [%s]: %s", ruleName, err.Error())
+		}
+		rs.Rules[ruleName] = rule
+	}
+	return rs, nil
+}
+
+// parseExtends parses `extends` which can be a string, a list of strings,
+// or a list including `[ruleset, selection]` pairs.
+func parseExtends(data json.RawMessage) ([]Extends, error) {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		return []Extends{{Ruleset: single}}, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid extends [%s]", string(data))
+	}
+	exts := []Extends{}
+	for _, item := range items {
+		var name string
+		if err := json.Unmarshal(item, &name); err == nil {
+			exts = append(exts, Extends{Ruleset: name})
+			continue
+		}
+		var pair []string
+		if err := json.Unmarshal(item, &pair); err != nil || len(pair) == 0 {
+			return nil, fmt.Errorf("invalid extends [%s]", string(item))
+		}
+		ext := Extends{Ruleset: pair[0]}
+		if len(pair) > 1 {
+			ext.Selection = pair[1]
+		}
+		exts = append(exts, ext)
+	}
+	return exts, nil
+}
+
+// parseRule parses a rule which can be a severity, a boolean or a rule object.
+func parseRule(data json.RawMessage) (*Rule, error) {
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err == nil {
+		rule := &Rule{Override: true, Severity: "off"}
+		if enabled {
+			rule.Severity = nil
+		}
+		return rule, nil
+	}
+	var sev interface{}
+	if err := json.Unmarshal(data, &sev); err == nil {
+		switch sev.(type) {
+		case string, float64:
+			return &Rule{Override: true, Severity: sev}, nil
+		}
+	}
+	rule := &Rule{}
+	if err := json.Unmarshal(data, rule); err != nil {
+		return nil, err
+	}
+	if rule.Given == nil && rule.Then == nil {
+		rule.Override = true
+	}
+	return rule, nil
+}
+
+// Thens returns the rule `then` as a slice.
+func (rule *Rule) Thens() ([]Then, error) {
+	if rule.Then == nil {
+		return []Then{}, nil
+	}
+	bytes, err := json.Marshal(rule.Then)
+	if err != nil {
+		return nil, err
+	}
+	thens := []Then{}
+	if strings.HasPrefix(strings.TrimSpace(string(bytes)), "[") {
+		err = json.Unmarshal(bytes, &thens)
+	} else {
+		then := Then{}
+		err = json.Unmarshal(bytes, &then)
+		thens = append(thens, then)
+	}
+	return thens, err
+}
+
+// Givens returns the rule `given` JSONPath expressions as a slice.
+func (rule *Rule) Givens() []string {
+	switch given := rule.Given.(type) {
+	case string:
+		return []string{given}
+	case []interface{}:
+		givens := []string{}
+		for _, item := range given {
+			if s, ok := item.(string); ok {
+				givens = append(givens, s)
+			}
+		}
+		return givens
+	}
+	return []string{}
+}
+
+// SeverityString returns the rule severity as a `github.com/grokify/mogo/log/severity`
+// compatible string. Spectral numeric severities are `0` (error) to `3` (hint).
+// The Spectral default severity `warn` is used when none is set.
+func (rule *Rule) SeverityString() string {
+	switch sev := rule.Severity.(type) {
+	case string:
+		return strings.ToLower(strings.TrimSpace(sev))
+	case float64:
+		switch int(sev) {
+		case 0:
+			return "error"
+		case 1:
+			return "warn"
+		case 2:
+			return "info"
+		case 3:
+			return "hint"
+		}
+		return "off"
+	}
+	return "warn"
+}
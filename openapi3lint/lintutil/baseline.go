@@ -0,0 +1,258 @@
+package lintutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BaselineEntry identifies an accepted violation by rule name, location and
+// a fingerprint of the violation value.
+type BaselineEntry struct {
+	RuleName         string `json:"ruleName"`
+	Location         string `json:"location"`
+	ValueFingerprint string `json:"valueFingerprint,omitempty"`
+}
+
+func (entry BaselineEntry) key() string {
+	return strings.Join([]string{entry.RuleName, entry.Location, entry.ValueFingerprint}, "\n")
+}
+
+// Document returns the document portion of the entry location.
+func (entry BaselineEntry) Document() string {
+	document, _ := (&PolicyViolation{Location: entry.Location}).LocationParts()
+	return document
+}
+
+// NewBaselineEntry returns the `BaselineEntry` for a violation.
+func NewBaselineEntry(vio PolicyViolation) BaselineEntry {
+	return BaselineEntry{
+		RuleName:         vio.RuleName,
+		Location:         vio.Location,
+		ValueFingerprint: ValueFingerprint(vio.Value)}
+}
+
+// ValueFingerprint returns a short SHA-256 fingerprint for a violation value.
+// An empty value has an empty fingerprint.
+func ValueFingerprint(value string) string {
+	if len(value) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Baseline is a set of accepted violations. Violations in the baseline are
+// not reported so that only new violations fail a build. Baselines for files
+// use entry documents that are file paths relative to `Dir`, which is set by
+// `ReadBaselineFile()` to the directory of the baseline file.
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+	Dir     string          `json:"-"`
+}
+
+// NewBaseline creates a `Baseline` from the violations in `ByRule`.
+func NewBaseline(sets *PolicyViolationsSets) Baseline {
+	bl := Baseline{Entries: []BaselineEntry{}}
+	if sets == nil {
+		return bl
+	}
+	for _, set := range sets.ByRule {
+		for _, vio := range set.Violations {
+			if len(vio.RuleName) == 0 {
+				vio.RuleName = set.RuleName
+			}
+			bl.Entries = append(bl.Entries, NewBaselineEntry(vio))
+		}
+	}
+	bl.Sort()
+	return bl
+}
+
+// NewBaselineFiles creates a `Baseline` from the violations for `files` with
+// entry documents set to file paths relative to `dir`, typically the directory
+// of the baseline file. Violation documents are `FileDocument()` paths as used
+// by `Policy.ValidateSpecFiles()`.
+func NewBaselineFiles(sets *PolicyViolationsSets, dir string, files []string) (Baseline, error) {
+	docs, err := baselineDocuments(dir, files)
+	if err != nil {
+		return Baseline{Entries: []BaselineEntry{}, Dir: dir}, err
+	}
+	bl := NewBaseline(RelocateViolations(sets, docs))
+	bl.Dir = dir
+	return bl, nil
+}
+
+// ReadBaselineFile reads a JSON baseline file and sets `Dir` to the directory
+// of the file.
+func ReadBaselineFile(filename string) (Baseline, error) {
+	bl := Baseline{Dir: filepath.Dir(filename)}
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return bl, err
+	}
+	err = json.Unmarshal(bytes, &bl)
+	if bl.Entries == nil {
+		bl.Entries = []BaselineEntry{}
+	}
+	return bl, err
+}
+
+// WriteFile writes the baseline as JSON.
+func (bl *Baseline) WriteFile(filename string, perm os.FileMode) error {
+	bl.Sort()
+	bytes, err := json.MarshalIndent(bl, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(bytes, '\n'), perm)
+}
+
+// Sort sorts entries by location, rule name and fingerprint.
+func (bl *Baseline) Sort() {
+	sort.Slice(bl.Entries, func(i, j int) bool {
+		if bl.Entries[i].Location != bl.Entries[j].Location {
+			return bl.Entries[i].Location < bl.Entries[j].Location
+		}
+		if bl.Entries[i].RuleName != bl.Entries[j].RuleName {
+			return bl.Entries[i].RuleName < bl.Entries[j].RuleName
+		}
+		return bl.Entries[i].ValueFingerprint < bl.Entries[j].ValueFingerprint
+	})
+}
+
+// BaselineResult summarizes the comparison of violations with a baseline.
+// `Fixed` contains baseline entries that no longer have a violation.
+type BaselineResult struct {
+	Matched uint            `json:"matched"`
+	Fixed   []BaselineEntry `json:"fixed"`
+}
+
+// Filter returns the violations that are not in the baseline. Baseline entries
+// for `documents` which are not matched are returned as fixed. If `documents`
+// is empty, all unmatched entries are considered fixed. Suppressed violations
+// are carried over unchanged.
+func (bl *Baseline) Filter(sets *PolicyViolationsSets, documents []string) *PolicyViolationsSets {
+	res := &BaselineResult{Fixed: []BaselineEntry{}}
+	out := NewPolicyViolationsSets()
+	out.Baseline = res
+
+	remaining := map[string]int{}
+	for _, entry := range bl.Entries {
+		remaining[entry.key()]++
+	}
+	if sets != nil {
+		for _, set := range sets.Suppressed {
+			for _, vio := range set.Violations {
+				out.AddSuppressed(vio)
+			}
+		}
+		for _, set := range sets.ByRule {
+			for _, vio := range set.Violations {
+				if len(vio.RuleName) == 0 {
+					vio.RuleName = set.RuleName
+				}
+				key := NewBaselineEntry(vio).key()
+				if remaining[key] > 0 {
+					remaining[key]--
+					res.Matched++
+					continue
+				}
+				out.AddViolation(vio)
+			}
+		}
+	}
+
+	docs := map[string]bool{}
+	for _, doc := range documents {
+		docs[doc] = true
+	}
+	for _, entry := range bl.Entries {
+		key := entry.key()
+		if remaining[key] == 0 {
+			continue
+		}
+		if len(docs) > 0 && !docs[entry.Document()] {
+			continue
+		}
+		remaining[key]--
+		res.Fixed = append(res.Fixed, entry)
+	}
+	return out
+}
+
+// FilterFiles is like `Filter()` for the violations of `files`, where violation
+// documents are `FileDocument()` paths and baseline entry documents are file
+// paths relative to `Dir`. Returned violations keep their locations and fixed entries keep
+// their baseline locations.
+func (bl *Baseline) FilterFiles(sets *PolicyViolationsSets, files []string) (*PolicyViolationsSets, error) {
+	docs, err := baselineDocuments(bl.Dir, files)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	documents := []string{}
+	for name, doc := range docs {
+		names[doc] = name
+		documents = append(documents, doc)
+	}
+	filtered := bl.Filter(RelocateViolations(sets, docs), documents)
+	out := RelocateViolations(filtered, names)
+	out.Baseline = filtered.Baseline
+	return out, nil
+}
+
+// baselineDocuments returns a map of `FileDocument()` paths to file paths
+// relative to `dir` using forward slashes.
+func baselineDocuments(dir string, files []string) (map[string]string, error) {
+	if len(dir) == 0 {
+		dir = "."
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	docs := map[string]string{}
+	for _, file := range files {
+		absFile, err := filepath.Abs(file)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(absDir, absFile)
+		if err != nil {
+			return nil, err
+		}
+		docs[FileDocument(file)] = filepath.ToSlash(rel)
+	}
+	return docs, nil
+}
+
+// relocateViolations returns a copy of `sets` with violation documents replaced
+// using `docs`, a map of current to new documents.
+func RelocateViolations(sets *PolicyViolationsSets, docs map[string]string) *PolicyViolationsSets {
+	out := NewPolicyViolationsSets()
+	if sets == nil {
+		return out
+	}
+	for _, setsMaps := range [][2]map[string]PolicyViolationsSet{
+		{sets.ByRule, out.ByRule},
+		{sets.Suppressed, out.Suppressed}} {
+		for ruleName, set := range setsMaps[0] {
+			vios := make([]PolicyViolation, len(set.Violations))
+			for i, vio := range set.Violations {
+				document, _ := vio.LocationParts()
+				if doc, ok := docs[document]; ok {
+					vio.Location = doc + strings.TrimPrefix(vio.Location, document)
+				}
+				vios[i] = vio
+			}
+			set.Violations = vios
+			setsMaps[1][ruleName] = set
+		}
+	}
+	return out
+}
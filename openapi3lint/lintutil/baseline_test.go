@@ -0,0 +1,102 @@
+package lintutil
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestBaselineFilter ensures baseline violations are filtered, new violations
+// are reported and unmatched entries for linted documents are fixed.
+func TestBaselineFilter(t *testing.T) {
+	old := NewPolicyViolationsSets()
+	old.AddSimple("rule-a", "a.json#/paths/~1users/get", "GetUsers")
+	old.AddSimple("rule-a", "a.json#/paths/~1users/post", "PostUsers")
+	old.AddSimple("rule-b", "b.json#/paths/~1accounts/get", "GetAccounts")
+	bl := NewBaseline(old)
+	if len(bl.Entries) != 3 {
+		t.Fatalf("NewBaseline() Mismatch: want [%d], got [%d]", 3, len(bl.Entries))
+	}
+
+	cur := NewPolicyViolationsSets()
+	cur.AddSimple("rule-a", "a.json#/paths/~1users/get", "GetUsers")
+	cur.AddSimple("rule-a", "a.json#/paths/~1users/put", "PutUsers")
+	// value change is a new violation.
+	cur.AddSimple("rule-b", "b.json#/paths/~1accounts/get", "ListAccounts")
+
+	got := bl.Filter(cur, []string{"a.json"})
+	if got.Count() != 2 {
+		t.Errorf("Baseline.Filter() Count Mismatch: want [%d], got [%d]", 2, got.Count())
+	}
+	if got.Baseline == nil || got.Baseline.Matched != 1 {
+		t.Fatalf("Baseline.Filter() Matched Mismatch: want [%d], got [%v]", 1, got.Baseline)
+	}
+	if len(got.Baseline.Fixed) != 1 || got.Baseline.Fixed[0].Location != "a.json#/paths/~1users/post" {
+		t.Errorf("Baseline.Filter() Fixed Mismatch: want [%s], got [%v]", "a.json#/paths/~1users/post", got.Baseline.Fixed)
+	}
+}
+
+// TestBaselineFiles ensures baseline entries use file paths relative to the
+// baseline file and files with the same name in different directories are
+// distinct.
+func TestBaselineFiles(t *testing.T) {
+	dir := t.TempDir()
+	blFile := filepath.Join(dir, "lint", "baseline.json")
+	specFile := filepath.Join(dir, "specs", "v1", "openapi.yaml")
+	other := filepath.Join(dir, "specs", "v2", "openapi.yaml")
+	doc := FileDocument(specFile)
+	otherDoc := FileDocument(other)
+
+	old := NewPolicyViolationsSets()
+	old.AddSimple("rule-a", doc+"#/paths/~1users/get", "GetUsers")
+	old.AddSimple("rule-a", doc+"#/paths/~1users/post", "PostUsers")
+	old.AddSimple("rule-a", otherDoc+"#/paths/~1users/delete", "DeleteUsers")
+	bl, err := NewBaselineFiles(old, filepath.Dir(blFile), []string{specFile, other})
+	if err != nil {
+		t.Fatalf("NewBaselineFiles() Error [%s]", err.Error())
+	}
+	if len(bl.Entries) != 3 || bl.Entries[0].Location != "../specs/v1/openapi.yaml#/paths/~1users/get" ||
+		bl.Entries[2].Location != "../specs/v2/openapi.yaml#/paths/~1users/delete" {
+		t.Fatalf("NewBaselineFiles() Mismatch: want [%s,%s], got [%v]", "../specs/v1/openapi.yaml#/paths/~1users/get",
+			"../specs/v2/openapi.yaml#/paths/~1users/delete", bl.Entries)
+	}
+	if err := os.MkdirAll(filepath.Dir(blFile), 0700); err != nil {
+		t.Fatalf("os.MkdirAll() Error [%s]", err.Error())
+	}
+	if err := bl.WriteFile(blFile, 0600); err != nil {
+		t.Fatalf("Baseline.WriteFile() Error [%s]", err.Error())
+	}
+	bl, err = ReadBaselineFile(blFile)
+	if err != nil {
+		t.Fatalf("ReadBaselineFile() Error [%s]", err.Error())
+	}
+
+	cur := NewPolicyViolationsSets()
+	cur.AddSimple("rule-a", doc+"#/paths/~1users/get", "GetUsers")
+	cur.AddSimple("rule-a", doc+"#/paths/~1users/put", "PutUsers")
+	cur.AddSimple("rule-a", otherDoc+"#/paths/~1users/get", "GetUsers")
+	got, err := bl.FilterFiles(cur, []string{specFile, other})
+	if err != nil {
+		t.Fatalf("Baseline.FilterFiles() Error [%s]", err.Error())
+	}
+	// files with the same name in different directories are matched separately.
+	locations := []string{}
+	for _, vio := range got.ByRule["rule-a"].Violations {
+		locations = append(locations, vio.Location)
+	}
+	sort.Strings(locations)
+	want := []string{doc + "#/paths/~1users/put", otherDoc + "#/paths/~1users/get"}
+	sort.Strings(want)
+	if strings.Join(locations, ",") != strings.Join(want, ",") {
+		t.Errorf("Baseline.FilterFiles() Mismatch: want [%v], got [%v]", want, locations)
+	}
+	if got.Baseline.Matched != 1 || len(got.Baseline.Fixed) != 2 ||
+		got.Baseline.Fixed[0].Location != "../specs/v1/openapi.yaml#/paths/~1users/post" ||
+		got.Baseline.Fixed[1].Location != "../specs/v2/openapi.yaml#/paths/~1users/delete" {
+		t.Errorf("Baseline.FilterFiles() Baseline Mismatch: want matched [1] fixed [%s,%s], got [%d] [%v]",
+			"../specs/v1/openapi.yaml#/paths/~1users/post", "../specs/v2/openapi.yaml#/paths/~1users/delete",
+			got.Baseline.Matched, got.Baseline.Fixed)
+	}
+}
@@ -0,0 +1,39 @@
+package lintutil
+
+import (
+	"context"
+	"sync"
+)
+
+type specCacheKey struct{}
+
+// specCache holds values computed from a spec during a single validation.
+type specCache struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// WithSpecCache returns a context with an empty cache for values computed from
+// a spec. `Policy.ValidateSpecContext()` adds a new cache for each validation
+// so cached values are not reused after a spec is modified.
+func WithSpecCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, specCacheKey{}, &specCache{values: map[string]interface{}{}})
+}
+
+// SpecCacheValue returns the value for `key` from the cache in `ctx`, calling
+// `fn` to compute and cache it on first use. If `ctx` has no cache, `fn` is
+// called each time.
+func SpecCacheValue(ctx context.Context, key string, fn func() interface{}) interface{} {
+	cache, ok := ctx.Value(specCacheKey{}).(*specCache)
+	if !ok || cache == nil {
+		return fn()
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if val, ok := cache.values[key]; ok {
+		return val
+	}
+	val := fn()
+	cache.values[key] = val
+	return val
+}
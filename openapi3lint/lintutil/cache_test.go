@@ -0,0 +1,28 @@
+package lintutil
+
+import (
+	"context"
+	"testing"
+)
+
+// TestSpecCacheValue ensures values are computed once per cache and each time
+// without a cache.
+func TestSpecCacheValue(t *testing.T) {
+	calls := 0
+	fn := func() interface{} {
+		calls++
+		return calls
+	}
+	ctx := WithSpecCache(context.Background())
+	for i := 0; i < 2; i++ {
+		if got := SpecCacheValue(ctx, "key", fn); got != 1 {
+			t.Errorf("SpecCacheValue() Mismatch: want [1], got [%v]", got)
+		}
+	}
+	if got := SpecCacheValue(WithSpecCache(context.Background()), "key", fn); got != 2 {
+		t.Errorf("SpecCacheValue() new cache Mismatch: want [2], got [%v]", got)
+	}
+	if got := SpecCacheValue(context.Background(), "key", fn); got != 3 {
+		t.Errorf("SpecCacheValue() no cache Mismatch: want [3], got [%v]", got)
+	}
+}
@@ -0,0 +1,75 @@
+package lintutil
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/grokify/mogo/text/stringcase"
+)
+
+// SplitWords splits a string into words on non-alphanumeric characters
+// and on case changes, e.g. `getHTTPServer_info` becomes `get`, `HTTP`,
+// `Server` and `info`.
+func SplitWords(s string) []string {
+	words := []string{}
+	for _, token := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(token)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			prev, cur := runes[i-1], runes[i]
+			if unicode.IsUpper(cur) &&
+				(unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+					(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// ToCase converts a string to a `github.com/grokify/mogo/text/stringcase`
+// case using word boundaries from `SplitWords()`.
+func ToCase(caseType, s string) (string, error) {
+	caseTypeCanonical, err := stringcase.Parse(caseType)
+	if err != nil {
+		return s, err
+	}
+	words := SplitWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	switch caseTypeCanonical {
+	case stringcase.CamelCase, stringcase.PascalCase:
+		for i, word := range words {
+			if i == 0 && caseTypeCanonical == stringcase.CamelCase {
+				continue
+			}
+			words[i] = upperFirst(word)
+		}
+		return strings.Join(words, ""), nil
+	case stringcase.KebabCase:
+		return strings.Join(words, "-"), nil
+	case stringcase.SnakeCase:
+		return strings.Join(words, "_"), nil
+	}
+	return s, fmt.Errorf("unknown string case type [%s]", caseType)
+}
+
+// ToFirstAlphaUpper capitalizes the first character if it is a letter.
+func ToFirstAlphaUpper(s string) string {
+	return upperFirst(s)
+}
+
+func upperFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
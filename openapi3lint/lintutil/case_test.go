@@ -0,0 +1,35 @@
+package lintutil
+
+import (
+	"testing"
+
+	"github.com/grokify/mogo/text/stringcase"
+)
+
+var toCaseTests = []struct {
+	caseType string
+	v        string
+	want     string
+}{
+	{stringcase.CamelCase, "GetUserInfo", "getUserInfo"},
+	{stringcase.CamelCase, "get_user_info", "getUserInfo"},
+	{stringcase.CamelCase, "getHTTPServer", "getHttpServer"},
+	{stringcase.KebabCase, "getUserID", "get-user-id"},
+	{stringcase.PascalCase, "list-account-users", "ListAccountUsers"},
+	{stringcase.SnakeCase, "extensionId", "extension_id"},
+	{stringcase.SnakeCase, "Version2Info", "version2_info"},
+}
+
+// TestToCase ensures strings are converted using word boundaries.
+func TestToCase(t *testing.T) {
+	for _, tt := range toCaseTests {
+		got, err := ToCase(tt.caseType, tt.v)
+		if err != nil {
+			t.Errorf("lintutil.ToCase(\"%s\",\"%s\") Error [%s]", tt.caseType, tt.v, err.Error())
+		}
+		if got != tt.want {
+			t.Errorf("lintutil.ToCase(\"%s\",\"%s\") Mismatch: want [%v], got [%v]",
+				tt.caseType, tt.v, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package lintutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	FixTypeOperationID      = "operation-operationid"
+	FixTypeOperationSummary = "operation-summary"
+	FixTypePathParamRename  = "path-param-rename"
+	FixTypeTagRename        = "tag-rename"
+)
+
+// Fix is a concrete edit to a spec returned by rules that can fix their own
+// violations. `Path` and `Method` identify the operation for operation fixes
+// and `Path` identifies the path for path parameter renames.
+type Fix struct {
+	RuleName string `json:"ruleName"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
+	Path     string `json:"path,omitempty"`
+	Method   string `json:"method,omitempty"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
+
+// Key returns the fix target. Fixes with the same key edit the same value.
+func (fix Fix) Key() string {
+	switch fix.Type {
+	case FixTypeTagRename:
+		return fix.Type + " " + fix.OldValue
+	case FixTypePathParamRename:
+		return fix.Type + " " + fix.Path + " " + fix.OldValue
+	default:
+		return fix.Type + " " + fix.Path + " " + strings.ToUpper(fix.Method)
+	}
+}
+
+// String returns a human readable description of the fix.
+func (fix Fix) String() string {
+	return fmt.Sprintf("%s [%s] [%s] => [%s]", fix.RuleName, fix.Location, fix.OldValue, fix.NewValue)
+}
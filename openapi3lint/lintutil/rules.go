@@ -2,6 +2,7 @@ package lintutil
 
 const (
 	RulenameDatatypeIntFormatStandardExist = "datatype-int-format-standard-exist"
+	RulenameExampleSchemaValid             = "example-schema-valid"
 	RuleOpDescExist                        = "operation-description-exist"
 	RuleOpIdExist                          = "operation-operationid-exist"
 
@@ -10,14 +11,24 @@ const (
 	RulenameOpIdStylePascalCase = "operation-operationid-style-pascalcase"
 	RulenameOpIdStyleSnakeCase  = "operation-operationid-style-snakecase"
 
+	RulenameSchemaNameStyleCamelCase  = "schema-name-style-camelcase"
+	RulenameSchemaNameStyleKebabCase  = "schema-name-style-kebabcase"
 	RulenameSchemaNameStylePascalCase = "schema-name-style-pascalcase"
+	RulenameSchemaNameStyleSnakeCase  = "schema-name-style-snakecase"
+	RulenameSchemaDuplicate           = "schema-duplicate"
 	RulenameSchemaHasReference        = "schema-has-reference"
 	RulenameSchemaReferenceHasSchema  = "schema-reference-has-schema"
 
 	RulenameOpSummaryExist               = "operation-summary-exist"
 	RulenameOpSummaryStyleFirstUpperCase = "operation-summary-style-first-uppercase"
 
+	RulenameOpResponseErrorExist   = "operation-response-error-exist"
+	RulenameOpResponseErrorSchema  = "operation-response-error-schema"
+	RulenameOpResponseNoContent    = "operation-response-no-content"
+	RulenameOpResponseSuccessExist = "operation-response-success-exist"
+
 	RuleOpTagsCountOneOnly = "operation-tags-count-one"
+	RulenameOpTagsDefined  = "operation-tags-defined"
 	RulePathParamNameExist = "path-param-name-exist"
 
 	RulenamePathParamStyleCamelCase  = "path-param-style-camelcase"
@@ -6,17 +6,46 @@ import (
 )
 
 const (
-	ScopeOperation     = "operation"
-	ScopeSpecification = "specification"
+	ScopeOperation      = "operation"
+	ScopeParameter      = "parameter"
+	ScopeRequestBody    = "request-body"
+	ScopeResponse       = "response"
+	ScopeSchema         = "schema"
+	ScopeSchemaProperty = "schema-property"
+	ScopeSpecification  = "specification"
+	ScopeTag            = "tag"
 )
 
 var mapStringScope = map[string]string{
-	"operation":     ScopeOperation,
-	"oper":          ScopeOperation,
-	"op":            ScopeOperation,
-	"specification": ScopeSpecification,
-	"spec":          ScopeSpecification,
-	"":              ScopeSpecification,
+	"operation":       ScopeOperation,
+	"oper":            ScopeOperation,
+	"op":              ScopeOperation,
+	"parameter":       ScopeParameter,
+	"param":           ScopeParameter,
+	"request-body":    ScopeRequestBody,
+	"requestbody":     ScopeRequestBody,
+	"response":        ScopeResponse,
+	"schema":          ScopeSchema,
+	"schema-property": ScopeSchemaProperty,
+	"property":        ScopeSchemaProperty,
+	"prop":            ScopeSchemaProperty,
+	"specification":   ScopeSpecification,
+	"spec":            ScopeSpecification,
+	"":                ScopeSpecification,
+	"tag":             ScopeTag,
+}
+
+// Scopes returns the list of canonical scopes.
+func Scopes() []string {
+	return []string{
+		ScopeOperation,
+		ScopeParameter,
+		ScopeRequestBody,
+		ScopeResponse,
+		ScopeSchema,
+		ScopeSchemaProperty,
+		ScopeSpecification,
+		ScopeTag}
 }
 
 func ParseScope(s string) (string, error) {
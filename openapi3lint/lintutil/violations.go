@@ -3,20 +3,29 @@ package lintutil
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/grokify/mogo/log/severity"
 	"github.com/grokify/mogo/type/stringsutil"
 )
 
 // PolicyViolationsSets is a container for `openapi3lint` policy violations.
 // Common approaches to view violatiosn are to use `PolicyViolationsSets.LocationsByRule()`
-// and `PolicyViolationsSets.CountsByRule()`.
+// and `PolicyViolationsSets.CountsByRule()`. Violations suppressed via `x-lint-ignore`
+// are held in `Suppressed` and are not included in `ByRule` counts. `Baseline` is
+// set when violations have been filtered by a `Baseline`.
 type PolicyViolationsSets struct {
-	ByRule map[string]PolicyViolationsSet
+	ByRule     map[string]PolicyViolationsSet
+	Suppressed map[string]PolicyViolationsSet
+	Baseline   *BaselineResult
 }
 
 func NewPolicyViolationsSets() *PolicyViolationsSets {
 	return &PolicyViolationsSets{
-		ByRule: map[string]PolicyViolationsSet{}}
+		ByRule:     map[string]PolicyViolationsSet{},
+		Suppressed: map[string]PolicyViolationsSet{}}
 }
 
 func (sets *PolicyViolationsSets) AddViolations(violations []PolicyViolation) {
@@ -25,6 +34,17 @@ func (sets *PolicyViolationsSets) AddViolations(violations []PolicyViolation) {
 	}
 }
 
+// AddViolationsWithSeverity adds violations, setting the severity
+// for violations that do not have one.
+func (sets *PolicyViolationsSets) AddViolationsWithSeverity(violations []PolicyViolation, sev string) {
+	for _, vio := range violations {
+		if len(vio.Severity) == 0 {
+			vio.Severity = sev
+		}
+		sets.AddViolation(vio)
+	}
+}
+
 func (sets *PolicyViolationsSets) AddViolation(violation PolicyViolation) {
 	set, ok := sets.ByRule[violation.RuleName]
 	if !ok {
@@ -34,6 +54,41 @@ func (sets *PolicyViolationsSets) AddViolation(violation PolicyViolation) {
 	sets.ByRule[violation.RuleName] = set
 }
 
+// AddSuppressed adds a violation that has been suppressed.
+func (sets *PolicyViolationsSets) AddSuppressed(violation PolicyViolation) {
+	if sets.Suppressed == nil {
+		sets.Suppressed = map[string]PolicyViolationsSet{}
+	}
+	set, ok := sets.Suppressed[violation.RuleName]
+	if !ok {
+		set = NewPolicyViolationsSet(violation.RuleName)
+	}
+	set.Violations = append(set.Violations, violation)
+	sets.Suppressed[violation.RuleName] = set
+}
+
+// Suppress moves violations for which `suppressed` returns a non-empty
+// reason from `ByRule` to `Suppressed`.
+func (sets *PolicyViolationsSets) Suppress(suppressed func(vio PolicyViolation) string) {
+	for ruleName, set := range sets.ByRule {
+		keep := []PolicyViolation{}
+		for _, vio := range set.Violations {
+			if reason := suppressed(vio); len(reason) > 0 {
+				vio.SuppressionReason = reason
+				sets.AddSuppressed(vio)
+			} else {
+				keep = append(keep, vio)
+			}
+		}
+		if len(keep) == 0 {
+			delete(sets.ByRule, ruleName)
+		} else {
+			set.Violations = keep
+			sets.ByRule[ruleName] = set
+		}
+	}
+}
+
 func (sets *PolicyViolationsSets) AddSimple(ruleName, location, value string) {
 	set, ok := sets.ByRule[ruleName]
 	if !ok {
@@ -56,14 +111,13 @@ func (sets *PolicyViolationsSets) UpsertSet(upsertSet PolicyViolationsSet) error
 		if len(ruleName) == 0 {
 			return errors.New("violation & violationSet have no RuleName")
 		}
-		existingSet, ok := sets.ByRule[upsertSet.RuleName]
+		existingSet, ok := sets.ByRule[ruleName]
 		if !ok {
-			sets.ByRule[upsertSet.RuleName] = upsertSet
-		} else {
-			existingSet.Violations = append(
-				existingSet.Violations, vio)
-			sets.ByRule[ruleName] = existingSet
+			existingSet = NewPolicyViolationsSet(ruleName)
 		}
+		existingSet.Violations = append(
+			existingSet.Violations, vio)
+		sets.ByRule[ruleName] = existingSet
 	}
 	return nil
 }
@@ -81,6 +135,14 @@ func (sets *PolicyViolationsSets) UpsertSets(upsertSets *PolicyViolationsSets) e
 			return err
 		}
 	}
+	for _, upsertSet := range upsertSets.Suppressed {
+		for _, vio := range upsertSet.Violations {
+			if len(vio.RuleName) == 0 {
+				vio.RuleName = upsertSet.RuleName
+			}
+			sets.AddSuppressed(vio)
+		}
+	}
 	return nil
 }
 
@@ -122,6 +184,64 @@ func (sets *PolicyViolationsSets) CountsByRule() map[string]uint {
 	return counts
 }
 
+// CountSeverityInclude returns the number of violations at or above
+// `filterSeverity`, e.g. to fail a CI build on `error` violations.
+func (sets *PolicyViolationsSets) CountSeverityInclude(filterSeverity string) (uint, error) {
+	if _, err := severity.Parse(filterSeverity); err != nil {
+		return 0, err
+	}
+	count := uint(0)
+	for _, set := range sets.ByRule {
+		for _, vio := range set.Violations {
+			if incl, err := severity.SeverityInclude(filterSeverity, vio.Severity); err == nil && incl {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// FilterSeverity returns the violations at or above `filterSeverity`. Suppressed
+// violations and the baseline result are carried over unchanged.
+func (sets *PolicyViolationsSets) FilterSeverity(filterSeverity string) (*PolicyViolationsSets, error) {
+	out := NewPolicyViolationsSets()
+	if _, err := severity.Parse(filterSeverity); err != nil {
+		return out, err
+	}
+	out.Suppressed = sets.Suppressed
+	out.Baseline = sets.Baseline
+	for ruleName, set := range sets.ByRule {
+		filtered := NewPolicyViolationsSet(set.RuleName)
+		for _, vio := range set.Violations {
+			if incl, err := severity.SeverityInclude(filterSeverity, vio.Severity); err == nil && incl {
+				filtered.Violations = append(filtered.Violations, vio)
+			}
+		}
+		if len(filtered.Violations) > 0 {
+			out.ByRule[ruleName] = filtered
+		}
+	}
+	return out, nil
+}
+
+// SuppressedCount returns the number of suppressed violations.
+func (sets *PolicyViolationsSets) SuppressedCount() uint {
+	count := uint(0)
+	for _, set := range sets.Suppressed {
+		count += set.Count()
+	}
+	return count
+}
+
+// SuppressedCountsByRule returns the number of suppressed violations by rule.
+func (sets *PolicyViolationsSets) SuppressedCountsByRule() map[string]uint {
+	counts := map[string]uint{}
+	for _, set := range sets.Suppressed {
+		counts[set.RuleName] = set.Count()
+	}
+	return counts
+}
+
 type PolicyRule struct {
 	Name         string
 	StringFormat string
@@ -163,12 +283,16 @@ func (vl *PolicyViolationLocations) Finalize() {
 }
 
 type PolicyViolation struct {
-	RuleName  string
-	RuleType  string
-	Violation string
-	Value     string
-	Location  string
-	Data      map[string]string
+	RuleName          string
+	RuleType          string
+	Severity          string
+	Violation         string
+	Value             string
+	Location          string
+	Line              int
+	Column            int
+	SuppressionReason string
+	Data              map[string]string
 }
 
 type ViolationLocationsByRuleSet struct {
@@ -189,3 +313,80 @@ func (vlrs *ViolationLocationsByRuleSet) Count() uint {
 	}
 	return uint(count)
 }
+
+// FileDocument returns the document name used in violation locations for a
+// spec file: the path as given, cleaned and with forward slashes, so files
+// with the same name in different directories are distinct documents.
+func FileDocument(file string) string {
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// LocationParts splits the `Location` into the document and the
+// JSON pointer fragment, e.g. `spec.yaml` and `/paths/~1users/get`.
+func (vio *PolicyViolation) LocationParts() (document, pointer string) {
+	idx := strings.Index(vio.Location, "#")
+	if idx < 0 {
+		return vio.Location, ""
+	}
+	return vio.Location[:idx], vio.Location[idx+1:]
+}
+
+// SetPositions sets `Line` and `Column` for violations and suppressed violations
+// in `document` using `position`, which resolves a JSON pointer, such as one
+// returned by `openapi3.SourceIndex.Position()`. A zero line leaves the
+// violation unchanged.
+func (sets *PolicyViolationsSets) SetPositions(document string, position func(pointer string) (line, column int)) {
+	for _, setsMap := range []map[string]PolicyViolationsSet{sets.ByRule, sets.Suppressed} {
+		for _, set := range setsMap {
+			for i, vio := range set.Violations {
+				vioDocument, pointer := vio.LocationParts()
+				if vioDocument != document {
+					continue
+				}
+				if line, column := position(pointer); line > 0 {
+					set.Violations[i].Line = line
+					set.Violations[i].Column = column
+				}
+			}
+		}
+	}
+}
+
+// Sort sorts violations and suppressed violations by document, pointer,
+// rule name and value.
+func (sets *PolicyViolationsSets) Sort() {
+	for _, set := range sets.ByRule {
+		set.Sort()
+	}
+	for _, set := range sets.Suppressed {
+		set.Sort()
+	}
+}
+
+// Sort sorts violations by document, pointer, rule name and value.
+func (set *PolicyViolationsSet) Sort() {
+	sort.SliceStable(set.Violations, func(i, j int) bool {
+		vi, vj := set.Violations[i], set.Violations[j]
+		di, pi := vi.LocationParts()
+		dj, pj := vj.LocationParts()
+		if di != dj {
+			return di < dj
+		} else if pi != pj {
+			return pi < pj
+		} else if vi.RuleName != vj.RuleName {
+			return vi.RuleName < vj.RuleName
+		}
+		return vi.Value < vj.Value
+	})
+}
+
+// Violations returns all violations sorted by document, pointer, rule name and value.
+func (sets *PolicyViolationsSets) Violations() []PolicyViolation {
+	vios := []PolicyViolation{}
+	for _, set := range sets.ByRule {
+		vios = append(vios, set.Violations...)
+	}
+	all := PolicyViolationsSet{Violations: vios}
+	all.Sort()
+	return all.Violations
+}
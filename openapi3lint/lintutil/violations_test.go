@@ -0,0 +1,32 @@
+package lintutil
+
+import (
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+)
+
+// TestFilterSeverity ensures violations below the filter severity are removed
+// and suppressed violations are kept.
+func TestFilterSeverity(t *testing.T) {
+	sets := NewPolicyViolationsSets()
+	sets.AddViolations([]PolicyViolation{
+		{RuleName: "rule-a", Severity: severity.SeverityError, Location: "a.json#/paths/~1users/get"},
+		{RuleName: "rule-a", Severity: severity.SeverityWarning, Location: "a.json#/paths/~1users/post"},
+		{RuleName: "rule-b", Severity: severity.SeverityWarning, Location: "a.json#/paths/~1accounts/get"}})
+	sets.AddSuppressed(PolicyViolation{RuleName: "rule-b", Severity: severity.SeverityWarning, Location: "a.json#/tags/0"})
+
+	got, err := sets.FilterSeverity(severity.SeverityError)
+	if err != nil {
+		t.Fatalf("PolicyViolationsSets.FilterSeverity() Error [%s]", err.Error())
+	}
+	if got.Count() != 1 || len(got.ByRule) != 1 {
+		t.Errorf("PolicyViolationsSets.FilterSeverity() Mismatch: want count [1] rules [1], got count [%d] rules [%d]", got.Count(), len(got.ByRule))
+	}
+	if got.SuppressedCount() != 1 {
+		t.Errorf("PolicyViolationsSets.FilterSeverity() Suppressed Mismatch: want [1], got [%d]", got.SuppressedCount())
+	}
+	if count, err := sets.CountSeverityInclude(severity.SeverityWarning); err != nil || count != 3 {
+		t.Errorf("PolicyViolationsSets.CountSeverityInclude() Mismatch: want [3], got [%d]", count)
+	}
+}
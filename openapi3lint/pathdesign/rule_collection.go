@@ -0,0 +1,81 @@
+package pathdesign
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathambiguous"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathcollectionplural"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathsegmentstyle"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathsegmentverb"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathtrailingslash"
+)
+
+type RuleCollectionPathDesign struct {
+	name      string
+	ruleNames map[string]int
+}
+
+func NewRuleCollectionPathDesign() RuleCollectionPathDesign {
+	rules := RuleCollectionPathDesign{
+		name:      "Spectrum OpenAPI 3 Lint Path Design Rule Collection",
+		ruleNames: map[string]int{}}
+	names := rules.RuleNames()
+	for _, name := range names {
+		rules.ruleNames[name] = 1
+	}
+	return rules
+}
+
+func (pd RuleCollectionPathDesign) Name() string {
+	return pd.name
+}
+
+func (pd RuleCollectionPathDesign) RuleExists(ruleName string) bool {
+	if _, ok := pd.ruleNames[ruleName]; ok {
+		return true
+	}
+	return false
+}
+
+func (pd RuleCollectionPathDesign) RuleNames() []string {
+	rulenames := []string{
+		rulepathambiguous.RuleName,
+		rulepathcollectionplural.RuleName,
+		rulepathsegmentstyle.RuleNameCamelCase,
+		rulepathsegmentstyle.RuleNameKebabCase,
+		rulepathsegmentstyle.RuleNamePascalCase,
+		rulepathsegmentstyle.RuleNameSnakeCase,
+		rulepathsegmentverb.RuleName,
+		rulepathtrailingslash.RuleName,
+	}
+	sort.Strings(rulenames)
+	return rulenames
+}
+
+func (pd RuleCollectionPathDesign) Rule(name string) (openapi3lint.Rule, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case rulepathambiguous.RuleName:
+		return rulepathambiguous.NewRule(), nil
+	case rulepathcollectionplural.RuleName:
+		return rulepathcollectionplural.NewRule(), nil
+	case rulepathsegmentstyle.RuleNameCamelCase:
+		return rulepathsegmentstyle.NewRule(stringcase.CamelCase)
+	case rulepathsegmentstyle.RuleNameKebabCase:
+		return rulepathsegmentstyle.NewRule(stringcase.KebabCase)
+	case rulepathsegmentstyle.RuleNamePascalCase:
+		return rulepathsegmentstyle.NewRule(stringcase.PascalCase)
+	case rulepathsegmentstyle.RuleNameSnakeCase:
+		return rulepathsegmentstyle.NewRule(stringcase.SnakeCase)
+	case rulepathsegmentverb.RuleName:
+		return rulepathsegmentverb.NewRule(), nil
+	case rulepathtrailingslash.RuleName:
+		return rulepathtrailingslash.NewRule(), nil
+	}
+
+	return openapi3lint.EmptyRule{}, fmt.Errorf("NewPathDesignRule: rule [%s] not found", name)
+}
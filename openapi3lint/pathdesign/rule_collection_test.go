@@ -0,0 +1,75 @@
+package pathdesign
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathambiguous"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathcollectionplural"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathsegmentstyle"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathsegmentverb"
+	"github.com/grokify/spectrum/openapi3lint/pathdesign/rulepathtrailingslash"
+	"golang.org/x/exp/slices"
+)
+
+const testSpecPathsFile = "testdata/spec_paths.yaml"
+
+var ruleCollectionPathDesignTests = []struct {
+	ruleName   string
+	violations []string
+}{
+	{rulepathambiguous.RuleName, []string{
+		"spec.yaml#/paths/~1v1~1users~1{id} [/v1/users/me]",
+		"spec.yaml#/paths/~1v1~1users~1{userId} [/v1/users/me]",
+		"spec.yaml#/paths/~1v1~1users~1{userId} [/v1/users/{id}]"}},
+	{rulepathcollectionplural.RuleName, []string{
+		"spec.yaml#/paths/~1v1~1invoice~1{invoiceId} [invoice]"}},
+	{rulepathsegmentstyle.RuleNameKebabCase, []string{
+		"spec.yaml#/paths/~1v1~1getReports~1 [getReports]"}},
+	{rulepathsegmentstyle.RuleNameCamelCase, []string{
+		"spec.yaml#/paths/~1v1~1accounts~1{accountId}~1user-groups~1{groupId} [user-groups]"}},
+	{rulepathsegmentverb.RuleName, []string{
+		"spec.yaml#/paths/~1v1~1getReports~1 [getReports]"}},
+	{rulepathtrailingslash.RuleName, []string{
+		"spec.yaml#/paths/~1v1~1getReports~1 [/v1/getReports/]"}},
+}
+
+// TestRuleCollectionPathDesign ensures path design rules report the expected
+// locations and values for the fixture spec.
+func TestRuleCollectionPathDesign(t *testing.T) {
+	spec, err := openapi3.ReadFile(testSpecPathsFile, false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	pdRules := NewRuleCollectionPathDesign()
+	for _, tt := range ruleCollectionPathDesignTests {
+		if !pdRules.RuleExists(tt.ruleName) {
+			t.Errorf("RuleCollectionPathDesign.RuleExists() Mismatch: rule [%s] not found", tt.ruleName)
+			continue
+		}
+		rule, err := pdRules.Rule(tt.ruleName)
+		if err != nil {
+			t.Fatalf("RuleCollectionPathDesign.Rule() Error [%s]", err.Error())
+		}
+		pol := openapi3lint.NewPolicy()
+		if err := pol.AddRule(rule, severity.SeverityError, true); err != nil {
+			t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+		}
+		vsets, err := pol.ValidateSpec(spec, "spec.yaml", severity.SeverityError)
+		if err != nil {
+			t.Fatalf("Policy.ValidateSpec() rule [%s] Error [%s]", tt.ruleName, err.Error())
+		}
+		got := []string{}
+		for _, vio := range vsets.ByRule[tt.ruleName].Violations {
+			got = append(got, vio.Location+" ["+vio.Value+"]")
+		}
+		sort.Strings(got)
+		if !slices.Equal(got, tt.violations) {
+			t.Errorf("Policy.ValidateSpec() rule [%s] Mismatch: want [%v], got [%v]",
+				tt.ruleName, tt.violations, got)
+		}
+	}
+}
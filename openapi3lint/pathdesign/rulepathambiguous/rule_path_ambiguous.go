@@ -0,0 +1,62 @@
+package rulepathambiguous
+
+import (
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/type/maputil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "path-template-ambiguous"
+)
+
+// RulePathAmbiguous reports paths that can match the same request URL as another
+// path, such as `/users/{id}` and `/users/me`, or `/a/{x}` and `/a/{y}`, which
+// gateways and routers may not resolve consistently. Each pair is reported once
+// at the path that sorts last, with the other path as the value.
+type RulePathAmbiguous struct {
+	name string
+}
+
+func NewRule() RulePathAmbiguous {
+	return RulePathAmbiguous{
+		name: RuleName}
+}
+
+func (rule RulePathAmbiguous) Name() string {
+	return rule.name
+}
+
+func (rule RulePathAmbiguous) Description() string {
+	return "Paths must not match the same request URL as another path, such as `/users/{id}` and `/users/me`."
+}
+
+func (rule RulePathAmbiguous) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RulePathAmbiguous) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RulePathAmbiguous) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	paths := maputil.StringKeys(spec.Paths, nil)
+	for i, path := range paths {
+		for _, otherPath := range paths[:i] {
+			if openapi3edit.PathMatchAmbiguous(path, otherPath) {
+				vios = append(vios, lintutil.PolicyViolation{
+					RuleName: rule.Name(),
+					Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s", pointerBase, path),
+					Value:    otherPath})
+			}
+		}
+	}
+	return vios
+}
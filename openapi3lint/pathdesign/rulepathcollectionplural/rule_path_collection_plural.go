@@ -0,0 +1,114 @@
+package rulepathcollectionplural
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/type/maputil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "path-collection-plural-consistent"
+)
+
+// irregularPlurals are plural words that do not end in `s`.
+var irregularPlurals = map[string]int{
+	"children": 1, "criteria": 1, "data": 1, "feet": 1, "media": 1,
+	"men": 1, "people": 1, "teeth": 1, "women": 1}
+
+// RulePathCollectionPlural reports collection segments that do not follow the
+// plural or singular naming used by most collections in the spec. A collection
+// segment is a literal segment followed by a template variable segment, such as
+// `users` in `/users/{userId}`. When tied, plural naming is expected.
+type RulePathCollectionPlural struct {
+	name string
+}
+
+func NewRule() RulePathCollectionPlural {
+	return RulePathCollectionPlural{
+		name: RuleName}
+}
+
+func (rule RulePathCollectionPlural) Name() string {
+	return rule.name
+}
+
+func (rule RulePathCollectionPlural) Description() string {
+	return "Collection segments, such as `users` in `/users/{userId}`, must use the plural or singular naming used by most collections in the spec."
+}
+
+func (rule RulePathCollectionPlural) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RulePathCollectionPlural) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RulePathCollectionPlural) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	paths := maputil.StringKeys(spec.Paths, nil)
+	plural := map[string]bool{}
+	for _, path := range paths {
+		for _, segment := range CollectionSegments(path) {
+			plural[segment] = IsPlural(segment)
+		}
+	}
+	countPlural := 0
+	for _, isPlural := range plural {
+		if isPlural {
+			countPlural++
+		}
+	}
+	wantPlural := countPlural*2 >= len(plural)
+	for _, path := range paths {
+		for _, segment := range CollectionSegments(path) {
+			if plural[segment] == wantPlural {
+				continue
+			}
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s", pointerBase, path),
+				Value:    segment})
+		}
+	}
+	return vios
+}
+
+// CollectionSegments returns literal segments that are followed by a
+// template variable segment.
+func CollectionSegments(path string) []string {
+	collections := []string{}
+	segments := openapi3edit.PathSegments(path)
+	for i := 0; i < len(segments)-1; i++ {
+		if !openapi3edit.PathSegmentHasVar(segments[i]) &&
+			openapi3edit.PathSegmentHasVar(segments[i+1]) {
+			collections = append(collections, segments[i])
+		}
+	}
+	return collections
+}
+
+// IsPlural uses the last word of a segment, e.g. `groups` in `user-groups`,
+// to determine if a segment is plural.
+func IsPlural(segment string) bool {
+	words := lintutil.SplitWords(segment)
+	if len(words) == 0 {
+		return false
+	}
+	word := strings.ToLower(words[len(words)-1])
+	if _, ok := irregularPlurals[word]; ok {
+		return true
+	}
+	return len(word) > 1 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") &&
+		!strings.HasSuffix(word, "is")
+}
@@ -0,0 +1,98 @@
+package rulepathsegmentstyle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/mogo/type/maputil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleNameCamelCase  = "path-segment-style-camelcase"
+	RuleNameKebabCase  = "path-segment-style-kebabcase"
+	RuleNamePascalCase = "path-segment-style-pascalcase"
+	RuleNameSnakeCase  = "path-segment-style-snakecase"
+)
+
+var rxVersion = regexp.MustCompile(`^[vV][0-9]+(\.[0-9]+)*$`)
+
+// RulePathSegmentStyle reports literal path segments that do not match the
+// required case. Version segments such as `v1` are skipped and file extensions,
+// such as `.json`, are removed before checking.
+type RulePathSegmentStyle struct {
+	name       string
+	stringCase string
+}
+
+func NewRule(requiredStringCase string) (RulePathSegmentStyle, error) {
+	canonicalCase, err := stringcase.Parse(requiredStringCase)
+	if err != nil {
+		return RulePathSegmentStyle{},
+			fmt.Errorf("invalid string case [%s]", requiredStringCase)
+	}
+	rule := RulePathSegmentStyle{
+		stringCase: canonicalCase}
+	switch canonicalCase {
+	case stringcase.CamelCase:
+		rule.name = RuleNameCamelCase
+	case stringcase.KebabCase:
+		rule.name = RuleNameKebabCase
+	case stringcase.PascalCase:
+		rule.name = RuleNamePascalCase
+	case stringcase.SnakeCase:
+		rule.name = RuleNameSnakeCase
+	default:
+		return rule, fmt.Errorf("invalid string case [%s]", canonicalCase)
+	}
+	return rule, nil
+}
+
+func (rule RulePathSegmentStyle) Name() string {
+	return rule.name
+}
+
+func (rule RulePathSegmentStyle) Description() string {
+	return fmt.Sprintf("Literal path segments must be %s. Version segments, such as `v1`, are skipped and file extensions are removed before checking.", rule.stringCase)
+}
+
+func (rule RulePathSegmentStyle) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RulePathSegmentStyle) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RulePathSegmentStyle) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	for _, path := range maputil.StringKeys(spec.Paths, nil) {
+		for _, segment := range openapi3edit.PathSegments(path) {
+			if openapi3edit.PathSegmentHasVar(segment) || rxVersion.MatchString(segment) {
+				continue
+			}
+			word := segment
+			if idx := strings.Index(word, "."); idx > 0 {
+				word = word[:idx]
+			}
+			isCase, err := stringcase.IsCase(rule.stringCase, word)
+			if err == nil && isCase {
+				continue
+			}
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s", pointerBase, path),
+				Value:    segment})
+		}
+	}
+	return vios
+}
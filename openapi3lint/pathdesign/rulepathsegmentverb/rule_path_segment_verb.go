@@ -0,0 +1,79 @@
+package rulepathsegmentverb
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/type/maputil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "path-segment-no-verb"
+)
+
+// Verbs are words that duplicate the HTTP method when used to start a path segment.
+var Verbs = map[string]int{
+	"add": 1, "create": 1, "delete": 1, "edit": 1, "fetch": 1, "get": 1,
+	"insert": 1, "list": 1, "modify": 1, "patch": 1, "post": 1, "put": 1,
+	"remove": 1, "retrieve": 1, "save": 1, "set": 1, "update": 1}
+
+// RulePathSegmentVerb reports literal path segments starting with a CRUD verb,
+// such as `/getUsers` or `/users/{userId}/delete`, which should be expressed
+// with the HTTP method instead.
+type RulePathSegmentVerb struct {
+	name string
+}
+
+func NewRule() RulePathSegmentVerb {
+	return RulePathSegmentVerb{
+		name: RuleName}
+}
+
+func (rule RulePathSegmentVerb) Name() string {
+	return rule.name
+}
+
+func (rule RulePathSegmentVerb) Description() string {
+	return "Literal path segments must not start with a CRUD verb, such as `get` or `delete`, which should be expressed with the HTTP method."
+}
+
+func (rule RulePathSegmentVerb) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RulePathSegmentVerb) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RulePathSegmentVerb) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	for _, path := range maputil.StringKeys(spec.Paths, nil) {
+		for _, segment := range openapi3edit.PathSegments(path) {
+			if openapi3edit.PathSegmentHasVar(segment) || !SegmentHasVerb(segment) {
+				continue
+			}
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s", pointerBase, path),
+				Value:    segment})
+		}
+	}
+	return vios
+}
+
+// SegmentHasVerb returns true if the first word of a path segment is in `Verbs`.
+func SegmentHasVerb(segment string) bool {
+	words := lintutil.SplitWords(segment)
+	if len(words) == 0 {
+		return false
+	}
+	_, ok := Verbs[strings.ToLower(words[0])]
+	return ok
+}
@@ -0,0 +1,57 @@
+package rulepathtrailingslash
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/type/maputil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "path-trailing-slash"
+)
+
+// RulePathTrailingSlash reports paths, other than `/`, that end with a slash.
+type RulePathTrailingSlash struct {
+	name string
+}
+
+func NewRule() RulePathTrailingSlash {
+	return RulePathTrailingSlash{
+		name: RuleName}
+}
+
+func (rule RulePathTrailingSlash) Name() string {
+	return rule.name
+}
+
+func (rule RulePathTrailingSlash) Description() string {
+	return "Paths, other than `/`, must not end with a slash."
+}
+
+func (rule RulePathTrailingSlash) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RulePathTrailingSlash) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RulePathTrailingSlash) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	for _, path := range maputil.StringKeys(spec.Paths, nil) {
+		if len(path) > 1 && strings.HasSuffix(path, "/") {
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s", pointerBase, path),
+				Value:    path})
+		}
+	}
+	return vios
+}
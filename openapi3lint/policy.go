@@ -1,6 +1,7 @@
 package openapi3lint
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -9,7 +10,6 @@ import (
 	oas3 "github.com/getkin/kin-openapi/openapi3"
 	"github.com/grokify/mogo/encoding/jsonpointer"
 	"github.com/grokify/mogo/log/severity"
-	"github.com/grokify/mogo/path/filepathutil"
 	"github.com/grokify/mogo/text/stringcase"
 	"github.com/grokify/mogo/type/stringsutil"
 	"github.com/grokify/spectrum/openapi3"
@@ -19,6 +19,8 @@ import (
 type Policy struct {
 	//rules       map[string]Rule
 	policyRules map[string]PolicyRule
+	baseline    *lintutil.Baseline
+	overrides   []policyOverride
 }
 
 func NewPolicy() Policy {
@@ -107,22 +109,60 @@ func (pol *Policy) RuleNames() []string {
 	return ruleNames
 }
 
+// SetBaseline sets a baseline of accepted violations used by `ValidateSpecFiles()`.
+// Set to `nil` to report all violations.
+func (pol *Policy) SetBaseline(bl *lintutil.Baseline) {
+	pol.baseline = bl
+}
+
+// PolicyRule returns the `PolicyRule` for a rule name.
+func (pol *Policy) PolicyRule(ruleName string) (PolicyRule, bool) {
+	policyRule, ok := pol.policyRules[ruleName]
+	return policyRule, ok
+}
+
 func (pol *Policy) ValidateSpec(spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
+	return pol.ValidateSpecContext(context.Background(), spec, pointerBase, filterSeverity)
+}
+
+// ValidateSpecContext is like `ValidateSpec()` and passes `ctx` to rules that
+// implement `RuleContext`. Errors from these rules, including cancellation of
+// `ctx`, are returned. `ctx` is given a new `lintutil.WithSpecCache()` cache
+// for the validation.
+func (pol *Policy) ValidateSpecContext(ctx context.Context, spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
+	vsets, err := pol.validateSpec(lintutil.WithSpecCache(ctx), spec, filterSeverity)
+	if err != nil || len(pointerBase) == 0 {
+		return vsets, err
+	}
+	// rules escape the pointer base as a JSON pointer token, so violations are
+	// relocated to `pointerBase`, which can be a file path.
+	return lintutil.RelocateViolations(vsets, map[string]string{"": pointerBase}), nil
+}
+
+func (pol *Policy) validateSpec(ctx context.Context, spec *openapi3.Spec, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
 	vsets := lintutil.NewPolicyViolationsSets()
 
 	unknownScopes := []string{}
+	unimplementedScopes := []string{}
 	for _, policyRule := range pol.policyRules {
 		_, err := lintutil.ParseScope(policyRule.Rule.Scope())
 		if err != nil {
 			unknownScopes = append(unknownScopes, policyRule.Rule.Scope())
+		} else if !ruleImplementsScope(policyRule.Rule) {
+			unimplementedScopes = append(unimplementedScopes, policyRule.Rule.Name())
 		}
 	}
 	if len(unknownScopes) > 0 {
 		return nil, fmt.Errorf("bad policy: rules have unknown scopes [%s]",
 			strings.Join(unknownScopes, ","))
 	}
+	if len(unimplementedScopes) > 0 {
+		sort.Strings(unimplementedScopes)
+		return nil, fmt.Errorf("bad policy: rules do not implement scope interface [%s]",
+			strings.Join(unimplementedScopes, ","))
+	}
 
-	vsetsOps, err := pol.processRulesOperation(spec, pointerBase, filterSeverity)
+	vsetsOps, err := pol.processRulesOperation(ctx, spec, "", filterSeverity)
 	if err != nil {
 		return vsets, err
 	}
@@ -131,7 +171,7 @@ func (pol *Policy) ValidateSpec(spec *openapi3.Spec, pointerBase, filterSeverity
 		return vsets, err
 	}
 
-	vsetsSpec, err := pol.processRulesSpecification(spec, pointerBase, filterSeverity)
+	vsetsSpec, err := pol.processRulesSpecification(ctx, spec, "", filterSeverity)
 	if err != nil {
 		return vsets, err
 	}
@@ -140,10 +180,25 @@ func (pol *Policy) ValidateSpec(spec *openapi3.Spec, pointerBase, filterSeverity
 		return vsets, err
 	}
 
+	vsetsComponents, err := pol.processRulesComponents(spec, "", filterSeverity)
+	if err != nil {
+		return vsets, err
+	}
+	err = vsets.UpsertSets(vsetsComponents)
+	if err != nil {
+		return vsets, err
+	}
+
+	sups, err := SpecSuppressions(spec)
+	if err != nil {
+		return vsets, err
+	}
+	vsets.Suppress(sups.Reason)
+
 	return vsets, nil
 }
 
-func (pol *Policy) processRulesSpecification(spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
+func (pol *Policy) processRulesSpecification(ctx context.Context, spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
 	if spec == nil {
 		return nil, errors.New("cannot process nil spec")
 	}
@@ -158,23 +213,33 @@ func (pol *Policy) processRulesSpecification(spec *openapi3.Spec, pointerBase, f
 			return vsets, err
 		}
 		// fmt.Printf("FILTER_SEV [%v] ITEM_SEV [%v] INCL [%v]\n", filterSeverity, rule.Severity(), inclRule)
-		if inclRule {
-			//fmt.Printf("PROC RULE name[%s] scope[%s] sev[%s]\n", rule.Name(), rule.Scope(), rule.Severity())
-			vsets.AddViolations(policyRule.Rule.ProcessSpec(spec, pointerBase))
+		if !inclRule {
+			continue
+		}
+		//fmt.Printf("PROC RULE name[%s] scope[%s] sev[%s]\n", rule.Name(), rule.Scope(), rule.Severity())
+		if ruleCtx, ok := policyRule.Rule.(RuleContext); ok {
+			vios, err := ruleCtx.ProcessSpecContext(ctx, spec, pointerBase)
+			if err != nil {
+				return vsets, err
+			}
+			vsets.AddViolationsWithSeverity(vios, policyRule.Severity)
+		} else {
+			vsets.AddViolationsWithSeverity(policyRule.Rule.ProcessSpec(spec, pointerBase), policyRule.Severity)
 		}
 	}
 	return vsets, nil
 }
 
-func (pol *Policy) processRulesOperation(spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
+func (pol *Policy) processRulesOperation(ctx context.Context, spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
 	vsets := lintutil.NewPolicyViolationsSets()
+	var procErr error
 
 	severityErrorRules := []string{}
 	unknownSeverities := []string{}
 
 	openapi3.VisitOperations(spec,
 		func(path, method string, op *oas3.Operation) {
-			if op == nil {
+			if op == nil || procErr != nil {
 				return
 			}
 			opPointer := jsonpointer.PointerSubEscapeAll(
@@ -184,18 +249,33 @@ func (pol *Policy) processRulesOperation(spec *openapi3.Spec, pointerBase, filte
 					continue
 				}
 				//fmt.Printf("HERE [%s] RULE [%s] Scope [%s]\n", path, rule.Name(), rule.Scope())
-				inclRule, err := severity.SeverityInclude(filterSeverity, policyRule.Severity)
+				opSeverity := pol.operationSeverity(policyRule, path, op)
+				inclRule, err := severity.SeverityInclude(filterSeverity, opSeverity)
 				//fmt.Printf("INCL_RULE? [%v] RULE [%s]\n", inclRule, rule.Name())
 				if err != nil {
 					severityErrorRules = append(severityErrorRules, policyRule.Rule.Name())
-					unknownSeverities = append(unknownSeverities, policyRule.Severity)
-				} else if inclRule {
-					vsets.AddViolations(policyRule.Rule.ProcessOperation(spec, op, opPointer, path, method))
+					unknownSeverities = append(unknownSeverities, opSeverity)
+				} else if !inclRule {
+					continue
+				} else if ruleCtx, ok := policyRule.Rule.(RuleContext); ok {
+					vios, err := ruleCtx.ProcessOperationContext(ctx, spec, op, opPointer, path, method)
+					if err != nil {
+						procErr = err
+						return
+					}
+					vsets.AddViolationsWithSeverity(vios, opSeverity)
+				} else {
+					vsets.AddViolationsWithSeverity(
+						policyRule.Rule.ProcessOperation(spec, op, opPointer, path, method),
+						opSeverity)
 				}
 			}
 		},
 	)
 
+	if procErr != nil {
+		return vsets, procErr
+	}
 	if len(severityErrorRules) > 0 || len(unknownSeverities) > 0 {
 		severityErrorRules = stringsutil.Dedupe(severityErrorRules)
 		sort.Strings(severityErrorRules)
@@ -213,8 +293,12 @@ var ErrNoSpecFiles = errors.New("no spec files supplied")
 
 // ValidateSpecFiles executes the policy against a set of one or more spec files.
 // `sev` is the severity as specified by `github.com/grokify/mogo/log/severity`.
+// Violations include the source `Line` and `Column` of their JSON pointer.
 // A benefit of using this over `ValidateSpec()` when validating multiple files
-// is that this will automatically inject the filename as a JSON pointer base.`
+// is that this will automatically inject the file path, as returned by
+// `lintutil.FileDocument()`, as a JSON pointer base.
+// If a baseline is set with `SetBaseline()`, only violations not in the baseline
+// are returned and `PolicyViolationsSets.Baseline` lists fixed baseline entries.
 func (pol *Policy) ValidateSpecFiles(filterSeverity string, specfiles []string) (*lintutil.PolicyViolationsSets, error) {
 	if len(specfiles) == 0 {
 		return nil, ErrNoSpecFiles
@@ -230,7 +314,11 @@ func (pol *Policy) ValidateSpecFiles(filterSeverity string, specfiles []string)
 		if err != nil {
 			return nil, err
 		}
-		vsetsRule, err := pol.ValidateSpec(spec, filepathutil.FilepathLeaf(file), severityLevel)
+		vsetsRule, err := pol.ValidateSpec(spec, lintutil.FileDocument(file), severityLevel)
+		if err != nil {
+			return nil, err
+		}
+		err = setSourcePositions(vsetsRule, file)
 		if err != nil {
 			return nil, err
 		}
@@ -240,5 +328,23 @@ func (pol *Policy) ValidateSpecFiles(filterSeverity string, specfiles []string)
 		}
 	}
 
+	if pol.baseline != nil {
+		return pol.baseline.FilterFiles(vsets, specfiles)
+	}
 	return vsets, nil
 }
+
+// setSourcePositions sets the source line and column for violations in `file`.
+// Pointers that cannot be fully resolved use the position of their nearest
+// existing ancestor, e.g. the operation for a missing `summary`.
+func setSourcePositions(vsets *lintutil.PolicyViolationsSets, file string) error {
+	idx, err := openapi3.ReadSourceIndexFile(file)
+	if err != nil {
+		return err
+	}
+	vsets.SetPositions(lintutil.FileDocument(file), func(pointer string) (int, int) {
+		pos, _ := idx.Position(pointer)
+		return pos.Line, pos.Column
+	})
+	return nil
+}
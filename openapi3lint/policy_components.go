@@ -0,0 +1,117 @@
+package openapi3lint
+
+import (
+	"errors"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// rulesForScope returns the rules for a scope that are included by `filterSeverity`.
+func (pol *Policy) rulesForScope(scope, filterSeverity string) ([]PolicyRule, error) {
+	rules := []PolicyRule{}
+	for _, policyRule := range pol.policyRules {
+		if !lintutil.ScopeMatch(scope, policyRule.Rule.Scope()) {
+			continue
+		}
+		inclRule, err := severity.SeverityInclude(filterSeverity, policyRule.Severity)
+		if err != nil {
+			return rules, err
+		} else if inclRule {
+			rules = append(rules, policyRule)
+		}
+	}
+	return rules, nil
+}
+
+// processRulesComponents executes rules with `parameter`, `request-body`, `response`,
+// `schema`, `schema-property` and `tag` scopes. Each spec element is visited once
+// and dispatched to every matching rule with a JSON pointer prefixed by `pointerBase`.
+func (pol *Policy) processRulesComponents(spec *openapi3.Spec, pointerBase, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
+	if spec == nil {
+		return nil, errors.New("cannot process nil spec")
+	}
+	vsets := lintutil.NewPolicyViolationsSets()
+
+	rules, err := pol.rulesForScope(lintutil.ScopeParameter, filterSeverity)
+	if err != nil {
+		return vsets, err
+	} else if len(rules) > 0 {
+		openapi3.VisitParameters(spec, func(jsonPointer string, paramRef *oas3.ParameterRef) {
+			for _, policyRule := range rules {
+				vsets.AddViolationsWithSeverity(
+					policyRule.Rule.(RuleParameter).ProcessParameter(spec, paramRef, pointerBase+jsonPointer),
+					policyRule.Severity)
+			}
+		})
+	}
+
+	rules, err = pol.rulesForScope(lintutil.ScopeRequestBody, filterSeverity)
+	if err != nil {
+		return vsets, err
+	} else if len(rules) > 0 {
+		openapi3.VisitRequestBodies(spec, func(jsonPointer string, reqBodyRef *oas3.RequestBodyRef) {
+			for _, policyRule := range rules {
+				vsets.AddViolationsWithSeverity(
+					policyRule.Rule.(RuleRequestBody).ProcessRequestBody(spec, reqBodyRef, pointerBase+jsonPointer),
+					policyRule.Severity)
+			}
+		})
+	}
+
+	rules, err = pol.rulesForScope(lintutil.ScopeResponse, filterSeverity)
+	if err != nil {
+		return vsets, err
+	} else if len(rules) > 0 {
+		openapi3.VisitResponses(spec, func(jsonPointer, key string, respRef *oas3.ResponseRef) {
+			for _, policyRule := range rules {
+				vsets.AddViolationsWithSeverity(
+					policyRule.Rule.(RuleResponse).ProcessResponse(spec, respRef, pointerBase+jsonPointer, key),
+					policyRule.Severity)
+			}
+		})
+	}
+
+	rules, err = pol.rulesForScope(lintutil.ScopeSchema, filterSeverity)
+	if err != nil {
+		return vsets, err
+	} else if len(rules) > 0 {
+		openapi3.VisitSchemas(spec, func(jsonPointer, schemaName string, schemaRef *oas3.SchemaRef) {
+			for _, policyRule := range rules {
+				vsets.AddViolationsWithSeverity(
+					policyRule.Rule.(RuleSchema).ProcessSchema(spec, schemaRef, pointerBase+jsonPointer, schemaName),
+					policyRule.Severity)
+			}
+		})
+	}
+
+	rules, err = pol.rulesForScope(lintutil.ScopeSchemaProperty, filterSeverity)
+	if err != nil {
+		return vsets, err
+	} else if len(rules) > 0 {
+		openapi3.VisitSchemaProperties(spec, func(jsonPointer, schemaName, propName string, propRef *oas3.SchemaRef) {
+			for _, policyRule := range rules {
+				vsets.AddViolationsWithSeverity(
+					policyRule.Rule.(RuleSchemaProperty).ProcessSchemaProperty(spec, propRef, pointerBase+jsonPointer, schemaName, propName),
+					policyRule.Severity)
+			}
+		})
+	}
+
+	rules, err = pol.rulesForScope(lintutil.ScopeTag, filterSeverity)
+	if err != nil {
+		return vsets, err
+	} else if len(rules) > 0 {
+		openapi3.VisitTags(spec, func(jsonPointer string, tag *oas3.Tag) {
+			for _, policyRule := range rules {
+				vsets.AddViolationsWithSeverity(
+					policyRule.Rule.(RuleTag).ProcessTag(spec, tag, pointerBase+jsonPointer),
+					policyRule.Severity)
+			}
+		})
+	}
+
+	return vsets, nil
+}
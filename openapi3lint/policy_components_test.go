@@ -0,0 +1,135 @@
+package openapi3lint
+
+import (
+	"sort"
+	"testing"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"golang.org/x/exp/slices"
+)
+
+const testSpecComponents = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "tags": [{"name": "users"}],
+  "paths": {
+    "/users/{userId}": {
+      "get": {
+        "parameters": [
+          {"name": "userId", "in": "path", "required": true, "schema": {"type": "string"}},
+          {"$ref": "#/components/parameters/Limit"}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      }
+    },
+    "/users": {
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+        "responses": {"201": {"description": "Created"}}
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+    },
+    "requestBodies": {
+      "UserBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}
+    },
+    "schemas": {
+      "User": {"type": "object", "properties": {"id": {"type": "string"}}}
+    }
+  }
+}`
+
+type testRuleScope struct {
+	name  string
+	scope string
+}
+
+func (rule testRuleScope) Name() string  { return rule.name }
+func (rule testRuleScope) Scope() string { return rule.scope }
+func (rule testRuleScope) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+func (rule testRuleScope) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+func (rule testRuleScope) violation(location string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{{RuleName: rule.name, Location: location}}
+}
+func (rule testRuleScope) ProcessParameter(spec *openapi3.Spec, paramRef *oas3.ParameterRef, paramPointer string) []lintutil.PolicyViolation {
+	return rule.violation(paramPointer)
+}
+func (rule testRuleScope) ProcessRequestBody(spec *openapi3.Spec, reqBodyRef *oas3.RequestBodyRef, reqBodyPointer string) []lintutil.PolicyViolation {
+	return rule.violation(reqBodyPointer)
+}
+func (rule testRuleScope) ProcessResponse(spec *openapi3.Spec, respRef *oas3.ResponseRef, respPointer, key string) []lintutil.PolicyViolation {
+	return rule.violation(respPointer)
+}
+func (rule testRuleScope) ProcessSchema(spec *openapi3.Spec, schemaRef *oas3.SchemaRef, schemaPointer, schemaName string) []lintutil.PolicyViolation {
+	return rule.violation(schemaPointer)
+}
+func (rule testRuleScope) ProcessSchemaProperty(spec *openapi3.Spec, propRef *oas3.SchemaRef, propPointer, schemaName, propName string) []lintutil.PolicyViolation {
+	return rule.violation(propPointer)
+}
+func (rule testRuleScope) ProcessTag(spec *openapi3.Spec, tag *oas3.Tag, tagPointer string) []lintutil.PolicyViolation {
+	return rule.violation(tagPointer)
+}
+
+var policyComponentsTests = []struct {
+	scope     string
+	locations []string
+}{
+	{lintutil.ScopeParameter, []string{
+		"spec.json#/components/parameters/Limit",
+		"spec.json#/paths/~1users~1{userId}/get/parameters/0"}},
+	{lintutil.ScopeRequestBody, []string{
+		"spec.json#/components/requestBodies/UserBody",
+		"spec.json#/paths/~1users/post/requestBody"}},
+	{lintutil.ScopeResponse, []string{
+		"spec.json#/paths/~1users/post/responses/201",
+		"spec.json#/paths/~1users~1{userId}/get/responses/200"}},
+	{lintutil.ScopeSchema, []string{
+		"spec.json#/components/schemas/User"}},
+	{lintutil.ScopeSchemaProperty, []string{
+		"spec.json#/components/schemas/User/properties/id"}},
+	{lintutil.ScopeTag, []string{
+		"spec.json#/tags/0"}},
+}
+
+// TestPolicyComponents ensures component scoped rules are dispatched with the correct JSON pointers
+// and that violations have the rule's policy severity.
+func TestPolicyComponents(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecComponents))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	for _, tt := range policyComponentsTests {
+		pol := NewPolicy()
+		err := pol.AddRule(testRuleScope{name: "test-rule", scope: tt.scope}, severity.SeverityWarning, true)
+		if err != nil {
+			t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+		}
+		vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityWarning)
+		if err != nil {
+			t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+		}
+		vset := vsets.ByRule["test-rule"]
+		got := vset.Locations().Locations
+		sort.Strings(got)
+		if !slices.Equal(got, tt.locations) {
+			t.Errorf("Policy.ValidateSpec() scope [%s] Mismatch: want [%v], got [%v]",
+				tt.scope, tt.locations, got)
+		}
+		for _, vio := range vset.Violations {
+			if vio.Severity != severity.SeverityWarning {
+				t.Errorf("Policy.ValidateSpec() scope [%s] Mismatch: want severity [%s], got [%s]",
+					tt.scope, severity.SeverityWarning, vio.Severity)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package openapi3lint
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// FileError is an error reading or linting a spec file.
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (fe FileError) Error() string {
+	return fmt.Sprintf("file [%s]: %s", fe.File, fe.Err.Error())
+}
+
+func (fe FileError) Unwrap() error {
+	return fe.Err
+}
+
+// FileErrors is a list of per-file errors in the order of the files supplied.
+type FileErrors []FileError
+
+func (fes FileErrors) Error() string {
+	msgs := []string{}
+	for _, fe := range fes {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateSpecFilesContext executes the policy against a set of spec files using
+// `workers` goroutines. If `workers` is less than 1, `runtime.NumCPU()` is used.
+// Files that cannot be read or linted are reported in `FileErrors` and do not stop
+// other files from being linted. Violations are sorted by file, pointer and rule.
+// If `ctx` is canceled, files not yet started are skipped and `ctx.Err()` is
+// returned with the results collected so far. If a baseline is set, it is applied
+// to the files linted without error.
+func (pol *Policy) ValidateSpecFilesContext(ctx context.Context, filterSeverity string, specfiles []string, workers int) (*lintutil.PolicyViolationsSets, FileErrors, error) {
+	if len(specfiles) == 0 {
+		return nil, nil, ErrNoSpecFiles
+	}
+	severityLevel, err := severity.Parse(filterSeverity)
+	if err != nil {
+		return nil, nil, err
+	}
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(specfiles) {
+		workers = len(specfiles)
+	}
+
+	results := make([]*lintutil.PolicyViolationsSets, len(specfiles))
+	errs := make([]error, len(specfiles))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = pol.validateSpecFile(ctx, specfiles[i], severityLevel)
+			}
+		}()
+	}
+	canceled := false
+dispatch:
+	for i := range specfiles {
+		if ctx.Err() != nil {
+			canceled = true
+			break
+		}
+		select {
+		case <-ctx.Done():
+			canceled = true
+			break dispatch
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	vsets := lintutil.NewPolicyViolationsSets()
+	fileErrs := FileErrors{}
+	linted := []string{}
+	for i, file := range specfiles {
+		if errs[i] != nil {
+			fileErrs = append(fileErrs, FileError{File: file, Err: errs[i]})
+			continue
+		} else if results[i] == nil {
+			continue
+		}
+		linted = append(linted, file)
+		if err := vsets.UpsertSets(results[i]); err != nil {
+			fileErrs = append(fileErrs, FileError{File: file, Err: err})
+		}
+	}
+	if pol.baseline != nil {
+		if vsets, err = pol.baseline.FilterFiles(vsets, linted); err != nil {
+			return nil, fileErrs, err
+		}
+	}
+	vsets.Sort()
+	if len(fileErrs) == 0 {
+		fileErrs = nil
+	}
+	if canceled {
+		return vsets, fileErrs, ctx.Err()
+	}
+	return vsets, fileErrs, nil
+}
+
+func (pol *Policy) validateSpecFile(ctx context.Context, file, filterSeverity string) (*lintutil.PolicyViolationsSets, error) {
+	spec, err := openapi3.ReadFile(file, false)
+	if err != nil {
+		return nil, err
+	}
+	vsets, err := pol.ValidateSpecContext(ctx, spec, lintutil.FileDocument(file), filterSeverity)
+	if err != nil {
+		return nil, err
+	}
+	return vsets, setSourcePositions(vsets, file)
+}
@@ -0,0 +1,218 @@
+package openapi3lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const testSpecConcurrentFormat = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test %d", "version": "1.0.0"},
+  "paths": {
+    "/users": {"get": {"responses": {"200": {"description": "OK"}}}},
+    "/accounts": {"get": {"responses": {"200": {"description": "OK"}}}}
+  }
+}`
+
+func testConcurrentPolicy(t *testing.T) Policy {
+	polCfg := PolicyConfig{
+		IncludeStandardRules: true,
+		Rules: map[string]RuleConfig{
+			lintutil.RuleOpIdExist:          {Severity: severity.SeverityError},
+			lintutil.RulenameOpSummaryExist: {Severity: severity.SeverityError}}}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	return pol
+}
+
+func testConcurrentFiles(t *testing.T, count int) []string {
+	dir := t.TempDir()
+	files := []string{}
+	for i := 0; i < count; i++ {
+		file := filepath.Join(dir, fmt.Sprintf("spec%02d.json", i))
+		if err := os.WriteFile(file, []byte(fmt.Sprintf(testSpecConcurrentFormat, i)), 0600); err != nil {
+			t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// TestValidateSpecFilesContext ensures concurrent linting collects per-file
+// errors and returns sorted results matching serial linting.
+func TestValidateSpecFilesContext(t *testing.T) {
+	pol := testConcurrentPolicy(t)
+	files := testConcurrentFiles(t, 12)
+	missing := filepath.Join(filepath.Dir(files[0]), "missing.json")
+
+	vsetsSerial, err := pol.ValidateSpecFiles(severity.SeverityError, files)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpecFiles() Error [%s]", err.Error())
+	}
+	vsetsSerial.Sort()
+
+	vsets, fileErrs, err := pol.ValidateSpecFilesContext(context.Background(),
+		severity.SeverityError, append([]string{missing}, files...), 4)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpecFilesContext() Error [%s]", err.Error())
+	}
+	if len(fileErrs) != 1 || fileErrs[0].File != missing {
+		t.Errorf("Policy.ValidateSpecFilesContext() FileErrors Mismatch: want [%s], got [%v]", missing, fileErrs)
+	}
+	if vsets.Count() != 48 {
+		t.Errorf("Policy.ValidateSpecFilesContext() Count Mismatch: want [%d], got [%d]", 48, vsets.Count())
+	}
+	want := vsetsSerial.Violations()
+	got := vsets.Violations()
+	if len(got) != len(want) {
+		t.Fatalf("Policy.ValidateSpecFilesContext() Violations Mismatch: want [%d], got [%d]", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].Location != want[i].Location || got[i].RuleName != want[i].RuleName {
+			t.Errorf("Policy.ValidateSpecFilesContext() Violation [%d] Mismatch: want [%s %s], got [%s %s]",
+				i, want[i].RuleName, want[i].Location, got[i].RuleName, got[i].Location)
+		}
+	}
+	first := vsets.ByRule[lintutil.RuleOpIdExist].Violations[0].Location
+	if wantFirst := lintutil.FileDocument(files[0]) + "#/paths/~1accounts/get/operationId"; first != wantFirst {
+		t.Errorf("Policy.ValidateSpecFilesContext() Sort Mismatch: want [%s], got [%s]", wantFirst, first)
+	}
+}
+
+const testSpecSameNameA = `{
+  "openapi": "3.0.3",
+  "info": {"title": "A", "version": "1.0.0"},
+  "paths": {
+    "/users": {"get": {"summary": "Get users", "responses": {"200": {"description": "OK"}}}}
+  }
+}`
+
+const testSpecSameNameB = `{
+  "openapi": "3.0.3",
+  "info": {"title": "B", "version": "1.0.0"},
+  "paths": {
+    "/users": {"get": {"operationId": "getUsers", "summary": "Get users", "responses": {"200": {"description": "OK"}}}},
+
+    "/accounts": {"get": {"summary": "Get accounts", "responses": {"200": {"description": "OK"}}}}
+  }
+}`
+
+// TestValidateSpecFilesSameName ensures files with the same name in different
+// directories are reported as separate documents with their own positions.
+func TestValidateSpecFilesSameName(t *testing.T) {
+	pol := testConcurrentPolicy(t)
+	dir := t.TempDir()
+	files := []string{}
+	for _, spec := range []struct{ subdir, data string }{
+		{"a", testSpecSameNameA},
+		{"b", testSpecSameNameB}} {
+		file := filepath.Join(dir, spec.subdir, "openapi.json")
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			t.Fatalf("os.MkdirAll() Error [%s]", err.Error())
+		}
+		if err := os.WriteFile(file, []byte(spec.data), 0600); err != nil {
+			t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+		}
+		files = append(files, file)
+	}
+	want := map[string][2]int{
+		lintutil.FileDocument(files[0]) + "#/paths/~1users/get/operationId":    {5, 16},
+		lintutil.FileDocument(files[1]) + "#/paths/~1accounts/get/operationId": {7, 19}}
+
+	vsetsSerial, err := pol.ValidateSpecFiles(severity.SeverityError, files)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpecFiles() Error [%s]", err.Error())
+	}
+	vsets, _, err := pol.ValidateSpecFilesContext(context.Background(), severity.SeverityError, files, 2)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpecFilesContext() Error [%s]", err.Error())
+	}
+	for _, vs := range []*lintutil.PolicyViolationsSets{vsetsSerial, vsets} {
+		vios := vs.Violations()
+		if len(vios) != len(want) {
+			t.Fatalf("Policy.ValidateSpecFiles() Mismatch: want [%d] violations, got [%d]", len(want), len(vios))
+		}
+		for _, vio := range vios {
+			pos, ok := want[vio.Location]
+			if !ok {
+				t.Errorf("Policy.ValidateSpecFiles() Mismatch: unexpected location [%s]", vio.Location)
+			} else if vio.Line != pos[0] || vio.Column != pos[1] {
+				t.Errorf("Policy.ValidateSpecFiles() Mismatch: location [%s] want [%d:%d], got [%d:%d]",
+					vio.Location, pos[0], pos[1], vio.Line, vio.Column)
+			}
+		}
+	}
+}
+
+// TestValidateSpecFilesContextCanceled ensures a canceled context returns
+// the context error.
+func TestValidateSpecFilesContextCanceled(t *testing.T) {
+	pol := testConcurrentPolicy(t)
+	files := testConcurrentFiles(t, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vsets, _, err := pol.ValidateSpecFilesContext(ctx, severity.SeverityError, files, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Policy.ValidateSpecFilesContext() Error Mismatch: want [%v], got [%v]", context.Canceled, err)
+	}
+	if vsets == nil || vsets.Count() != 0 {
+		t.Errorf("Policy.ValidateSpecFilesContext() Mismatch: want empty results for canceled context")
+	}
+}
+
+// testRuleContext is an operation scope rule that implements `RuleContext`
+// and returns the context error.
+type testRuleContext struct{}
+
+func (rule testRuleContext) Name() string  { return "test-rule-context" }
+func (rule testRuleContext) Scope() string { return lintutil.ScopeOperation }
+func (rule testRuleContext) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+func (rule testRuleContext) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{{RuleName: rule.Name(), Location: opPointer}}
+}
+func (rule testRuleContext) ProcessSpecContext(ctx context.Context, spec *openapi3.Spec, pointerBase string) ([]lintutil.PolicyViolation, error) {
+	return rule.ProcessSpec(spec, pointerBase), ctx.Err()
+}
+func (rule testRuleContext) ProcessOperationContext(ctx context.Context, spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) ([]lintutil.PolicyViolation, error) {
+	return rule.ProcessOperation(spec, op, opPointer, path, method), ctx.Err()
+}
+
+// TestValidateSpecFilesContextRuleContext ensures the context is passed to
+// rules implementing `RuleContext` and their errors are reported per file.
+func TestValidateSpecFilesContextRuleContext(t *testing.T) {
+	pol := NewPolicy()
+	if err := pol.AddRule(testRuleContext{}, severity.SeverityError, true); err != nil {
+		t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+	}
+	files := testConcurrentFiles(t, 2)
+	vsets, fileErrs, err := pol.ValidateSpecFilesContext(context.Background(), severity.SeverityError, files, 1)
+	if err != nil || len(fileErrs) != 0 {
+		t.Fatalf("Policy.ValidateSpecFilesContext() Error [%v] [%v]", err, fileErrs)
+	}
+	if vsets.Count() != 4 {
+		t.Errorf("Policy.ValidateSpecFilesContext() Count Mismatch: want [%d], got [%d]", 4, vsets.Count())
+	}
+
+	spec, err := openapi3.ReadFile(files[0], false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := pol.ValidateSpecContext(ctx, spec, "spec.json", severity.SeverityError); !errors.Is(err, context.Canceled) {
+		t.Errorf("Policy.ValidateSpecContext() Error Mismatch: want [%v], got [%v]", context.Canceled, err)
+	}
+}
@@ -4,29 +4,151 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/grokify/mogo/errors/errorsutil"
+	"github.com/grokify/mogo/log/severity"
 	"github.com/grokify/mogo/type/stringsutil"
+	"github.com/grokify/spectrum/openapi3lint/ruledeclarative"
+	"github.com/grokify/spectrum/openapi3lint/ruleexternal"
+	"sigs.k8s.io/yaml"
 )
 
 type PolicyConfig struct {
-	Name                 string                `json:"name"`
-	Version              string                `json:"version"`
-	LastUpdated          time.Time             `json:"lastUpdated,omitempty"`
-	IncludeStandardRules bool                  `json:"includeStandardRules"`
-	Rules                map[string]RuleConfig `json:"rules,omitempty"`
-	NonStandardRules     []string              `json:"nonStandardRules,omitempty"`
-	xRuleCollections     RuleCollections       `json:"-"`
+	Name                 string                                    `json:"name"`
+	Version              string                                    `json:"version"`
+	LastUpdated          time.Time                                 `json:"lastUpdated,omitempty"`
+	Extends              []string                                  `json:"extends,omitempty"`
+	IncludeStandardRules bool                                      `json:"includeStandardRules"`
+	Rules                map[string]RuleConfig                     `json:"rules,omitempty"`
+	NonStandardRules     []string                                  `json:"nonStandardRules,omitempty"`
+	CustomRules          map[string]ruledeclarative.RuleDefinition `json:"customRules,omitempty"`
+	ExternalRules        map[string]ruleexternal.RuleDefinition    `json:"externalRules,omitempty"`
+	Overrides            []PolicyOverride                          `json:"overrides,omitempty"`
+	xRuleCollections     RuleCollections                           `json:"-"`
 }
 
+// NewPolicyConfigFile reads a JSON or YAML policy config file. YAML is
+// used for files with a `.yaml` or `.yml` extension. Base policy files in
+// `extends` are read and merged. Relative `extends` paths and relative
+// `externalRules` command paths are resolved from the directory of the file
+// that declares them.
 func NewPolicyConfigFile(filename string) (PolicyConfig, error) {
+	return readPolicyConfigFile(filename, map[string]int{})
+}
+
+func readPolicyConfigFile(filename string, visited map[string]int) (PolicyConfig, error) {
 	pol := PolicyConfig{}
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return pol, err
+	}
+	if _, ok := visited[absFilename]; ok {
+		return pol, fmt.Errorf("policy config extends cycle [%s]", filename)
+	}
+	visited[absFilename]++
+	defer delete(visited, absFilename)
+
 	bytes, err := os.ReadFile(filename)
 	if err != nil {
 		return pol, err
 	}
-	return pol, json.Unmarshal(bytes, &pol)
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == ".yaml" || ext == ".yml" {
+		bytes, err = yaml.YAMLToJSON(bytes)
+		if err != nil {
+			return pol, err
+		}
+	}
+	if err = json.Unmarshal(bytes, &pol); err != nil {
+		return pol, err
+	}
+	for ruleName, def := range pol.ExternalRules {
+		def.Command = strings.TrimSpace(def.Command)
+		if strings.ContainsRune(def.Command, '/') && !filepath.IsAbs(def.Command) {
+			def.Command = filepath.Join(filepath.Dir(absFilename), def.Command)
+		}
+		pol.ExternalRules[ruleName] = def
+	}
+	if len(pol.Extends) == 0 {
+		return pol, nil
+	}
+	merged := PolicyConfig{}
+	for _, baseFilename := range pol.Extends {
+		baseFilename = strings.TrimSpace(baseFilename)
+		if !filepath.IsAbs(baseFilename) {
+			baseFilename = filepath.Join(filepath.Dir(filename), baseFilename)
+		}
+		base, err := readPolicyConfigFile(baseFilename, visited)
+		if err != nil {
+			return pol, errorsutil.Wrap(err, fmt.Sprintf("extends [%s]", baseFilename))
+		}
+		merged = MergePolicyConfigs(merged, base)
+	}
+	return MergePolicyConfigs(merged, pol), nil
+}
+
+// MergePolicyConfigs returns `base` overridden by `policy`. `Rules`,
+// `CustomRules` and `ExternalRules` are merged by rule name with `policy` taking precedence,
+// `NonStandardRules` are combined, `Overrides` from `base` are applied before
+// those from `policy`, `IncludeStandardRules` is set if set in either and
+// `Name`, `Version` and `LastUpdated` are used from `policy` when set.
+func MergePolicyConfigs(base, policy PolicyConfig) PolicyConfig {
+	merged := PolicyConfig{
+		Name:                 base.Name,
+		Version:              base.Version,
+		LastUpdated:          base.LastUpdated,
+		Extends:              policy.Extends,
+		IncludeStandardRules: base.IncludeStandardRules || policy.IncludeStandardRules,
+		Rules:                map[string]RuleConfig{},
+		NonStandardRules: stringsutil.SliceCondenseSpace(
+			append(append([]string{}, base.NonStandardRules...), policy.NonStandardRules...), true, true),
+		CustomRules:      map[string]ruledeclarative.RuleDefinition{},
+		ExternalRules:    map[string]ruleexternal.RuleDefinition{},
+		Overrides:        append(append([]PolicyOverride{}, base.Overrides...), policy.Overrides...),
+		xRuleCollections: append(append(RuleCollections{}, base.xRuleCollections...), policy.xRuleCollections...)}
+	if len(strings.TrimSpace(policy.Name)) > 0 {
+		merged.Name = policy.Name
+	}
+	if len(strings.TrimSpace(policy.Version)) > 0 {
+		merged.Version = policy.Version
+	}
+	if !policy.LastUpdated.IsZero() {
+		merged.LastUpdated = policy.LastUpdated
+	}
+	for _, rules := range []map[string]RuleConfig{base.Rules, policy.Rules} {
+		for ruleName, ruleCfg := range rules {
+			merged.Rules[ruleName] = ruleCfg
+		}
+	}
+	for _, customRules := range []map[string]ruledeclarative.RuleDefinition{base.CustomRules, policy.CustomRules} {
+		for ruleName, def := range customRules {
+			merged.CustomRules[ruleName] = def
+		}
+	}
+	for _, externalRules := range []map[string]ruleexternal.RuleDefinition{base.ExternalRules, policy.ExternalRules} {
+		for ruleName, def := range externalRules {
+			merged.ExternalRules[ruleName] = def
+		}
+	}
+	if len(merged.NonStandardRules) == 0 {
+		merged.NonStandardRules = nil
+	}
+	if len(merged.Rules) == 0 {
+		merged.Rules = nil
+	}
+	if len(merged.CustomRules) == 0 {
+		merged.CustomRules = nil
+	}
+	if len(merged.ExternalRules) == 0 {
+		merged.ExternalRules = nil
+	}
+	if len(merged.Overrides) == 0 {
+		merged.Overrides = nil
+	}
+	return merged
 }
 
 const (
@@ -43,8 +165,12 @@ func (polCfg *PolicyConfig) RuleNames() map[string][]string {
 		RuleTypeXDefined:   {},
 		RuleTypeXUndefined: {}}
 	stdRules := NewRuleCollectionStandard()
+	xRuleCollections, err := polCfg.ruleCollections()
+	if err != nil {
+		xRuleCollections = polCfg.xRuleCollections
+	}
 	xRuleNames := map[string]int{} // defined = 1, undefined 0
-	for ruleName := range polCfg.Rules {
+	for ruleName := range polCfg.rulesConfig() {
 		ruleNamesMap[RuleTypeAll] = append(ruleNamesMap[RuleTypeAll], ruleName)
 		if polCfg.IncludeStandardRules &&
 			stdRules.RuleExists(ruleName) {
@@ -52,10 +178,10 @@ func (polCfg *PolicyConfig) RuleNames() map[string][]string {
 				append(ruleNamesMap[RuleTypeStandard], ruleName)
 			continue
 		}
-		if len(polCfg.xRuleCollections) == 0 {
+		if len(xRuleCollections) == 0 {
 			xRuleNames[ruleName] = 0
 		} else {
-			for _, ruleCollection := range polCfg.xRuleCollections {
+			for _, ruleCollection := range xRuleCollections {
 				if ruleCollection.RuleExists(ruleName) {
 					xRuleNames[ruleName] = 1
 				} else {
@@ -86,16 +212,96 @@ func (polCfg *PolicyConfig) AddRuleCollection(collection RuleCollection) {
 	polCfg.xRuleCollections = append(polCfg.xRuleCollections, collection)
 }
 
+// RuleConfig sets a rule's severity. `Options` are passed to rules that
+// implement `RuleConfigurable`.
 type RuleConfig struct {
-	Severity string `json:"severity"`
+	Severity string                 `json:"severity"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+}
+
+// CustomRulesCollectionName is the name of the rule collection compiled
+// from `PolicyConfig.CustomRules`.
+const CustomRulesCollectionName = "Policy Config Custom Rules"
+
+// ruleCollections returns the added rule collections and collections
+// compiled from `CustomRules` and `ExternalRules`.
+func (polCfg *PolicyConfig) ruleCollections() (RuleCollections, error) {
+	rcs := RuleCollections{}
+	if len(polCfg.CustomRules) > 0 {
+		custom := NewRuleCollectionSimple(CustomRulesCollectionName)
+		for ruleName, def := range polCfg.CustomRules {
+			rule, err := ruledeclarative.NewRule(ruleName, def)
+			if err != nil {
+				return polCfg.xRuleCollections, err
+			}
+			if err := custom.AddRule(rule); err != nil {
+				return polCfg.xRuleCollections, err
+			}
+		}
+		rcs = append(rcs, custom)
+	}
+	if len(polCfg.ExternalRules) > 0 {
+		external, err := NewRuleCollectionExternal(ExternalRulesCollectionName, polCfg.ExternalRules)
+		if err != nil {
+			return polCfg.xRuleCollections, err
+		}
+		rcs = append(rcs, external)
+	}
+	return append(rcs, polCfg.xRuleCollections...), nil
+}
+
+// AvailableRuleCollections returns the standard rule collection, followed by
+// collections compiled from `CustomRules` and `ExternalRules` and added rule
+// collections.
+func (polCfg *PolicyConfig) AvailableRuleCollections() (RuleCollections, error) {
+	rcs, err := polCfg.ruleCollections()
+	if err != nil {
+		return nil, err
+	}
+	return append(RuleCollections{NewRuleCollectionStandard()}, rcs...), nil
+}
+
+// rulesConfig returns `Rules` with custom and external rules that are not in
+// `Rules` added using the severity in the rule definition. Rules that are
+// only named in `Overrides` are added as disabled so overrides can enable them.
+func (polCfg *PolicyConfig) rulesConfig() map[string]RuleConfig {
+	rules := map[string]RuleConfig{}
+	for ruleName, ruleCfg := range polCfg.Rules {
+		rules[ruleName] = ruleCfg
+	}
+	for ruleName, def := range polCfg.CustomRules {
+		ruleName = strings.ToLower(strings.TrimSpace(ruleName))
+		if _, ok := rules[ruleName]; !ok {
+			rules[ruleName] = RuleConfig{Severity: def.Severity}
+		}
+	}
+	for ruleName, def := range polCfg.ExternalRules {
+		ruleName = strings.ToLower(strings.TrimSpace(ruleName))
+		if _, ok := rules[ruleName]; !ok {
+			rules[ruleName] = RuleConfig{Severity: def.Severity}
+		}
+	}
+	for _, override := range polCfg.Overrides {
+		for ruleName := range override.Rules {
+			ruleName = strings.ToLower(strings.TrimSpace(ruleName))
+			if _, ok := rules[ruleName]; !ok && ruleName != RuleNameAll {
+				rules[ruleName] = RuleConfig{Severity: severity.SeverityDisabled}
+			}
+		}
+	}
+	return rules
 }
 
 func (polCfg *PolicyConfig) Policy() (Policy, error) {
 	pol := NewPolicy()
 	stdRules := NewRuleCollectionStandard()
 	ruleCollectionsMap := map[string][]string{}
+	xRuleCollections, err := polCfg.ruleCollections()
+	if err != nil {
+		return pol, errorsutil.Wrap(err, "custom rule error. PolicyConfig.Policy()")
+	}
 
-	for ruleName, ruleCfg := range polCfg.Rules {
+	for ruleName, ruleCfg := range polCfg.rulesConfig() {
 		if polCfg.IncludeStandardRules {
 			if stdRules.RuleExists(ruleName) {
 				if _, ok := ruleCollectionsMap[ruleName]; !ok {
@@ -106,6 +312,9 @@ func (polCfg *PolicyConfig) Policy() (Policy, error) {
 				if err != nil {
 					return pol, errorsutil.Wrap(err, "standard error not found. PolicyConfig.Policy()")
 				}
+				if err = configureRule(rule, ruleCfg); err != nil {
+					return pol, err
+				}
 				if err = pol.AddRule(rule, ruleCfg.Severity, true); err != nil {
 					return pol, errorsutil.Wrap(err, fmt.Sprintf("Policy.AddRule() [%s]", ruleName))
 				}
@@ -115,7 +324,7 @@ func (polCfg *PolicyConfig) Policy() (Policy, error) {
 					}*/
 			}
 		}
-		for _, collection := range polCfg.xRuleCollections {
+		for _, collection := range xRuleCollections {
 			if collection.RuleExists(ruleName) {
 				if _, ok := ruleCollectionsMap[ruleName]; !ok {
 					ruleCollectionsMap[ruleName] = []string{}
@@ -125,6 +334,9 @@ func (polCfg *PolicyConfig) Policy() (Policy, error) {
 				if err != nil {
 					return pol, errorsutil.Wrap(err, "collection rule exists but not found. PolicyConfig.Policy()")
 				}
+				if err = configureRule(rule, ruleCfg); err != nil {
+					return pol, err
+				}
 				if err = pol.AddRule(rule, ruleCfg.Severity, true); err != nil {
 					return pol, errorsutil.Wrap(err, fmt.Sprintf("Policy.AddRule() [%s]", ruleName))
 				}
@@ -135,6 +347,10 @@ func (polCfg *PolicyConfig) Policy() (Policy, error) {
 		}
 	}
 
+	if err := pol.SetOverrides(polCfg.Overrides); err != nil {
+		return pol, errorsutil.Wrap(err, "overrides error. PolicyConfig.Policy()")
+	}
+
 	collisions := map[string][]string{}
 	for ruleName, collections := range ruleCollectionsMap {
 		if len(collections) > 1 {
@@ -152,3 +368,18 @@ func (polCfg *PolicyConfig) Policy() (Policy, error) {
 
 	return pol, nil
 }
+
+// configureRule passes `RuleConfig.Options` to rules that implement `RuleConfigurable`.
+func configureRule(rule Rule, ruleCfg RuleConfig) error {
+	if len(ruleCfg.Options) == 0 {
+		return nil
+	}
+	configurable, ok := rule.(RuleConfigurable)
+	if !ok {
+		return fmt.Errorf("rule [%s] does not support options", rule.Name())
+	}
+	if err := configurable.Configure(ruleCfg.Options); err != nil {
+		return errorsutil.Wrap(err, fmt.Sprintf("RuleConfigurable.Configure() [%s]", rule.Name()))
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package openapi3lint
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"golang.org/x/exp/slices"
+)
+
+const testPolicyConfigCustomRulesYAML = `name: Custom Rules Policy
+rules:
+  operation-summary-length:
+    severity: warning
+customRules:
+  operation-summary-length:
+    description: Operation summaries must be between 5 and 40 characters.
+    target: operation
+    field: summary
+    assert:
+      exists: true
+      minLength: 5
+      maxLength: 40
+  parameter-name-camelcase:
+    target: parameter
+    field: name
+    assert:
+      casing: camelCase
+  operation-x-audience-enum:
+    severity: error
+    target: operation
+    field: x-audience
+    assert:
+      enum: [public, internal]
+  tag-description-exist:
+    target: tag
+    field: description
+    assert:
+      exists: true
+`
+
+const testSpecCustomRules = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "tags": [{"name": "Users"}],
+  "paths": {
+    "/users": {
+      "get": {
+        "summary": "List",
+        "x-audience": "partner",
+        "parameters": [
+          {"name": "page_size", "in": "query", "schema": {"type": "integer"}}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+// TestPolicyConfigCustomRules ensures declarative rules in a YAML policy config
+// are compiled into rules with the configured severities.
+func TestPolicyConfigCustomRules(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(filename, []byte(testPolicyConfigCustomRulesYAML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	polCfg, err := NewPolicyConfigFile(filename)
+	if err != nil {
+		t.Fatalf("NewPolicyConfigFile() Error [%s]", err.Error())
+	}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	wantRules := []string{
+		"operation-summary-length",
+		"operation-x-audience-enum",
+		"parameter-name-camelcase",
+		"tag-description-exist"}
+	if got := pol.RuleNames(); !slices.Equal(got, wantRules) {
+		t.Errorf("Policy.RuleNames() Mismatch: want [%v], got [%v]", wantRules, got)
+	}
+	if polRule, ok := pol.PolicyRule("operation-summary-length"); !ok || polRule.Severity != severity.SeverityWarning {
+		t.Errorf("Policy.PolicyRule() severity Mismatch: want [%s], got [%s]", severity.SeverityWarning, polRule.Severity)
+	}
+
+	spec, err := openapi3.Parse([]byte(testSpecCustomRules))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityInformational)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	got := []string{}
+	for ruleName, vset := range vsets.ByRule {
+		for _, vio := range vset.Violations {
+			got = append(got, ruleName+" "+vio.Location)
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"operation-summary-length spec.json#/paths/~1users/get/summary",
+		"operation-x-audience-enum spec.json#/paths/~1users/get/x-audience",
+		"parameter-name-camelcase spec.json#/paths/~1users/get/parameters/0/name",
+		"tag-description-exist spec.json#/tags/0/description"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Policy.ValidateSpec() Mismatch: want [%v], got [%v]", want, got)
+	}
+}
+
+// TestPolicyConfigRuleOptions ensures `RuleConfig.Options` are passed to
+// configurable rules and rejected for other rules.
+func TestPolicyConfigRuleOptions(t *testing.T) {
+	polCfg := PolicyConfig{
+		IncludeStandardRules: true,
+		Rules: map[string]RuleConfig{
+			lintutil.RulenameOpResponseErrorSchema: {
+				Severity: severity.SeverityError,
+				Options:  map[string]interface{}{"errorSchemas": []string{"LegacyError"}}}}}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	spec, err := openapi3.ReadFile(testSpecRulesResponsesFile, false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.yaml", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	vset := vsets.ByRule[lintutil.RulenameOpResponseErrorSchema]
+	got := vset.Locations().Locations
+	sort.Strings(got)
+	want := []string{
+		"spec.yaml#/paths/~1users/get/responses/400/content/application~1json/schema",
+		"spec.yaml#/paths/~1users/get/responses/default/content/application~1json/schema",
+		"spec.yaml#/paths/~1users~1{userId}/put/responses/500/content/application~1json/schema"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Policy.ValidateSpec() Mismatch: want [%v], got [%v]", want, got)
+	}
+
+	polCfg.Rules = map[string]RuleConfig{
+		lintutil.RuleOpIdExist: {
+			Severity: severity.SeverityError,
+			Options:  map[string]interface{}{"errorSchemas": []string{"Error"}}}}
+	if _, err := polCfg.Policy(); err == nil {
+		t.Errorf("PolicyConfig.Policy() Mismatch: want error for unsupported options, got nil")
+	}
+}
+
+// TestPolicyConfigOverridesErrorSchema ensures per-path overrides apply to the
+// operation scope error schema rule.
+func TestPolicyConfigOverridesErrorSchema(t *testing.T) {
+	polCfg := PolicyConfig{
+		IncludeStandardRules: true,
+		Rules: map[string]RuleConfig{
+			lintutil.RulenameOpResponseErrorSchema: {
+				Severity: severity.SeverityError,
+				Options:  map[string]interface{}{"errorSchemas": []string{"LegacyError"}}}},
+		Overrides: []PolicyOverride{{
+			Paths: []string{"/users/*"},
+			Rules: map[string]RuleConfig{
+				lintutil.RulenameOpResponseErrorSchema: {Severity: severity.SeverityWarning}}}}}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	spec, err := openapi3.ReadFile(testSpecRulesResponsesFile, false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.yaml", severity.SeverityWarning)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	got := []string{}
+	for _, vio := range vsets.ByRule[lintutil.RulenameOpResponseErrorSchema].Violations {
+		got = append(got, vio.Severity+" "+vio.Location)
+	}
+	sort.Strings(got)
+	want := []string{
+		"err spec.yaml#/paths/~1users/get/responses/400/content/application~1json/schema",
+		"err spec.yaml#/paths/~1users/get/responses/default/content/application~1json/schema",
+		"warning spec.yaml#/paths/~1users~1{userId}/put/responses/500/content/application~1json/schema"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Policy.ValidateSpec() Mismatch: want [%v], got [%v]", want, got)
+	}
+}
+
+const testPolicyConfigBaseYAML = `name: Platform Policy
+includeStandardRules: true
+rules:
+  operation-operationid-exist:
+    severity: error
+  operation-summary-exist:
+    severity: error
+overrides:
+  - tags: [Legacy]
+    rules:
+      operation-summary-exist:
+        severity: warning
+`
+
+const testPolicyConfigTeamJSON = `{
+  "name": "Team Policy",
+  "extends": ["base/platform.yaml"],
+  "rules": {
+    "operation-summary-exist": {"severity": "warning"},
+    "operation-description-exist": {"severity": "error"}
+  },
+  "overrides": [
+    {"paths": ["/internal/**"], "rules": {"*": {"severity": "off"}}},
+    {"xProperties": {"x-beta": true}, "rules": {"operation-tags-count-one": {"severity": "error"}}}
+  ]
+}`
+
+const testSpecOverrides = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {
+    "/users": {
+      "get": {
+        "tags": ["Legacy"],
+        "responses": {"200": {"description": "OK"}}
+      },
+      "post": {
+        "tags": ["Users", "Admin"],
+        "x-beta": true,
+        "responses": {"200": {"description": "OK"}}
+      }
+    },
+    "/internal/jobs/{jobId}": {
+      "get": {
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+// TestPolicyConfigExtendsOverrides ensures base policies are merged and
+// overrides change rule severities per operation.
+func TestPolicyConfigExtendsOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "base"), 0700); err != nil {
+		t.Fatalf("os.MkdirAll() Error [%s]", err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "base", "platform.yaml"), []byte(testPolicyConfigBaseYAML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	filename := filepath.Join(dir, "team.json")
+	if err := os.WriteFile(filename, []byte(testPolicyConfigTeamJSON), 0600); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	polCfg, err := NewPolicyConfigFile(filename)
+	if err != nil {
+		t.Fatalf("NewPolicyConfigFile() Error [%s]", err.Error())
+	}
+	if polCfg.Name != "Team Policy" || !polCfg.IncludeStandardRules || len(polCfg.Overrides) != 3 {
+		t.Errorf("NewPolicyConfigFile() Mismatch: name [%s] includeStandardRules [%v] overrides [%d]",
+			polCfg.Name, polCfg.IncludeStandardRules, len(polCfg.Overrides))
+	}
+	if sev := polCfg.Rules[lintutil.RulenameOpSummaryExist].Severity; sev != "warning" {
+		t.Errorf("NewPolicyConfigFile() rule severity Mismatch: want [warning], got [%s]", sev)
+	}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	spec, err := openapi3.Parse([]byte(testSpecOverrides))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityWarning)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	got := []string{}
+	for ruleName, vset := range vsets.ByRule {
+		for _, vio := range vset.Violations {
+			got = append(got, ruleName+" "+vio.Severity+" "+vio.Location)
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"operation-description-exist err spec.json#/paths/~1users/get/description",
+		"operation-description-exist err spec.json#/paths/~1users/post/description",
+		"operation-operationid-exist err spec.json#/paths/~1users/get/operationId",
+		"operation-operationid-exist err spec.json#/paths/~1users/post/operationId",
+		"operation-summary-exist warning spec.json#/paths/~1users/get/summary",
+		"operation-summary-exist warning spec.json#/paths/~1users/post/summary",
+		"operation-tags-count-one err spec.json#/paths/~1users/post/tags"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Policy.ValidateSpec() Mismatch: want [%v], got [%v]", want, got)
+	}
+}
+
+// TestPolicyConfigExtendsCycle ensures an `extends` cycle is an error.
+func TestPolicyConfigExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"extends":["b.json"]}`), 0600); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"extends":["a.json"]}`), 0600); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	if _, err := NewPolicyConfigFile(filepath.Join(dir, "a.json")); err == nil {
+		t.Errorf("NewPolicyConfigFile() Mismatch: want cycle error, got nil")
+	}
+}
+
+const testPolicyConfigExternalRulesYAML = `name: External Rules Policy
+externalRules:
+  operation-external-check:
+    description: Checks operations with an external tool.
+    severity: warning
+    scope: operation
+    command: ./bin/check.sh
+    timeout: 10s
+`
+
+// TestPolicyConfigExternalRules ensures external rules in a policy config are
+// run with command paths relative to the policy file.
+func TestPolicyConfigExternalRules(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported")
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0700); err != nil {
+		t.Fatalf("os.MkdirAll() Error [%s]", err.Error())
+	}
+	script := "#!/bin/sh\ncat > /dev/null\necho '[{\"Value\":\"external\"}]'\n"
+	if err := os.WriteFile(filepath.Join(dir, "bin", "check.sh"), []byte(script), 0700); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	filename := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(filename, []byte(testPolicyConfigExternalRulesYAML), 0600); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	polCfg, err := NewPolicyConfigFile(filename)
+	if err != nil {
+		t.Fatalf("NewPolicyConfigFile() Error [%s]", err.Error())
+	}
+	rcs, err := polCfg.AvailableRuleCollections()
+	if err != nil {
+		t.Fatalf("PolicyConfig.AvailableRuleCollections() Error [%s]", err.Error())
+	}
+	if _, rc, err := rcs.Rule("operation-external-check"); err != nil {
+		t.Errorf("RuleCollections.Rule() Error [%s]", err.Error())
+	} else if rc.Name() != ExternalRulesCollectionName {
+		t.Errorf("RuleCollections.Rule() Mismatch: want [%s], got [%s]", ExternalRulesCollectionName, rc.Name())
+	}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	spec, err := openapi3.Parse([]byte(testSpecCustomRules))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityInformational)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	vset := vsets.ByRule["operation-external-check"]
+	want := []string{"spec.json#/paths/~1users/get"}
+	if got := vset.Locations().Locations; !slices.Equal(got, want) {
+		t.Errorf("Policy.ValidateSpec() Mismatch: want [%v], got [%v] [%v]", want, got, vset.Violations)
+	}
+	for _, vio := range vset.Violations {
+		if vio.Severity != severity.SeverityWarning {
+			t.Errorf("Policy.ValidateSpec() severity Mismatch: want [%s], got [%s]", severity.SeverityWarning, vio.Severity)
+		}
+	}
+}
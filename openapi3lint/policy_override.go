@@ -0,0 +1,189 @@
+package openapi3lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+)
+
+// RuleNameAll is used as a rule name in `PolicyOverride.Rules` to match all rules.
+const RuleNameAll = "*"
+
+// PolicyOverride changes rule severities for operations matching all of the
+// criteria that are set. `Tags` matches operations with any of the tags, `Paths`
+// matches operations with a path matching any of the globs and `XProperties`
+// matches operations with all of the `x-` properties. A `nil` `XProperties` value
+// matches any value. In path globs, `*` matches within a path segment and `**`
+// matches across segments. `Rules` are keyed by rule name or `*` for all rules.
+// Use the `off` severity to disable a rule.
+type PolicyOverride struct {
+	Tags        []string               `json:"tags,omitempty"`
+	Paths       []string               `json:"paths,omitempty"`
+	XProperties map[string]interface{} `json:"xProperties,omitempty"`
+	Rules       map[string]RuleConfig  `json:"rules"`
+}
+
+// policyOverride is a `PolicyOverride` with compiled path globs and
+// canonical severities.
+type policyOverride struct {
+	tags        map[string]int
+	paths       []*regexp.Regexp
+	xProperties map[string]interface{}
+	severities  map[string]string
+}
+
+func newPolicyOverride(override PolicyOverride) (policyOverride, error) {
+	po := policyOverride{
+		tags:        map[string]int{},
+		paths:       []*regexp.Regexp{},
+		xProperties: override.XProperties,
+		severities:  map[string]string{}}
+	for _, tag := range override.Tags {
+		po.tags[strings.TrimSpace(tag)]++
+	}
+	for _, glob := range override.Paths {
+		rx, err := PathGlobToRegexp(glob)
+		if err != nil {
+			return po, err
+		}
+		po.paths = append(po.paths, rx)
+	}
+	for ruleName, ruleCfg := range override.Rules {
+		sev, err := severity.Parse(ruleCfg.Severity)
+		if err != nil {
+			return po, fmt.Errorf("override rule [%s] severity not found [%s]", ruleName, ruleCfg.Severity)
+		}
+		po.severities[strings.ToLower(strings.TrimSpace(ruleName))] = sev
+	}
+	return po, nil
+}
+
+// match returns true if the operation matches all criteria that are set.
+func (po policyOverride) match(path string, op *oas3.Operation) bool {
+	if op == nil {
+		return false
+	}
+	if len(po.tags) > 0 {
+		found := false
+		for _, tag := range op.Tags {
+			if _, ok := po.tags[strings.TrimSpace(tag)]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(po.paths) > 0 {
+		found := false
+		for _, rx := range po.paths {
+			if rx.MatchString(path) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for xPropName, xPropValueWant := range po.xProperties {
+		xPropValue, ok := op.Extensions[xPropName]
+		if !ok || (xPropValueWant != nil && !jsonEqual(xPropValue, xPropValueWant)) {
+			return false
+		}
+	}
+	return true
+}
+
+// severity returns the override severity for a rule name.
+func (po policyOverride) severity(ruleName string) (string, bool) {
+	if sev, ok := po.severities[ruleName]; ok {
+		return sev, true
+	}
+	sev, ok := po.severities[RuleNameAll]
+	return sev, ok
+}
+
+// jsonEqual compares two values, including `json.RawMessage`, by their JSON
+// representation.
+func jsonEqual(a, b interface{}) bool {
+	var aa, bb interface{}
+	if err := jsonRoundTrip(a, &aa); err != nil {
+		return false
+	}
+	if err := jsonRoundTrip(b, &bb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aa, bb)
+}
+
+func jsonRoundTrip(v, out interface{}) error {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, out)
+}
+
+// PathGlobToRegexp converts a path glob to a regular expression. `**` matches
+// any characters, `*` matches any characters except `/` and `?` matches one
+// character except `/`.
+func PathGlobToRegexp(glob string) (*regexp.Regexp, error) {
+	glob = strings.TrimSpace(glob)
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// SetOverrides sets the overrides used for operation scope rules. Overrides are
+// applied in order so later overrides take precedence.
+func (pol *Policy) SetOverrides(overrides []PolicyOverride) error {
+	pos := []policyOverride{}
+	for _, override := range overrides {
+		po, err := newPolicyOverride(override)
+		if err != nil {
+			return err
+		}
+		pos = append(pos, po)
+	}
+	pol.overrides = pos
+	return nil
+}
+
+// operationSeverity returns the severity for a rule and operation after
+// applying overrides.
+func (pol *Policy) operationSeverity(policyRule PolicyRule, path string, op *oas3.Operation) string {
+	sev := policyRule.Severity
+	ruleName := policyRule.Rule.Name()
+	for _, po := range pol.overrides {
+		if !po.match(path, op) {
+			continue
+		}
+		if overrideSev, ok := po.severity(ruleName); ok {
+			sev = overrideSev
+		}
+	}
+	return sev
+}
@@ -0,0 +1,33 @@
+package openapi3lint
+
+import (
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// TestValidateSpecFilesPositions ensures violations include source line and column.
+func TestValidateSpecFilesPositions(t *testing.T) {
+	polCfg := PolicyConfig{
+		IncludeStandardRules: true,
+		Rules: map[string]RuleConfig{
+			lintutil.RuleOpIdExist: {Severity: severity.SeverityError}}}
+	pol, err := polCfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpecFiles(severity.SeverityError, []string{"testdata/spec_rules_standard.yaml"})
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpecFiles() Error [%s]", err.Error())
+	}
+	vios := vsets.Violations()
+	if len(vios) != 1 {
+		t.Fatalf("Policy.ValidateSpecFiles() Mismatch: want [1] violations, got [%d]", len(vios))
+	}
+	// missing `operationId` resolves to the `delete` operation key.
+	if vios[0].Line != 20 || vios[0].Column != 5 {
+		t.Errorf("PolicyViolation.Line/Column Mismatch: want [20:5], got [%d:%d]",
+			vios[0].Line, vios[0].Column)
+	}
+}
@@ -1,6 +1,7 @@
 package openapi3lint
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/grokify/spectrum/openapi3lint/lintutil"
 )
 
+// Rule is a lint rule.
 type Rule interface {
 	Name() string
 	Scope() string
@@ -19,6 +21,106 @@ type Rule interface {
 	ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation
 }
 
+// RuleDescriber is optionally implemented by rules to return a human readable
+// explanation of what the rule checks, used by `oas3lint --explain` and
+// `--list-rules`.
+type RuleDescriber interface {
+	Description() string
+}
+
+// RuleDescription returns the description of a rule that implements
+// `RuleDescriber`, or an empty string.
+func RuleDescription(rule Rule) string {
+	if describer, ok := rule.(RuleDescriber); ok {
+		return describer.Description()
+	}
+	return ""
+}
+
+// RuleContext is optionally implemented by rules with `specification` or
+// `operation` scope that should stop when linting is canceled, such as rules
+// that run external processes, or that cache values computed from the spec
+// with `lintutil.SpecCacheValue()`. `Policy.ValidateSpecContext()` calls these in
+// place of `ProcessSpec()` and `ProcessOperation()`, and a returned error stops
+// linting the spec.
+type RuleContext interface {
+	ProcessSpecContext(ctx context.Context, spec *openapi3.Spec, pointerBase string) ([]lintutil.PolicyViolation, error)
+	ProcessOperationContext(ctx context.Context, spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) ([]lintutil.PolicyViolation, error)
+}
+
+// RuleParameter is implemented by rules with `parameter` scope. It is called
+// once per parameter definition, excluding `$ref` references.
+type RuleParameter interface {
+	ProcessParameter(spec *openapi3.Spec, paramRef *oas3.ParameterRef, paramPointer string) []lintutil.PolicyViolation
+}
+
+// RuleRequestBody is implemented by rules with `request-body` scope. It is called
+// once per request body definition, excluding `$ref` references.
+type RuleRequestBody interface {
+	ProcessRequestBody(spec *openapi3.Spec, reqBodyRef *oas3.RequestBodyRef, reqBodyPointer string) []lintutil.PolicyViolation
+}
+
+// RuleResponse is implemented by rules with `response` scope. It is called once per
+// response definition, excluding `$ref` references. `key` is the component name or
+// the operation status code.
+type RuleResponse interface {
+	ProcessResponse(spec *openapi3.Spec, respRef *oas3.ResponseRef, respPointer, key string) []lintutil.PolicyViolation
+}
+
+// RuleSchema is implemented by rules with `schema` scope. It is called once
+// per schema under `#/components/schemas`.
+type RuleSchema interface {
+	ProcessSchema(spec *openapi3.Spec, schemaRef *oas3.SchemaRef, schemaPointer, schemaName string) []lintutil.PolicyViolation
+}
+
+// RuleSchemaProperty is implemented by rules with `schema-property` scope. It is
+// called once per property of schemas under `#/components/schemas`.
+type RuleSchemaProperty interface {
+	ProcessSchemaProperty(spec *openapi3.Spec, propRef *oas3.SchemaRef, propPointer, schemaName, propName string) []lintutil.PolicyViolation
+}
+
+// RuleTag is implemented by rules with `tag` scope. It is called once per tag
+// in the top level `tags` property.
+type RuleTag interface {
+	ProcessTag(spec *openapi3.Spec, tag *oas3.Tag, tagPointer string) []lintutil.PolicyViolation
+}
+
+// RuleConfigurable is implemented by rules that accept options from
+// `RuleConfig.Options` in a `PolicyConfig`.
+type RuleConfigurable interface {
+	Configure(opts map[string]interface{}) error
+}
+
+// ruleImplementsScope checks that a rule implements the optional
+// interface required by its scope.
+func ruleImplementsScope(rule Rule) bool {
+	scope, err := lintutil.ParseScope(rule.Scope())
+	if err != nil {
+		return false
+	}
+	switch scope {
+	case lintutil.ScopeParameter:
+		_, ok := rule.(RuleParameter)
+		return ok
+	case lintutil.ScopeRequestBody:
+		_, ok := rule.(RuleRequestBody)
+		return ok
+	case lintutil.ScopeResponse:
+		_, ok := rule.(RuleResponse)
+		return ok
+	case lintutil.ScopeSchema:
+		_, ok := rule.(RuleSchema)
+		return ok
+	case lintutil.ScopeSchemaProperty:
+		_, ok := rule.(RuleSchemaProperty)
+		return ok
+	case lintutil.ScopeTag:
+		_, ok := rule.(RuleTag)
+		return ok
+	}
+	return true
+}
+
 type PolicyRule struct {
 	Rule     Rule
 	Severity string
@@ -1,5 +1,7 @@
 package openapi3lint
 
+import "fmt"
+
 type RuleCollections []RuleCollection
 
 type RuleCollection interface {
@@ -8,3 +10,15 @@ type RuleCollection interface {
 	RuleExists(ruleName string) bool
 	Rule(ruleName string) (Rule, error)
 }
+
+// Rule returns the rule from the first collection that has `ruleName` and
+// the collection it belongs to.
+func (rcs RuleCollections) Rule(ruleName string) (Rule, RuleCollection, error) {
+	for _, rc := range rcs {
+		if rc.RuleExists(ruleName) {
+			rule, err := rc.Rule(ruleName)
+			return rule, rc, err
+		}
+	}
+	return EmptyRule{}, nil, fmt.Errorf("rule [%s] not found", ruleName)
+}
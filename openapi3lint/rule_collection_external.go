@@ -0,0 +1,62 @@
+package openapi3lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grokify/spectrum/openapi3lint/ruleexternal"
+)
+
+// ExternalRulesCollectionName is the name of the rule collection compiled
+// from `PolicyConfig.ExternalRules`.
+const ExternalRulesCollectionName = "Policy Config External Rules"
+
+// RuleCollectionExternal is a collection of rules that run external executables.
+type RuleCollectionExternal struct {
+	name  string
+	rules map[string]*ruleexternal.RuleExternal
+}
+
+// NewRuleCollectionExternal returns a collection with a rule for each definition.
+func NewRuleCollectionExternal(name string, defs map[string]ruleexternal.RuleDefinition) (RuleCollectionExternal, error) {
+	rc := RuleCollectionExternal{
+		name:  name,
+		rules: map[string]*ruleexternal.RuleExternal{}}
+	for ruleName, def := range defs {
+		rule, err := ruleexternal.NewRule(ruleName, def)
+		if err != nil {
+			return rc, err
+		}
+		rc.rules[rule.Name()] = rule
+	}
+	return rc, nil
+}
+
+func (rc RuleCollectionExternal) Name() string {
+	if len(strings.TrimSpace(rc.name)) > 0 {
+		return rc.name
+	}
+	return ExternalRulesCollectionName
+}
+
+func (rc RuleCollectionExternal) RuleNames() []string {
+	names := []string{}
+	for ruleName := range rc.rules {
+		names = append(names, ruleName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (rc RuleCollectionExternal) RuleExists(ruleName string) bool {
+	_, ok := rc.rules[ruleName]
+	return ok
+}
+
+func (rc RuleCollectionExternal) Rule(ruleName string) (Rule, error) {
+	if rule, ok := rc.rules[ruleName]; ok {
+		return rule, nil
+	}
+	return EmptyRule{}, fmt.Errorf("rule not found [%s]", ruleName)
+}
@@ -12,6 +12,12 @@ type RuleCollectionSimple struct {
 	rules map[string]Rule
 }
 
+func NewRuleCollectionSimple(name string) RuleCollectionSimple {
+	return RuleCollectionSimple{
+		name:  name,
+		rules: map[string]Rule{}}
+}
+
 func (simple RuleCollectionSimple) AddRule(rule Rule) error {
 	ruleName := rule.Name()
 	if len(strings.TrimSpace(ruleName)) == 0 {
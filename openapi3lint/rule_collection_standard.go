@@ -7,12 +7,23 @@ import (
 
 	"github.com/grokify/mogo/text/stringcase"
 	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"github.com/grokify/spectrum/openapi3lint/ruleexamplevalid"
 	"github.com/grokify/spectrum/openapi3lint/ruleintstdformat"
+	"github.com/grokify/spectrum/openapi3lint/ruleopdescexist"
+	"github.com/grokify/spectrum/openapi3lint/ruleopidexist"
 	"github.com/grokify/spectrum/openapi3lint/ruleopidstyle"
+	"github.com/grokify/spectrum/openapi3lint/ruleopresponseerrorschema"
+	"github.com/grokify/spectrum/openapi3lint/ruleopresponses"
 	"github.com/grokify/spectrum/openapi3lint/ruleopsummaryexist"
 	"github.com/grokify/spectrum/openapi3lint/ruleopsummarystylefirstuppercase"
+	"github.com/grokify/spectrum/openapi3lint/ruleoptagscountone"
+	"github.com/grokify/spectrum/openapi3lint/ruleoptagsdefined"
+	"github.com/grokify/spectrum/openapi3lint/rulepathparamnameexist"
 	"github.com/grokify/spectrum/openapi3lint/rulepathparamstyle"
+	"github.com/grokify/spectrum/openapi3lint/ruleschemaduplicate"
+	"github.com/grokify/spectrum/openapi3lint/ruleschemanamestyle"
 	"github.com/grokify/spectrum/openapi3lint/ruleschemaobjectpropsexist"
+	"github.com/grokify/spectrum/openapi3lint/ruleschemapropdescexist"
 	"github.com/grokify/spectrum/openapi3lint/ruleschemapropenumstyle"
 	"github.com/grokify/spectrum/openapi3lint/ruleschemareferences"
 	"github.com/grokify/spectrum/openapi3lint/ruletagstylefirstuppercase"
@@ -48,23 +59,39 @@ func (std RuleCollectionStandard) RuleExists(ruleName string) bool {
 func (std RuleCollectionStandard) RuleNames() []string {
 	rulenames := []string{
 		lintutil.RulenameDatatypeIntFormatStandardExist,
+		lintutil.RulenameExampleSchemaValid,
+		lintutil.RuleOpDescExist,
+		lintutil.RuleOpIdExist,
 		lintutil.RulenameOpIdStyleCamelCase,
 		lintutil.RulenameOpIdStyleKebabCase,
 		lintutil.RulenameOpIdStylePascalCase,
 		lintutil.RulenameOpIdStyleSnakeCase,
+		lintutil.RulenameOpResponseErrorExist,
+		lintutil.RulenameOpResponseErrorSchema,
+		lintutil.RulenameOpResponseNoContent,
+		lintutil.RulenameOpResponseSuccessExist,
 		lintutil.RulenameOpSummaryExist,
 		lintutil.RulenameOpSummaryStyleFirstUpperCase,
+		lintutil.RuleOpTagsCountOneOnly,
+		lintutil.RulenameOpTagsDefined,
+		lintutil.RulePathParamNameExist,
 		lintutil.RulenamePathParamStyleCamelCase,
 		lintutil.RulenamePathParamStyleKebabCase,
 		lintutil.RulenamePathParamStylePascalCase,
 		lintutil.RulenamePathParamStyleSnakeCase,
+		lintutil.RulenameSchemaDuplicate,
 		lintutil.RulenameSchemaHasReference,
+		lintutil.RulenameSchemaNameStyleCamelCase,
+		lintutil.RulenameSchemaNameStyleKebabCase,
+		lintutil.RulenameSchemaNameStylePascalCase,
+		lintutil.RulenameSchemaNameStyleSnakeCase,
 		lintutil.RulenameSchemaReferenceHasSchema,
 		lintutil.RulenameSchemaObjectPropsExist,
 		lintutil.RulenameSchemaPropEnumStyleCamelCase,
 		lintutil.RulenameSchemaPropEnumStyleKebabCase,
 		lintutil.RulenameSchemaPropEnumStylePascalCase,
 		lintutil.RulenameSchemaPropEnumStyleSnakeCase,
+		lintutil.RuleSchemaPropDescExist,
 		lintutil.RulenameTagStyleFirstUpperCase,
 	}
 	sort.Strings(rulenames)
@@ -76,6 +103,13 @@ func (std RuleCollectionStandard) Rule(name string) (Rule, error) {
 	switch name {
 	case lintutil.RulenameDatatypeIntFormatStandardExist:
 		return ruleintstdformat.NewRule(), nil
+	case lintutil.RulenameExampleSchemaValid:
+		return ruleexamplevalid.NewRule(), nil
+
+	case lintutil.RuleOpDescExist:
+		return ruleopdescexist.NewRule(), nil
+	case lintutil.RuleOpIdExist:
+		return ruleopidexist.NewRule(), nil
 
 	case lintutil.RulenameOpIdStyleCamelCase:
 		return ruleopidstyle.NewRule(stringcase.CamelCase)
@@ -86,11 +120,26 @@ func (std RuleCollectionStandard) Rule(name string) (Rule, error) {
 	case lintutil.RulenameOpIdStyleSnakeCase:
 		return ruleopidstyle.NewRule(stringcase.SnakeCase)
 
+	case lintutil.RulenameOpResponseErrorExist,
+		lintutil.RulenameOpResponseNoContent,
+		lintutil.RulenameOpResponseSuccessExist:
+		return ruleopresponses.NewRule(name)
+	case lintutil.RulenameOpResponseErrorSchema:
+		return ruleopresponseerrorschema.NewRule(), nil
+
 	case lintutil.RulenameOpSummaryExist:
 		return ruleopsummaryexist.NewRule(), nil
 	case lintutil.RulenameOpSummaryStyleFirstUpperCase:
 		return ruleopsummarystylefirstuppercase.NewRule(), nil
 
+	case lintutil.RuleOpTagsCountOneOnly:
+		return ruleoptagscountone.NewRule(), nil
+	case lintutil.RulenameOpTagsDefined:
+		return ruleoptagsdefined.NewRule(), nil
+
+	case lintutil.RulePathParamNameExist:
+		return rulepathparamnameexist.NewRule(), nil
+
 	case lintutil.RulenamePathParamStyleCamelCase:
 		return rulepathparamstyle.NewRule(stringcase.CamelCase)
 	case lintutil.RulenamePathParamStyleKebabCase:
@@ -100,11 +149,23 @@ func (std RuleCollectionStandard) Rule(name string) (Rule, error) {
 	case lintutil.RulenamePathParamStyleSnakeCase:
 		return rulepathparamstyle.NewRule(stringcase.SnakeCase)
 
+	case lintutil.RulenameSchemaDuplicate:
+		return ruleschemaduplicate.NewRule(), nil
+
 	case lintutil.RulenameSchemaHasReference:
 		return ruleschemareferences.NewRule(lintutil.RulenameSchemaHasReference)
 	case lintutil.RulenameSchemaReferenceHasSchema:
 		return ruleschemareferences.NewRule(lintutil.RulenameSchemaReferenceHasSchema)
 
+	case lintutil.RulenameSchemaNameStyleCamelCase:
+		return ruleschemanamestyle.NewRule(stringcase.CamelCase)
+	case lintutil.RulenameSchemaNameStyleKebabCase:
+		return ruleschemanamestyle.NewRule(stringcase.KebabCase)
+	case lintutil.RulenameSchemaNameStylePascalCase:
+		return ruleschemanamestyle.NewRule(stringcase.PascalCase)
+	case lintutil.RulenameSchemaNameStyleSnakeCase:
+		return ruleschemanamestyle.NewRule(stringcase.SnakeCase)
+
 	case lintutil.RulenameSchemaObjectPropsExist:
 		return ruleschemaobjectpropsexist.NewRule(), nil
 
@@ -117,6 +178,9 @@ func (std RuleCollectionStandard) Rule(name string) (Rule, error) {
 	case lintutil.RulenameSchemaPropEnumStyleSnakeCase:
 		return ruleschemapropenumstyle.NewRule(stringcase.SnakeCase)
 
+	case lintutil.RuleSchemaPropDescExist:
+		return ruleschemapropdescexist.NewRule(), nil
+
 	case lintutil.RulenameTagStyleFirstUpperCase:
 		return ruletagstylefirstuppercase.NewRule(), nil
 	}
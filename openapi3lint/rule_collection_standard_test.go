@@ -0,0 +1,116 @@
+package openapi3lint
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	testSpecRulesStandardFile   = "testdata/spec_rules_standard.yaml"
+	testSpecRulesResponsesFile  = "testdata/spec_rules_responses.yaml"
+	testSpecRulesExamplesFile   = "testdata/spec_rules_examples.yaml"
+	testSpecRulesDuplicatesFile = "testdata/spec_rules_duplicates.yaml"
+)
+
+var ruleCollectionStandardTests = []struct {
+	filename  string
+	ruleName  string
+	locations []string
+}{
+	{testSpecRulesExamplesFile, lintutil.RulenameExampleSchemaValid, []string{
+		"spec.yaml#/components/schemas/User/example/id",
+		"spec.yaml#/paths/~1users/get/parameters/0/example",
+		"spec.yaml#/paths/~1users/get/responses/200/content/application~1json/example/tags/1",
+		"spec.yaml#/paths/~1users/get/responses/200/headers/X-Rate-Limit/example",
+		"spec.yaml#/paths/~1users/post/requestBody/content/application~1json/examples/invalid/value/name"}},
+	{testSpecRulesDuplicatesFile, lintutil.RulenameSchemaDuplicate, []string{
+		"spec.yaml#/components/schemas/Location",
+		"spec.yaml#/components/schemas/Person",
+		"spec.yaml#/components/schemas/TreeNode"}},
+	{testSpecRulesStandardFile, lintutil.RuleOpDescExist, []string{
+		"spec.yaml#/paths/~1users~1{userId}/delete/description"}},
+	{testSpecRulesStandardFile, lintutil.RuleOpIdExist, []string{
+		"spec.yaml#/paths/~1users~1{userId}/delete/operationId"}},
+	{testSpecRulesStandardFile, lintutil.RuleOpTagsCountOneOnly, []string{
+		"spec.yaml#/paths/~1accounts~1{accountId}~1users~1{user_id}/get/tags",
+		"spec.yaml#/paths/~1users~1{userId}/delete/tags"}},
+	{testSpecRulesStandardFile, lintutil.RulePathParamNameExist, []string{
+		"spec.yaml#/paths/~1accounts~1{accountId}~1users~1{user_id}/get/parameters"}},
+	{testSpecRulesStandardFile, lintutil.RuleSchemaPropDescExist, []string{
+		"spec.yaml#/components/schemas/User/properties/name/description"}},
+	{testSpecRulesStandardFile, lintutil.RulenameSchemaNameStyleCamelCase, []string{
+		"spec.yaml#/components/schemas/User",
+		"spec.yaml#/components/schemas/account_info"}},
+	{testSpecRulesStandardFile, lintutil.RulenameSchemaNameStyleKebabCase, []string{
+		"spec.yaml#/components/schemas/User",
+		"spec.yaml#/components/schemas/account_info"}},
+	{testSpecRulesStandardFile, lintutil.RulenameSchemaNameStylePascalCase, []string{
+		"spec.yaml#/components/schemas/account_info"}},
+	{testSpecRulesStandardFile, lintutil.RulenameSchemaNameStyleSnakeCase, []string{
+		"spec.yaml#/components/schemas/User"}},
+	{testSpecRulesResponsesFile, lintutil.RulenameOpResponseErrorExist, []string{
+		"spec.yaml#/paths/~1users~1{userId}/delete/responses"}},
+	{testSpecRulesResponsesFile, lintutil.RulenameOpResponseErrorSchema, []string{
+		"spec.yaml#/paths/~1users~1{userId}/get/responses/404/content/application~1json/schema",
+		"spec.yaml#/paths/~1users~1{userId}/put/responses/500/content/application~1json/schema"}},
+	{testSpecRulesResponsesFile, lintutil.RulenameOpResponseNoContent, []string{
+		"spec.yaml#/paths/~1users~1{userId}/delete/responses/204/content"}},
+	{testSpecRulesResponsesFile, lintutil.RulenameOpResponseSuccessExist, []string{
+		"spec.yaml#/paths/~1users~1{userId}/get/responses"}},
+}
+
+// TestRuleCollectionStandard ensures standard rules report the expected
+// locations for the fixture spec.
+func TestRuleCollectionStandard(t *testing.T) {
+	stdRules := NewRuleCollectionStandard()
+	for _, tt := range ruleCollectionStandardTests {
+		spec, err := openapi3.ReadFile(tt.filename, false)
+		if err != nil {
+			t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+		}
+		if !stdRules.RuleExists(tt.ruleName) {
+			t.Errorf("RuleCollectionStandard.RuleExists() Mismatch: rule [%s] not found", tt.ruleName)
+			continue
+		}
+		rule, err := stdRules.Rule(tt.ruleName)
+		if err != nil {
+			t.Fatalf("RuleCollectionStandard.Rule() Error [%s]", err.Error())
+		}
+		pol := NewPolicy()
+		if err := pol.AddRule(rule, severity.SeverityError, true); err != nil {
+			t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+		}
+		vsets, err := pol.ValidateSpec(spec, "spec.yaml", severity.SeverityError)
+		if err != nil {
+			t.Fatalf("Policy.ValidateSpec() rule [%s] Error [%s]", tt.ruleName, err.Error())
+		}
+		vset := vsets.ByRule[tt.ruleName]
+		got := vset.Locations().Locations
+		sort.Strings(got)
+		if !slices.Equal(got, tt.locations) {
+			t.Errorf("Policy.ValidateSpec() rule [%s] Mismatch: want [%v], got [%v]",
+				tt.ruleName, tt.locations, got)
+		}
+	}
+}
+
+// TestRuleCollectionStandardRules ensures all standard rule names can be instantiated
+// and have a description.
+func TestRuleCollectionStandardRules(t *testing.T) {
+	stdRules := NewRuleCollectionStandard()
+	for _, ruleName := range stdRules.RuleNames() {
+		rule, err := stdRules.Rule(ruleName)
+		if err != nil {
+			t.Errorf("RuleCollectionStandard.Rule(\"%s\") Error [%s]", ruleName, err.Error())
+		} else if rule.Name() != ruleName {
+			t.Errorf("RuleCollectionStandard.Rule(\"%s\") Name Mismatch: got [%s]", ruleName, rule.Name())
+		} else if len(RuleDescription(rule)) == 0 {
+			t.Errorf("RuleCollectionStandard.Rule(\"%s\") Description Mismatch: got empty description", ruleName)
+		}
+	}
+}
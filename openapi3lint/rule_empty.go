@@ -8,8 +8,9 @@ import (
 
 type EmptyRule struct{}
 
-func (rule EmptyRule) Name() string  { return "" }
-func (rule EmptyRule) Scope() string { return "" }
+func (rule EmptyRule) Name() string        { return "" }
+func (rule EmptyRule) Description() string { return "" }
+func (rule EmptyRule) Scope() string       { return "" }
 func (rule EmptyRule) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
 	return []lintutil.PolicyViolation{}
 }
@@ -0,0 +1,287 @@
+package ruledeclarative
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	TargetOperation      = "operation"
+	TargetParameter      = "parameter"
+	TargetSchemaProperty = "schema-property"
+	TargetTag            = "tag"
+
+	FieldDescription = "description"
+	FieldFormat      = "format"
+	FieldIn          = "in"
+	FieldName        = "name"
+	FieldOperationID = "operationId"
+	FieldSummary     = "summary"
+	FieldTitle       = "title"
+	FieldType        = "type"
+)
+
+// targetFields are the supported fields by target in addition to `x-` properties.
+var targetFields = map[string][]string{
+	TargetOperation:      {FieldDescription, FieldOperationID, FieldSummary},
+	TargetParameter:      {FieldDescription, FieldIn, FieldName},
+	TargetSchemaProperty: {FieldDescription, FieldFormat, FieldName, FieldTitle, FieldType},
+	TargetTag:            {FieldDescription, FieldName},
+}
+
+// RuleDefinition is a rule defined in a policy config file. A rule selects a
+// `Target`, reads a `Field` and checks it against `Assert`.
+type RuleDefinition struct {
+	Description string    `json:"description,omitempty"`
+	Severity    string    `json:"severity,omitempty"`
+	Target      string    `json:"target"`
+	Field       string    `json:"field"`
+	Assert      Assertion `json:"assert"`
+}
+
+// Assertion is a set of checks that must all be true. Value checks are only
+// applied when the field is present.
+type Assertion struct {
+	Exists    *bool    `json:"exists,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Casing    string   `json:"casing,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+}
+
+type RuleDeclarative struct {
+	name      string
+	def       RuleDefinition
+	rxPattern *regexp.Regexp
+	casing    string
+}
+
+func NewRule(ruleName string, def RuleDefinition) (RuleDeclarative, error) {
+	rule := RuleDeclarative{
+		name: strings.ToLower(strings.TrimSpace(ruleName)),
+		def:  def}
+	if len(rule.name) == 0 {
+		return rule, errors.New("rule name not provided")
+	}
+	rule.def.Target = strings.ToLower(strings.TrimSpace(def.Target))
+	rule.def.Field = strings.TrimSpace(def.Field)
+	fields, ok := targetFields[rule.def.Target]
+	if !ok {
+		return rule, fmt.Errorf("rule [%s] has invalid target [%s]", rule.name, def.Target)
+	}
+	if !strings.HasPrefix(rule.def.Field, "x-") {
+		fieldOK := false
+		for _, field := range fields {
+			if field == rule.def.Field {
+				fieldOK = true
+			}
+		}
+		if !fieldOK {
+			return rule, fmt.Errorf("rule [%s] has invalid field [%s] for target [%s] valid [%s]",
+				rule.name, def.Field, rule.def.Target, strings.Join(fields, ","))
+		}
+	}
+	assert := def.Assert
+	if assert.Exists == nil && len(assert.Pattern) == 0 && len(assert.Casing) == 0 &&
+		len(assert.Enum) == 0 && assert.MinLength == nil && assert.MaxLength == nil {
+		return rule, fmt.Errorf("rule [%s] has no assertion", rule.name)
+	}
+	if len(assert.Pattern) > 0 {
+		rx, err := regexp.Compile(assert.Pattern)
+		if err != nil {
+			return rule, fmt.Errorf("rule [%s] has invalid pattern [%s]: %s", rule.name, assert.Pattern, err.Error())
+		}
+		rule.rxPattern = rx
+	}
+	if len(assert.Casing) > 0 {
+		casing, err := stringcase.Parse(assert.Casing)
+		if err != nil {
+			return rule, fmt.Errorf("rule [%s] has invalid casing [%s]", rule.name, assert.Casing)
+		}
+		rule.casing = casing
+	}
+	return rule, nil
+}
+
+func (rule RuleDeclarative) Name() string {
+	return rule.name
+}
+
+// Description returns the rule definition description.
+func (rule RuleDeclarative) Description() string {
+	return rule.def.Description
+}
+
+// Definition returns the rule definition.
+func (rule RuleDeclarative) Definition() RuleDefinition {
+	return rule.def
+}
+
+func (rule RuleDeclarative) Scope() string {
+	switch rule.def.Target {
+	case TargetParameter:
+		return lintutil.ScopeParameter
+	case TargetSchemaProperty:
+		return lintutil.ScopeSchemaProperty
+	case TargetTag:
+		return lintutil.ScopeTag
+	default:
+		return lintutil.ScopeOperation
+	}
+}
+
+func (rule RuleDeclarative) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+func (rule RuleDeclarative) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	if op == nil || rule.def.Target != TargetOperation {
+		return []lintutil.PolicyViolation{}
+	}
+	var val string
+	var ok bool
+	switch rule.def.Field {
+	case FieldDescription:
+		val, ok = op.Description, len(op.Description) > 0
+	case FieldOperationID:
+		val, ok = op.OperationID, len(op.OperationID) > 0
+	case FieldSummary:
+		val, ok = op.Summary, len(op.Summary) > 0
+	default:
+		val, ok = extensionValue(op.ExtensionProps, rule.def.Field)
+	}
+	return rule.check(opPointer+"/"+jsonpointer.PropertyNameEscape(rule.def.Field), val, ok)
+}
+
+func (rule RuleDeclarative) ProcessParameter(spec *openapi3.Spec, paramRef *oas3.ParameterRef, paramPointer string) []lintutil.PolicyViolation {
+	if paramRef == nil || paramRef.Value == nil || rule.def.Target != TargetParameter {
+		return []lintutil.PolicyViolation{}
+	}
+	param := paramRef.Value
+	var val string
+	var ok bool
+	switch rule.def.Field {
+	case FieldDescription:
+		val, ok = param.Description, len(param.Description) > 0
+	case FieldIn:
+		val, ok = param.In, len(param.In) > 0
+	case FieldName:
+		val, ok = param.Name, len(param.Name) > 0
+	default:
+		val, ok = extensionValue(param.ExtensionProps, rule.def.Field)
+	}
+	return rule.check(paramPointer+"/"+jsonpointer.PropertyNameEscape(rule.def.Field), val, ok)
+}
+
+func (rule RuleDeclarative) ProcessSchemaProperty(spec *openapi3.Spec, propRef *oas3.SchemaRef, propPointer, schemaName, propName string) []lintutil.PolicyViolation {
+	if propRef == nil || rule.def.Target != TargetSchemaProperty {
+		return []lintutil.PolicyViolation{}
+	}
+	if rule.def.Field == FieldName {
+		return rule.check(propPointer, propName, true)
+	}
+	if len(propRef.Ref) > 0 || propRef.Value == nil {
+		return []lintutil.PolicyViolation{}
+	}
+	prop := propRef.Value
+	var val string
+	var ok bool
+	switch rule.def.Field {
+	case FieldDescription:
+		val, ok = prop.Description, len(prop.Description) > 0
+	case FieldFormat:
+		val, ok = prop.Format, len(prop.Format) > 0
+	case FieldTitle:
+		val, ok = prop.Title, len(prop.Title) > 0
+	case FieldType:
+		val, ok = prop.Type, len(prop.Type) > 0
+	default:
+		val, ok = extensionValue(prop.ExtensionProps, rule.def.Field)
+	}
+	return rule.check(propPointer+"/"+jsonpointer.PropertyNameEscape(rule.def.Field), val, ok)
+}
+
+func (rule RuleDeclarative) ProcessTag(spec *openapi3.Spec, tag *oas3.Tag, tagPointer string) []lintutil.PolicyViolation {
+	if tag == nil || rule.def.Target != TargetTag {
+		return []lintutil.PolicyViolation{}
+	}
+	var val string
+	var ok bool
+	switch rule.def.Field {
+	case FieldDescription:
+		val, ok = tag.Description, len(tag.Description) > 0
+	case FieldName:
+		val, ok = tag.Name, len(tag.Name) > 0
+	default:
+		val, ok = extensionValue(tag.ExtensionProps, rule.def.Field)
+	}
+	return rule.check(tagPointer+"/"+jsonpointer.PropertyNameEscape(rule.def.Field), val, ok)
+}
+
+func extensionValue(xprops oas3.ExtensionProps, key string) (string, bool) {
+	if _, ok := xprops.Extensions[key]; !ok {
+		return "", false
+	}
+	return openapi3.GetExtensionPropStringOrEmpty(xprops, key), true
+}
+
+// check returns a violation for each failed assertion.
+func (rule RuleDeclarative) check(location, val string, exists bool) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	add := func(violation string) {
+		vios = append(vios, lintutil.PolicyViolation{
+			RuleName:  rule.Name(),
+			Violation: violation,
+			Location:  location,
+			Value:     val})
+	}
+	assert := rule.def.Assert
+	if assert.Exists != nil {
+		if *assert.Exists && !exists {
+			add(fmt.Sprintf("field [%s] does not exist", rule.def.Field))
+		} else if !*assert.Exists && exists {
+			add(fmt.Sprintf("field [%s] exists", rule.def.Field))
+		}
+	}
+	if !exists {
+		return vios
+	}
+	if rule.rxPattern != nil && !rule.rxPattern.MatchString(val) {
+		add(fmt.Sprintf("field [%s] does not match pattern [%s]", rule.def.Field, assert.Pattern))
+	}
+	if len(rule.casing) > 0 {
+		if isCase, err := stringcase.IsCase(rule.casing, val); err != nil || !isCase {
+			add(fmt.Sprintf("field [%s] is not [%s]", rule.def.Field, rule.casing))
+		}
+	}
+	if len(assert.Enum) > 0 {
+		inEnum := false
+		for _, enumVal := range assert.Enum {
+			if val == enumVal {
+				inEnum = true
+				break
+			}
+		}
+		if !inEnum {
+			add(fmt.Sprintf("field [%s] is not one of [%s]", rule.def.Field, strings.Join(assert.Enum, ",")))
+		}
+	}
+	length := len([]rune(val))
+	if assert.MinLength != nil && length < *assert.MinLength {
+		add(fmt.Sprintf("field [%s] is shorter than minLength [%s]", rule.def.Field, strconv.Itoa(*assert.MinLength)))
+	}
+	if assert.MaxLength != nil && length > *assert.MaxLength {
+		add(fmt.Sprintf("field [%s] is longer than maxLength [%s]", rule.def.Field, strconv.Itoa(*assert.MaxLength)))
+	}
+	return vios
+}
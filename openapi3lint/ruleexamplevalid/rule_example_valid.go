@@ -0,0 +1,344 @@
+package ruleexamplevalid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/type/maputil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	PropertyExample  = "example"
+	PropertyExamples = "examples"
+	PropertyValue    = "value"
+
+	// DataSchemaPointer is the `PolicyViolation.Data` key for the pointer of the
+	// failing schema keyword.
+	DataSchemaPointer = "schemaPointer"
+)
+
+// RuleExampleValid reports `example` and `examples` values that do not validate
+// against their schema. Media types, parameters and headers in operations and
+// components are checked, as are examples on component schemas and their inline
+// subschemas. The violation location is the pointer to the failing value within
+// the example and `Value` is the pointer to the failing schema keyword. Examples
+// whose schemas have references that cannot be resolved, such as remote
+// references, are skipped.
+type RuleExampleValid struct {
+	name string
+}
+
+func NewRule() RuleExampleValid {
+	return RuleExampleValid{
+		name: lintutil.RulenameExampleSchemaValid}
+}
+
+func (rule RuleExampleValid) Name() string {
+	return rule.name
+}
+
+func (rule RuleExampleValid) Description() string {
+	return "`example` and `examples` values on media types, parameters, headers and component schemas must validate against their schema."
+}
+
+func (rule RuleExampleValid) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RuleExampleValid) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+func (rule RuleExampleValid) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	v := validator{rule: rule, pointerBase: pointerBase}
+	if spec == nil {
+		return v.vios
+	}
+	spec = resolveSpec(spec)
+	for _, path := range maputil.StringKeys(spec.Paths, nil) {
+		pathItem := spec.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		pathPointer := fmt.Sprintf("#/paths/%s", jsonpointer.PropertyNameEscape(path))
+		v.parameters(pathItem.Parameters, pathPointer+"/parameters")
+		ops := pathItem.Operations()
+		for _, method := range maputil.StringKeys(ops, nil) {
+			op := ops[method]
+			opPointer := pathPointer + "/" + strings.ToLower(method)
+			v.parameters(op.Parameters, opPointer+"/parameters")
+			if op.RequestBody != nil && len(op.RequestBody.Ref) == 0 && op.RequestBody.Value != nil {
+				v.content(op.RequestBody.Value.Content, opPointer+"/requestBody/content")
+			}
+			for _, status := range maputil.StringKeys(op.Responses, nil) {
+				v.response(op.Responses[status], opPointer+"/responses/"+jsonpointer.PropertyNameEscape(status))
+			}
+		}
+	}
+	for _, name := range maputil.StringKeys(spec.Components.Parameters, nil) {
+		paramRef := spec.Components.Parameters[name]
+		if paramRef != nil && len(paramRef.Ref) == 0 && paramRef.Value != nil {
+			v.parameter(paramRef.Value, "#/components/parameters/"+jsonpointer.PropertyNameEscape(name))
+		}
+	}
+	for _, name := range maputil.StringKeys(spec.Components.Headers, nil) {
+		headerRef := spec.Components.Headers[name]
+		if headerRef != nil && len(headerRef.Ref) == 0 && headerRef.Value != nil {
+			v.parameter(&headerRef.Value.Parameter, "#/components/headers/"+jsonpointer.PropertyNameEscape(name))
+		}
+	}
+	for _, name := range maputil.StringKeys(spec.Components.RequestBodies, nil) {
+		reqBodyRef := spec.Components.RequestBodies[name]
+		if reqBodyRef != nil && len(reqBodyRef.Ref) == 0 && reqBodyRef.Value != nil {
+			v.content(reqBodyRef.Value.Content, "#/components/requestBodies/"+jsonpointer.PropertyNameEscape(name)+"/content")
+		}
+	}
+	for _, name := range maputil.StringKeys(spec.Components.Responses, nil) {
+		v.response(spec.Components.Responses[name], "#/components/responses/"+jsonpointer.PropertyNameEscape(name))
+	}
+	for _, name := range maputil.StringKeys(spec.Components.Schemas, nil) {
+		schRef := spec.Components.Schemas[name]
+		if schRef != nil && len(schRef.Ref) == 0 {
+			v.schemaExamples(schRef.Value, "#/components/schemas/"+jsonpointer.PropertyNameEscape(name), map[*oas3.Schema]int{})
+		}
+	}
+	return v.vios
+}
+
+// validator collects violations while traversing a spec.
+type validator struct {
+	rule        RuleExampleValid
+	pointerBase string
+	vios        []lintutil.PolicyViolation
+}
+
+func (v *validator) response(respRef *oas3.ResponseRef, respPointer string) {
+	if respRef == nil || len(respRef.Ref) > 0 || respRef.Value == nil {
+		return
+	}
+	for _, name := range maputil.StringKeys(respRef.Value.Headers, nil) {
+		headerRef := respRef.Value.Headers[name]
+		if headerRef != nil && len(headerRef.Ref) == 0 && headerRef.Value != nil {
+			v.parameter(&headerRef.Value.Parameter, respPointer+"/headers/"+jsonpointer.PropertyNameEscape(name))
+		}
+	}
+	v.content(respRef.Value.Content, respPointer+"/content")
+}
+
+func (v *validator) parameters(params oas3.Parameters, paramsPointer string) {
+	for i, paramRef := range params {
+		if paramRef != nil && len(paramRef.Ref) == 0 && paramRef.Value != nil {
+			v.parameter(paramRef.Value, paramsPointer+"/"+strconv.Itoa(i))
+		}
+	}
+}
+
+// parameter checks parameter and header examples against the parameter schema
+// and the examples of any parameter media types.
+func (v *validator) parameter(param *oas3.Parameter, paramPointer string) {
+	v.examples(param.Schema, paramPointer+"/schema", param.Example, param.Examples, paramPointer)
+	v.content(param.Content, paramPointer+"/content")
+}
+
+func (v *validator) content(content oas3.Content, contentPointer string) {
+	for _, mediaType := range maputil.StringKeys(content, nil) {
+		mt := content[mediaType]
+		if mt == nil {
+			continue
+		}
+		mtPointer := contentPointer + "/" + jsonpointer.PropertyNameEscape(mediaType)
+		v.examples(mt.Schema, mtPointer+"/schema", mt.Example, mt.Examples, mtPointer)
+	}
+}
+
+// examples checks the `example` and `examples` properties of the object at
+// `objPointer` against `schRef`.
+func (v *validator) examples(schRef *oas3.SchemaRef, schPointer string, example interface{}, examples oas3.Examples, objPointer string) {
+	if schRef == nil || schRef.Value == nil {
+		return
+	}
+	if len(schRef.Ref) > 0 {
+		schPointer = refPointer(schRef.Ref, schPointer)
+	}
+	if example != nil {
+		v.validate(schRef.Value, schPointer, example, objPointer+"/"+PropertyExample)
+	}
+	for _, name := range maputil.StringKeys(examples, nil) {
+		exRef := examples[name]
+		if exRef == nil || exRef.Value == nil || exRef.Value.Value == nil {
+			continue
+		}
+		exPointer := objPointer + "/" + PropertyExamples + "/" + jsonpointer.PropertyNameEscape(name)
+		if len(exRef.Ref) == 0 {
+			exPointer += "/" + PropertyValue
+		}
+		v.validate(schRef.Value, schPointer, exRef.Value.Value, exPointer)
+	}
+}
+
+// schemaExamples checks the example of a schema and its inline subschemas.
+func (v *validator) schemaExamples(sch *oas3.Schema, schPointer string, visited map[*oas3.Schema]int) {
+	if sch == nil {
+		return
+	}
+	if _, ok := visited[sch]; ok {
+		return
+	}
+	visited[sch] = 1
+	if sch.Example != nil {
+		v.validate(sch, schPointer, sch.Example, schPointer+"/"+PropertyExample)
+	}
+	inline := func(schRef *oas3.SchemaRef, pointer string) {
+		if schRef != nil && len(schRef.Ref) == 0 {
+			v.schemaExamples(schRef.Value, pointer, visited)
+		}
+	}
+	for _, propName := range maputil.StringKeys(sch.Properties, nil) {
+		inline(sch.Properties[propName], schPointer+"/properties/"+jsonpointer.PropertyNameEscape(propName))
+	}
+	inline(sch.Items, schPointer+"/items")
+	inline(sch.AdditionalProperties, schPointer+"/additionalProperties")
+	for i, schRef := range sch.AllOf {
+		inline(schRef, schPointer+"/allOf/"+strconv.Itoa(i))
+	}
+	for i, schRef := range sch.AnyOf {
+		inline(schRef, schPointer+"/anyOf/"+strconv.Itoa(i))
+	}
+	for i, schRef := range sch.OneOf {
+		inline(schRef, schPointer+"/oneOf/"+strconv.Itoa(i))
+	}
+}
+
+// validate checks `value` against `sch` and adds a violation for the first error.
+func (v *validator) validate(sch *oas3.Schema, schPointer string, value interface{}, exPointer string) {
+	if !schemaResolved(sch, map[*oas3.Schema]int{}) {
+		return
+	}
+	value, err := normalize(value)
+	if err == nil {
+		err = sch.VisitJSON(value)
+	}
+	if err == nil {
+		return
+	}
+	valuePath := []string{}
+	schemaField := ""
+	reason := err.Error()
+	var schErr *oas3.SchemaError
+	if errors.As(err, &schErr) {
+		valuePath = schErr.JSONPointer()
+		schemaField = schErr.SchemaField
+		reason = schErr.Reason
+	}
+	for _, token := range valuePath {
+		exPointer += "/" + jsonpointer.PropertyNameEscape(token)
+	}
+	schPointer = SchemaKeywordPointer(sch, schPointer, valuePath)
+	if len(schemaField) > 0 {
+		schPointer += "/" + jsonpointer.PropertyNameEscape(schemaField)
+	}
+	v.vios = append(v.vios, lintutil.PolicyViolation{
+		RuleName:  v.rule.Name(),
+		Location:  v.pointerBase + exPointer,
+		Value:     v.pointerBase + schPointer,
+		Violation: "example does not match schema: " + reason,
+		Data:      map[string]string{DataSchemaPointer: v.pointerBase + schPointer}})
+}
+
+// SchemaKeywordPointer returns the pointer of the subschema that validates the
+// value at `valuePath`, e.g. `properties/id` for `["id"]` or `items` for `["0"]`.
+// Local `$ref` references are followed so the pointer is the referenced schema.
+func SchemaKeywordPointer(sch *oas3.Schema, schPointer string, valuePath []string) string {
+	for _, token := range valuePath {
+		if sch == nil {
+			break
+		}
+		var next *oas3.SchemaRef
+		if propRef, ok := sch.Properties[token]; ok {
+			next = propRef
+			schPointer += "/properties/" + jsonpointer.PropertyNameEscape(token)
+		} else if _, err := strconv.Atoi(token); err == nil && sch.Items != nil {
+			next = sch.Items
+			schPointer += "/items"
+		} else if sch.AdditionalProperties != nil {
+			next = sch.AdditionalProperties
+			schPointer += "/additionalProperties"
+		} else {
+			break
+		}
+		if next == nil {
+			break
+		}
+		if len(next.Ref) > 0 {
+			schPointer = refPointer(next.Ref, schPointer)
+		}
+		sch = next.Value
+	}
+	return schPointer
+}
+
+// resolveSpec returns a copy of the spec with local references resolved, as
+// specs read with `openapi3.ReadFile()` without validation are not resolved.
+// If the spec cannot be resolved, it is returned unchanged.
+func resolveSpec(spec *openapi3.Spec) *openapi3.Spec {
+	bytes, err := spec.MarshalJSON()
+	if err != nil {
+		return spec
+	}
+	resolved, err := oas3.NewLoader().LoadFromData(bytes)
+	if err != nil {
+		return spec
+	}
+	return resolved
+}
+
+// schemaResolved returns false if a schema has a reference without a value.
+func schemaResolved(sch *oas3.Schema, visited map[*oas3.Schema]int) bool {
+	if sch == nil {
+		return false
+	}
+	if _, ok := visited[sch]; ok {
+		return true
+	}
+	visited[sch] = 1
+	schRefs := oas3.SchemaRefs{sch.Items, sch.AdditionalProperties, sch.Not}
+	for _, propRef := range sch.Properties {
+		schRefs = append(schRefs, propRef)
+	}
+	schRefs = append(schRefs, sch.AllOf...)
+	schRefs = append(schRefs, sch.AnyOf...)
+	schRefs = append(schRefs, sch.OneOf...)
+	for _, schRef := range schRefs {
+		if schRef != nil && !schemaResolved(schRef.Value, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+// refPointer returns the fragment of a local `$ref` or `fallback` for remote references.
+func refPointer(ref, fallback string) string {
+	if strings.HasPrefix(ref, "#/") {
+		return ref
+	}
+	return fallback
+}
+
+// normalize converts a value to the types produced by `encoding/json`, which
+// `Schema.VisitJSON` expects, e.g. `float64` for all numbers.
+func normalize(value interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(bytes, &out)
+	return out, err
+}
@@ -0,0 +1,49 @@
+package ruleexamplevalid
+
+import (
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+)
+
+var ruleExampleValidTests = []struct {
+	location      string
+	schemaPointer string
+}{
+	{"spec.yaml#/components/schemas/User/example/id",
+		"spec.yaml#/components/schemas/User/properties/id/type"},
+	{"spec.yaml#/paths/~1users/get/parameters/0/example",
+		"spec.yaml#/paths/~1users/get/parameters/0/schema/maximum"},
+	{"spec.yaml#/paths/~1users/get/responses/200/content/application~1json/example/tags/1",
+		"spec.yaml#/components/schemas/User/properties/tags/items/type"},
+	{"spec.yaml#/paths/~1users/get/responses/200/headers/X-Rate-Limit/example",
+		"spec.yaml#/paths/~1users/get/responses/200/headers/X-Rate-Limit/schema/type"},
+	{"spec.yaml#/paths/~1users/post/requestBody/content/application~1json/examples/invalid/value/name",
+		"spec.yaml#/components/schemas/User/properties/name/type"},
+}
+
+// TestRuleExampleValid ensures invalid examples are reported with the pointers
+// of the failing example value and schema keyword.
+func TestRuleExampleValid(t *testing.T) {
+	spec, err := openapi3.ReadFile("../testdata/spec_rules_examples.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	vios := NewRule().ProcessSpec(spec, "spec.yaml")
+	got := map[string]string{}
+	for _, vio := range vios {
+		got[vio.Location] = vio.Value
+	}
+	if len(vios) != len(ruleExampleValidTests) {
+		t.Errorf("RuleExampleValid.ProcessSpec() Mismatch: want [%d] violations, got [%d] [%v]",
+			len(ruleExampleValidTests), len(vios), got)
+	}
+	for _, tt := range ruleExampleValidTests {
+		if schemaPointer, ok := got[tt.location]; !ok {
+			t.Errorf("RuleExampleValid.ProcessSpec() Mismatch: missing location [%s]", tt.location)
+		} else if schemaPointer != tt.schemaPointer {
+			t.Errorf("RuleExampleValid.ProcessSpec() location [%s] Mismatch: want [%s], got [%s]",
+				tt.location, tt.schemaPointer, schemaPointer)
+		}
+	}
+}
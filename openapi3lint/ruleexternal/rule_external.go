@@ -0,0 +1,252 @@
+package ruleexternal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// DefaultTimeout is the time allowed for each invocation of a rule
+// executable when `RuleDefinition.Timeout` is not set.
+const DefaultTimeout = 30 * time.Second
+
+// RuleDefinition is an external-process rule in a policy config. `Command` is
+// run with `Args` once per spec for `specification` scope, or once per operation
+// for `operation` scope. `Timeout` is a Go duration string such as `10s`.
+type RuleDefinition struct {
+	Description string   `json:"description,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	Command     string   `json:"command"`
+	Args        []string `json:"args,omitempty"`
+	Timeout     string   `json:"timeout,omitempty"`
+}
+
+// Input is written to the executable as JSON on stdin. `Operation`, `Path`
+// and `Method` are only set for `operation` scope.
+type Input struct {
+	RuleName  string          `json:"ruleName"`
+	Scope     string          `json:"scope"`
+	Pointer   string          `json:"pointer"`
+	Path      string          `json:"path,omitempty"`
+	Method    string          `json:"method,omitempty"`
+	Operation *oas3.Operation `json:"operation,omitempty"`
+	Spec      json.RawMessage `json:"spec"`
+}
+
+// RuleExternal runs an executable that reads an `Input` as JSON on stdin and
+// writes a JSON array of `lintutil.PolicyViolation` on stdout. Violations
+// without a `RuleName` or `Location` are set to the rule name and the spec or
+// operation pointer. It implements `openapi3lint.RuleContext` so the executable
+// is stopped when linting is canceled and failures are returned as errors. When
+// called without a context, if the executable fails, times out or writes invalid
+// output, one `error` severity violation is returned with the error in
+// `Data["error"]`.
+type RuleExternal struct {
+	name    string
+	def     RuleDefinition
+	scope   string
+	timeout time.Duration
+}
+
+func NewRule(ruleName string, def RuleDefinition) (*RuleExternal, error) {
+	rule := &RuleExternal{
+		name:    strings.ToLower(strings.TrimSpace(ruleName)),
+		def:     def,
+		timeout: DefaultTimeout}
+	if len(rule.name) == 0 {
+		return rule, errors.New("rule name not provided")
+	}
+	rule.def.Command = strings.TrimSpace(def.Command)
+	if len(rule.def.Command) == 0 {
+		return rule, fmt.Errorf("rule [%s] has no command", rule.name)
+	}
+	if len(strings.TrimSpace(def.Scope)) == 0 {
+		rule.scope = lintutil.ScopeSpecification
+	} else {
+		scope, err := lintutil.ParseScope(def.Scope)
+		if err != nil {
+			return rule, fmt.Errorf("rule [%s] has invalid scope [%s]", rule.name, def.Scope)
+		}
+		if scope != lintutil.ScopeSpecification && scope != lintutil.ScopeOperation {
+			return rule, fmt.Errorf("rule [%s] has unsupported scope [%s] valid [%s,%s]",
+				rule.name, def.Scope, lintutil.ScopeSpecification, lintutil.ScopeOperation)
+		}
+		rule.scope = scope
+	}
+	if len(strings.TrimSpace(def.Timeout)) > 0 {
+		timeout, err := time.ParseDuration(strings.TrimSpace(def.Timeout))
+		if err != nil || timeout <= 0 {
+			return rule, fmt.Errorf("rule [%s] has invalid timeout [%s]", rule.name, def.Timeout)
+		}
+		rule.timeout = timeout
+	}
+	return rule, nil
+}
+
+func (rule *RuleExternal) Name() string {
+	return rule.name
+}
+
+// Description returns the rule definition description.
+func (rule *RuleExternal) Description() string {
+	if len(strings.TrimSpace(rule.def.Description)) > 0 {
+		return rule.def.Description
+	}
+	return fmt.Sprintf("Runs the external command `%s`.", rule.def.Command)
+}
+
+// Definition returns the rule definition.
+func (rule *RuleExternal) Definition() RuleDefinition {
+	return rule.def
+}
+
+func (rule *RuleExternal) Scope() string {
+	return rule.scope
+}
+
+func (rule *RuleExternal) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return rule.errorViolations(rule.ProcessSpecContext(context.Background(), spec, pointerBase))
+}
+
+func (rule *RuleExternal) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return rule.errorViolations(rule.ProcessOperationContext(context.Background(), spec, op, opPointer, path, method))
+}
+
+// ProcessSpecContext runs the executable for `specification` scope. The
+// executable is killed if `ctx` is canceled.
+func (rule *RuleExternal) ProcessSpecContext(ctx context.Context, spec *openapi3.Spec, pointerBase string) ([]lintutil.PolicyViolation, error) {
+	if rule.scope != lintutil.ScopeSpecification {
+		return []lintutil.PolicyViolation{}, nil
+	}
+	return rule.run(ctx, spec, Input{
+		RuleName: rule.name,
+		Scope:    rule.scope,
+		Pointer:  pointerBase})
+}
+
+// ProcessOperationContext runs the executable for `operation` scope. The
+// executable is killed if `ctx` is canceled.
+func (rule *RuleExternal) ProcessOperationContext(ctx context.Context, spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) ([]lintutil.PolicyViolation, error) {
+	if rule.scope != lintutil.ScopeOperation || op == nil {
+		return []lintutil.PolicyViolation{}, nil
+	}
+	return rule.run(ctx, spec, Input{
+		RuleName:  rule.name,
+		Scope:     rule.scope,
+		Pointer:   opPointer,
+		Path:      path,
+		Method:    method,
+		Operation: op})
+}
+
+// errorViolations returns `vios` or, on error, one `error` severity violation
+// at the input pointer with the error in `Data["error"]`.
+func (rule *RuleExternal) errorViolations(vios []lintutil.PolicyViolation, err error) []lintutil.PolicyViolation {
+	if err == nil {
+		return vios
+	}
+	var runErr *runError
+	pointer := ""
+	if errors.As(err, &runErr) {
+		pointer = runErr.pointer
+	}
+	return []lintutil.PolicyViolation{{
+		RuleName:  rule.name,
+		Severity:  severity.SeverityError,
+		Location:  pointer,
+		Violation: err.Error(),
+		Data:      map[string]string{"error": err.Error()}}}
+}
+
+// runError is an executable failure for an input pointer.
+type runError struct {
+	pointer string
+	err     error
+}
+
+func (e *runError) Error() string { return e.err.Error() }
+
+func (e *runError) Unwrap() error { return e.err }
+
+func (rule *RuleExternal) run(ctx context.Context, spec *openapi3.Spec, input Input) ([]lintutil.PolicyViolation, error) {
+	vios, err := rule.exec(ctx, spec, input)
+	if err != nil {
+		return nil, &runError{pointer: input.Pointer, err: err}
+	}
+	for i, vio := range vios {
+		if len(vio.RuleName) == 0 {
+			vios[i].RuleName = rule.name
+		}
+		if len(vio.Location) == 0 {
+			vios[i].Location = input.Pointer
+		}
+	}
+	return vios, nil
+}
+
+func (rule *RuleExternal) exec(ctx context.Context, spec *openapi3.Spec, input Input) ([]lintutil.PolicyViolation, error) {
+	specRaw, err := specJSON(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	input.Spec = specRaw
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	runCtx, cancel := context.WithTimeout(ctx, rule.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, rule.def.Command, rule.def.Args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("rule [%s] command [%s] canceled: %w", rule.name, rule.def.Command, ctx.Err())
+		} else if runCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("rule [%s] command [%s] timed out after %s", rule.name, rule.def.Command, rule.timeout)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) == 0 {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("rule [%s] command [%s] failed: %s", rule.name, rule.def.Command, msg)
+	}
+	vios := []lintutil.PolicyViolation{}
+	if out := bytes.TrimSpace(stdout.Bytes()); len(out) > 0 {
+		if err := json.Unmarshal(out, &vios); err != nil {
+			return nil, fmt.Errorf("rule [%s] command [%s] invalid output: %s", rule.name, rule.def.Command, err.Error())
+		}
+	}
+	return vios, nil
+}
+
+// specJSON returns the spec as JSON. The result is cached for the validation
+// with `lintutil.SpecCacheValue()` since operation scope rules are run once per
+// operation.
+func specJSON(ctx context.Context, spec *openapi3.Spec) (json.RawMessage, error) {
+	type result struct {
+		raw json.RawMessage
+		err error
+	}
+	res, _ := lintutil.SpecCacheValue(ctx, "ruleexternal/specJSON", func() interface{} {
+		if spec == nil {
+			return result{raw: json.RawMessage("null")}
+		}
+		raw, err := spec.MarshalJSON()
+		return result{raw: raw, err: err}
+	}).(result)
+	return res.raw, res.err
+}
@@ -0,0 +1,193 @@
+package ruleexternal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const testSpecExternal = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {
+    "/users": {"get": {"operationId": "listUsers", "responses": {"200": {"description": "OK"}}}},
+    "/accounts": {"post": {"operationId": "createAccount", "responses": {"201": {"description": "Created"}}}}
+  }
+}`
+
+var ruleExternalTests = []struct {
+	script    string
+	def       RuleDefinition
+	locations []string
+	errSubstr string
+}{
+	{`cat > "$0.in"; echo '[{"Location":"spec.json#/info/title","Value":"Test","Violation":"title"}]'`,
+		RuleDefinition{}, []string{"spec.json#/info/title"}, ""},
+	{`cat > /dev/null; echo '[{"Value":"op"}]'`,
+		RuleDefinition{Scope: lintutil.ScopeOperation},
+		[]string{"spec.json#/paths/~1accounts/post", "spec.json#/paths/~1users/get"}, ""},
+	{`cat > /dev/null; echo 'boom' >&2; exit 2`,
+		RuleDefinition{}, []string{"spec.json"}, "boom"},
+	{`cat > /dev/null; echo 'not json'`,
+		RuleDefinition{}, []string{"spec.json"}, "invalid output"},
+	{`exec sleep 5`,
+		RuleDefinition{Timeout: "100ms"}, []string{"spec.json"}, "timed out"},
+}
+
+// TestRuleExternal ensures executables receive the spec on stdin and that
+// violations, failures, invalid output and timeouts are reported.
+func TestRuleExternal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported")
+	}
+	spec, err := openapi3.Parse([]byte(testSpecExternal))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	for i, tt := range ruleExternalTests {
+		script := filepath.Join(t.TempDir(), "rule.sh")
+		if err := os.WriteFile(script, []byte("#!/bin/sh\n"+tt.script+"\n"), 0700); err != nil {
+			t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+		}
+		tt.def.Command = script
+		rule, err := NewRule("external-test", tt.def)
+		if err != nil {
+			t.Fatalf("NewRule() Error [%s]", err.Error())
+		}
+		vios := rule.ProcessSpec(spec, "spec.json")
+		openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+			vios = append(vios, rule.ProcessOperation(spec, op,
+				"spec.json#/paths/"+strings.ReplaceAll(path, "/", "~1")+"/"+strings.ToLower(method), path, method)...)
+		})
+		set := lintutil.PolicyViolationsSet{RuleName: rule.Name(), Violations: vios}
+		got := set.Locations().Locations
+		if strings.Join(got, ",") != strings.Join(tt.locations, ",") {
+			t.Errorf("RuleExternal [%d] Mismatch: want [%v], got [%v]", i, tt.locations, got)
+		}
+		for _, vio := range vios {
+			if vio.RuleName != rule.Name() {
+				t.Errorf("RuleExternal [%d] RuleName Mismatch: want [%s], got [%s]", i, rule.Name(), vio.RuleName)
+			}
+			if len(tt.errSubstr) > 0 && !strings.Contains(vio.Data["error"], tt.errSubstr) {
+				t.Errorf("RuleExternal [%d] error Mismatch: want [%s], got [%s]", i, tt.errSubstr, vio.Data["error"])
+			}
+		}
+		if i == 0 {
+			bytes, err := os.ReadFile(script + ".in")
+			if err != nil {
+				t.Fatalf("os.ReadFile() Error [%s]", err.Error())
+			}
+			input := Input{}
+			if err := json.Unmarshal(bytes, &input); err != nil {
+				t.Fatalf("json.Unmarshal() Error [%s]", err.Error())
+			}
+			if input.RuleName != "external-test" || input.Scope != lintutil.ScopeSpecification ||
+				!strings.Contains(string(input.Spec), "listUsers") {
+				t.Errorf("RuleExternal Input Mismatch: got [%s]", string(bytes))
+			}
+		}
+	}
+}
+
+// TestNewRuleErrors ensures invalid definitions are rejected.
+func TestNewRuleErrors(t *testing.T) {
+	for _, def := range []RuleDefinition{
+		{},
+		{Command: "true", Scope: lintutil.ScopeTag},
+		{Command: "true", Timeout: "soon"},
+	} {
+		if _, err := NewRule("external-test", def); err == nil {
+			t.Errorf("NewRule(%v) Mismatch: want error, got [nil]", def)
+		}
+	}
+}
+
+// TestRuleExternalContext ensures failures are returned as errors with a
+// context, reported as `error` severity violations without one, and that
+// canceling the context stops the executable.
+func TestRuleExternalContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported")
+	}
+	spec, err := openapi3.Parse([]byte(testSpecExternal))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	newRule := func(script string) *RuleExternal {
+		file := filepath.Join(t.TempDir(), "rule.sh")
+		if err := os.WriteFile(file, []byte("#!/bin/sh\n"+script+"\n"), 0700); err != nil {
+			t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+		}
+		rule, err := NewRule("external-test", RuleDefinition{Command: file})
+		if err != nil {
+			t.Fatalf("NewRule() Error [%s]", err.Error())
+		}
+		return rule
+	}
+
+	rule := newRule(`cat > /dev/null; echo 'boom' >&2; exit 2`)
+	if _, err := rule.ProcessSpecContext(context.Background(), spec, "spec.json"); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("RuleExternal.ProcessSpecContext() Mismatch: want error [%s], got [%v]", "boom", err)
+	}
+	vios := rule.ProcessSpec(spec, "spec.json")
+	if len(vios) != 1 || vios[0].Severity != severity.SeverityError || vios[0].Location != "spec.json" {
+		t.Errorf("RuleExternal.ProcessSpec() Mismatch: want one [%s] violation, got [%v]", severity.SeverityError, vios)
+	}
+
+	rule = newRule(`exec sleep 5`)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = rule.ProcessSpecContext(ctx, spec, "spec.json")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RuleExternal.ProcessSpecContext() canceled Mismatch: want [%v], got [%v]", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("RuleExternal.ProcessSpecContext() canceled Mismatch: want stop before [3s], got [%s]", elapsed)
+	}
+}
+
+// TestRuleExternalSpecModified ensures a spec modified between validations is
+// sent to the executable as modified.
+func TestRuleExternalSpecModified(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported")
+	}
+	spec, err := openapi3.Parse([]byte(testSpecExternal))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	script := filepath.Join(t.TempDir(), "rule.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat > \"$0.in\"; echo '[]'\n"), 0700); err != nil {
+		t.Fatalf("os.WriteFile() Error [%s]", err.Error())
+	}
+	rule, err := NewRule("external-test", RuleDefinition{Command: script})
+	if err != nil {
+		t.Fatalf("NewRule() Error [%s]", err.Error())
+	}
+	for _, title := range []string{"Test", "Modified"} {
+		spec.Info.Title = title
+		// each validation uses a new cache as in `Policy.ValidateSpecContext()`.
+		if _, err := rule.ProcessSpecContext(lintutil.WithSpecCache(context.Background()), spec, "spec.json"); err != nil {
+			t.Fatalf("RuleExternal.ProcessSpecContext() Error [%s]", err.Error())
+		}
+		bytes, err := os.ReadFile(script + ".in")
+		if err != nil {
+			t.Fatalf("os.ReadFile() Error [%s]", err.Error())
+		}
+		if !strings.Contains(string(bytes), `"title":"`+title+`"`) {
+			t.Errorf("RuleExternal Input Mismatch: want title [%s], got [%s]", title, string(bytes))
+		}
+	}
+}
@@ -20,6 +20,10 @@ func (rule RuleDatatypeIntFormatStandardExist) Name() string {
 	return rule.name
 }
 
+func (rule RuleDatatypeIntFormatStandardExist) Description() string {
+	return "Integer schemas must use the standard `int32` or `int64` format."
+}
+
 func (rule RuleDatatypeIntFormatStandardExist) Scope() string {
 	return lintutil.ScopeSpecification
 }
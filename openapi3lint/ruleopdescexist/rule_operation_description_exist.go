@@ -0,0 +1,52 @@
+package ruleopdescexist
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+type RuleOperationDescriptionExist struct {
+	name string
+}
+
+func NewRule() RuleOperationDescriptionExist {
+	return RuleOperationDescriptionExist{
+		name: lintutil.RuleOpDescExist}
+}
+
+func (rule RuleOperationDescriptionExist) Name() string {
+	return rule.name
+}
+
+func (rule RuleOperationDescriptionExist) Description() string {
+	return "Operations must have a `description`."
+}
+
+func (rule RuleOperationDescriptionExist) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RuleOperationDescriptionExist) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil || op == nil {
+		return vios
+	}
+
+	desc := strings.TrimSpace(op.Description)
+	if len(desc) > 0 {
+		return vios
+	}
+
+	return []lintutil.PolicyViolation{{
+		RuleName: rule.Name(),
+		Location: urlutil.JoinAbsolute(opPointer, openapi3.PropertyDescription),
+		Value:    op.Description}}
+}
+
+func (rule RuleOperationDescriptionExist) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
@@ -0,0 +1,52 @@
+package ruleopidexist
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+type RuleOperationOperationIdExist struct {
+	name string
+}
+
+func NewRule() RuleOperationOperationIdExist {
+	return RuleOperationOperationIdExist{
+		name: lintutil.RuleOpIdExist}
+}
+
+func (rule RuleOperationOperationIdExist) Name() string {
+	return rule.name
+}
+
+func (rule RuleOperationOperationIdExist) Description() string {
+	return "Operations must have an `operationId`."
+}
+
+func (rule RuleOperationOperationIdExist) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RuleOperationOperationIdExist) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil || op == nil {
+		return vios
+	}
+
+	opID := strings.TrimSpace(op.OperationID)
+	if len(opID) > 0 {
+		return vios
+	}
+
+	return []lintutil.PolicyViolation{{
+		RuleName: rule.Name(),
+		Location: urlutil.JoinAbsolute(opPointer, openapi3.PropertyOperationID),
+		Value:    op.OperationID}}
+}
+
+func (rule RuleOperationOperationIdExist) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
@@ -2,8 +2,10 @@ package ruleopidstyle
 
 import (
 	"fmt"
+	"strings"
 
 	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
 	"github.com/grokify/mogo/net/urlutil"
 	"github.com/grokify/mogo/text/stringcase"
 	"github.com/grokify/spectrum/openapi3"
@@ -42,6 +44,10 @@ func (rule RuleOperationOperationIdStyle) Name() string {
 	return rule.name
 }
 
+func (rule RuleOperationOperationIdStyle) Description() string {
+	return fmt.Sprintf("Operation `operationId` values must be %s.", rule.stringCase)
+}
+
 func (rule RuleOperationOperationIdStyle) Scope() string {
 	return lintutil.ScopeOperation
 }
@@ -68,3 +74,34 @@ func (rule RuleOperationOperationIdStyle) ProcessOperation(spec *openapi3.Spec,
 func (rule RuleOperationOperationIdStyle) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
 	return []lintutil.PolicyViolation{}
 }
+
+// Fixes converts operationIds to the rule's string case. OperationIds
+// that cannot be converted are not fixed.
+func (rule RuleOperationOperationIdStyle) Fixes(spec *openapi3.Spec, pointerBase string) []lintutil.Fix {
+	fixes := []lintutil.Fix{}
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil || len(op.OperationID) == 0 {
+			return
+		}
+		if isWantCase, err := stringcase.IsCase(rule.stringCase, op.OperationID); err != nil || isWantCase {
+			return
+		}
+		newOpID, err := lintutil.ToCase(rule.stringCase, op.OperationID)
+		if err != nil || len(newOpID) == 0 {
+			return
+		}
+		if isWantCase, err := stringcase.IsCase(rule.stringCase, newOpID); err != nil || !isWantCase {
+			return
+		}
+		fixes = append(fixes, lintutil.Fix{
+			RuleName: rule.Name(),
+			Type:     lintutil.FixTypeOperationID,
+			Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s/%s/%s",
+				pointerBase, path, strings.ToLower(method), openapi3.PropertyOperationID),
+			Path:     path,
+			Method:   method,
+			OldValue: op.OperationID,
+			NewValue: newOpID})
+	})
+	return fixes
+}
@@ -0,0 +1,229 @@
+package ruleopresponseerrorschema
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+	"github.com/grokify/spectrum/openapi3lint/ruleopresponses"
+)
+
+const (
+	MediaTypeProblemJSON = "application/problem+json"
+	MediaTypeProblemXML  = "application/problem+xml"
+	OptionErrorSchemas   = "errorSchemas"
+)
+
+// RuleOpResponseErrorSchema reports `4xx`, `5xx` and `default` response content that
+// does not use an expected error schema. Content is accepted when it uses the
+// `application/problem+json` or `application/problem+xml` media type, or a `$ref` to
+// one of the configured component schema names. When no names are configured, the
+// component schema most used by error responses in the spec is expected.
+type RuleOpResponseErrorSchema struct {
+	name         string
+	errorSchemas []string
+}
+
+// NewRule returns a rule that expects one of `errorSchemaNames`. It can also be
+// configured with the `errorSchemas` option.
+func NewRule(errorSchemaNames ...string) *RuleOpResponseErrorSchema {
+	rule := &RuleOpResponseErrorSchema{
+		name: lintutil.RulenameOpResponseErrorSchema}
+	rule.setErrorSchemas(errorSchemaNames)
+	return rule
+}
+
+func (rule *RuleOpResponseErrorSchema) setErrorSchemas(errorSchemaNames []string) {
+	rule.errorSchemas = []string{}
+	for _, schemaName := range errorSchemaNames {
+		if schemaName = strings.TrimSpace(schemaName); len(schemaName) > 0 {
+			rule.errorSchemas = append(rule.errorSchemas, schemaName)
+		}
+	}
+	sort.Strings(rule.errorSchemas)
+}
+
+// Configure sets the expected error schema names from the `errorSchemas` option.
+func (rule *RuleOpResponseErrorSchema) Configure(opts map[string]interface{}) error {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	cfg := struct {
+		ErrorSchemas []string `json:"errorSchemas"`
+	}{}
+	if err := json.Unmarshal(bytes, &cfg); err != nil {
+		return err
+	}
+	rule.setErrorSchemas(cfg.ErrorSchemas)
+	return nil
+}
+
+// ErrorSchemas returns the configured error schema names.
+func (rule *RuleOpResponseErrorSchema) ErrorSchemas() []string {
+	return rule.errorSchemas
+}
+
+func (rule *RuleOpResponseErrorSchema) Name() string {
+	return rule.name
+}
+
+func (rule *RuleOpResponseErrorSchema) Description() string {
+	return "`4xx`, `5xx` and `default` response content must use the configured error schema, " +
+		"or the most used error schema when none is configured, or a problem details media type."
+}
+
+func (rule *RuleOpResponseErrorSchema) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule *RuleOpResponseErrorSchema) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return rule.processOperation(rule.expectedSchemas(spec), op, opPointer, path, method)
+}
+
+// ProcessOperationContext is like `ProcessOperation()` with the expected error
+// schemas computed once per validation using `lintutil.SpecCacheValue()`.
+func (rule *RuleOpResponseErrorSchema) ProcessOperationContext(ctx context.Context, spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) ([]lintutil.PolicyViolation, error) {
+	expected, _ := lintutil.SpecCacheValue(ctx, rule.name+"/expectedSchemas", func() interface{} {
+		return rule.expectedSchemas(spec)
+	}).(map[string]int)
+	return rule.processOperation(expected, op, opPointer, path, method), nil
+}
+
+func (rule *RuleOpResponseErrorSchema) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+func (rule *RuleOpResponseErrorSchema) ProcessSpecContext(ctx context.Context, spec *openapi3.Spec, pointerBase string) ([]lintutil.PolicyViolation, error) {
+	return rule.ProcessSpec(spec, pointerBase), nil
+}
+
+// expectedSchemas returns the configured error schema names or, if none are
+// configured, the most used error schemas in `spec`.
+func (rule *RuleOpResponseErrorSchema) expectedSchemas(spec *openapi3.Spec) map[string]int {
+	expected := map[string]int{}
+	errorSchemas := rule.errorSchemas
+	if len(errorSchemas) == 0 {
+		errorSchemas = MostUsedErrorSchemas(spec)
+	}
+	for _, schemaName := range errorSchemas {
+		expected[schemaName]++
+	}
+	return expected
+}
+
+func (rule *RuleOpResponseErrorSchema) processOperation(expected map[string]int, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if op == nil {
+		return vios
+	}
+	om := openapi3.OperationMore{
+		Path:      path,
+		Method:    method,
+		Operation: op}
+	for _, statusCode := range om.ResponseStatusCodes() {
+		if !ruleopresponses.IsErrorStatusCode(statusCode) {
+			continue
+		}
+		respRef := op.Responses[statusCode]
+		if respRef == nil || respRef.Value == nil {
+			continue
+		}
+		mediaTypes := []string{}
+		for mediaType := range respRef.Value.Content {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+		sort.Strings(mediaTypes)
+		for _, mediaType := range mediaTypes {
+			if IsProblemMediaType(mediaType) {
+				continue
+			}
+			schemaName := ""
+			if mt := respRef.Value.Content[mediaType]; mt != nil {
+				schemaName = SchemaRefName(mt.Schema)
+			}
+			if _, ok := expected[schemaName]; ok && len(schemaName) > 0 {
+				continue
+			}
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: urlutil.JoinAbsolute(opPointer, ruleopresponses.PropertyResponses,
+					jsonpointer.PropertyNameEscape(statusCode), "content",
+					jsonpointer.PropertyNameEscape(mediaType), "schema"),
+				Value: schemaName})
+		}
+	}
+	return vios
+}
+
+// IsProblemMediaType returns true for RFC 7807 problem details media types.
+func IsProblemMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if idx := strings.Index(mediaType, ";"); idx >= 0 {
+		mediaType = strings.TrimSpace(mediaType[:idx])
+	}
+	return mediaType == MediaTypeProblemJSON || mediaType == MediaTypeProblemXML
+}
+
+// SchemaRefName returns the component schema name for a `$ref` to
+// `#/components/schemas`, or an empty string otherwise.
+func SchemaRefName(schemaRef *oas3.SchemaRef) string {
+	if schemaRef == nil || len(strings.TrimSpace(schemaRef.Ref)) == 0 {
+		return ""
+	}
+	ptr, err := openapi3.ParseJSONPointer(strings.TrimSpace(schemaRef.Ref))
+	if err != nil {
+		return ""
+	}
+	schemaName, _ := ptr.IsTopSchema()
+	return schemaName
+}
+
+// MostUsedErrorSchemas returns the component schema names used most often by
+// error response content that is not a problem details media type. Multiple
+// names are returned when tied.
+func MostUsedErrorSchemas(spec *openapi3.Spec) []string {
+	counts := map[string]int{}
+	if spec == nil {
+		return []string{}
+	}
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil {
+			return
+		}
+		for statusCode, respRef := range op.Responses {
+			if !ruleopresponses.IsErrorStatusCode(statusCode) ||
+				respRef == nil || respRef.Value == nil {
+				continue
+			}
+			for mediaType, mt := range respRef.Value.Content {
+				if mt == nil || IsProblemMediaType(mediaType) {
+					continue
+				}
+				if schemaName := SchemaRefName(mt.Schema); len(schemaName) > 0 {
+					counts[schemaName]++
+				}
+			}
+		}
+	})
+	max := 0
+	for _, count := range counts {
+		if count > max {
+			max = count
+		}
+	}
+	names := []string{}
+	for schemaName, count := range counts {
+		if count == max {
+			names = append(names, schemaName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,112 @@
+package ruleopresponses
+
+import (
+	"fmt"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const PropertyResponses = "responses"
+
+// RuleOpResponses checks operation response coverage. `operation-response-success-exist`
+// reports operations without a `2xx` response, `operation-response-error-exist` reports
+// operations without a `4xx`, `5xx` or `default` response and `operation-response-no-content`
+// reports `204` and `304` responses that have content.
+type RuleOpResponses struct {
+	name string
+}
+
+func NewRule(ruleName string) (RuleOpResponses, error) {
+	ruleNameCanonical := strings.ToLower(strings.TrimSpace(ruleName))
+	rule := RuleOpResponses{
+		name: ruleNameCanonical}
+	if ruleNameCanonical != lintutil.RulenameOpResponseSuccessExist &&
+		ruleNameCanonical != lintutil.RulenameOpResponseErrorExist &&
+		ruleNameCanonical != lintutil.RulenameOpResponseNoContent {
+		return rule, fmt.Errorf("rule [%s] not supported", ruleName)
+	}
+	return rule, nil
+}
+
+func (rule RuleOpResponses) Name() string {
+	return rule.name
+}
+
+func (rule RuleOpResponses) Description() string {
+	switch rule.name {
+	case lintutil.RulenameOpResponseErrorExist:
+		return "Operations must have a `4xx`, `5xx` or `default` response."
+	case lintutil.RulenameOpResponseNoContent:
+		return "`204` and `304` responses must not have content."
+	}
+	return "Operations must have a `2xx` response."
+}
+
+func (rule RuleOpResponses) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RuleOpResponses) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	if op == nil {
+		return nil
+	}
+	om := openapi3.OperationMore{
+		Path:      path,
+		Method:    method,
+		Operation: op}
+	statusCodes := om.ResponseStatusCodes()
+	respsPointer := urlutil.JoinAbsolute(opPointer, PropertyResponses)
+
+	switch rule.name {
+	case lintutil.RulenameOpResponseSuccessExist:
+		for _, statusCode := range statusCodes {
+			if openapi3.StatusCodeIsClass(statusCode, 2) {
+				return nil
+			}
+		}
+		return []lintutil.PolicyViolation{{
+			RuleName: rule.Name(),
+			Location: respsPointer}}
+	case lintutil.RulenameOpResponseErrorExist:
+		for _, statusCode := range statusCodes {
+			if IsErrorStatusCode(statusCode) {
+				return nil
+			}
+		}
+		return []lintutil.PolicyViolation{{
+			RuleName: rule.Name(),
+			Location: respsPointer}}
+	case lintutil.RulenameOpResponseNoContent:
+		vios := []lintutil.PolicyViolation{}
+		for _, statusCode := range statusCodes {
+			if statusCode != "204" && statusCode != "304" {
+				continue
+			}
+			respRef := op.Responses[statusCode]
+			if respRef == nil || respRef.Value == nil || len(respRef.Value.Content) == 0 {
+				continue
+			}
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: urlutil.JoinAbsolute(respsPointer, statusCode, "content"),
+				Value:    statusCode})
+		}
+		return vios
+	}
+	return nil
+}
+
+func (rule RuleOpResponses) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+// IsErrorStatusCode returns true for `4xx`, `5xx` and `default` response keys.
+func IsErrorStatusCode(statusCode string) bool {
+	return openapi3.StatusCodeIsClass(statusCode, 4) ||
+		openapi3.StatusCodeIsClass(statusCode, 5) ||
+		strings.EqualFold(statusCode, openapi3.StatusCodeDefault)
+}
@@ -22,6 +22,10 @@ func (rule RuleOperationSummaryExist) Name() string {
 	return rule.name
 }
 
+func (rule RuleOperationSummaryExist) Description() string {
+	return "Operations must have a `summary`."
+}
+
 func (rule RuleOperationSummaryExist) Scope() string {
 	return lintutil.ScopeOperation
 }
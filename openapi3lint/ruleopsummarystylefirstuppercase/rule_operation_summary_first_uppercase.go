@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
 	"github.com/grokify/mogo/net/urlutil"
 	"github.com/grokify/mogo/type/stringsutil"
 	"github.com/grokify/spectrum/openapi3"
@@ -23,6 +24,10 @@ func (rule RuleOperationSummaryStyleFirstUpperCase) Name() string {
 	return rule.name
 }
 
+func (rule RuleOperationSummaryStyleFirstUpperCase) Description() string {
+	return "Operation summaries must start with an upper case letter."
+}
+
 func (rule RuleOperationSummaryStyleFirstUpperCase) Scope() string {
 	return lintutil.ScopeOperation
 }
@@ -34,9 +39,6 @@ func (rule RuleOperationSummaryStyleFirstUpperCase) ProcessOperation(spec *opena
 	}
 
 	summary := strings.TrimSpace(op.Summary)
-	if len(summary) > 0 {
-		return vios
-	}
 	if len(summary) == 0 {
 		return vios
 	}
@@ -53,3 +55,27 @@ func (rule RuleOperationSummaryStyleFirstUpperCase) ProcessOperation(spec *opena
 func (rule RuleOperationSummaryStyleFirstUpperCase) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
 	return []lintutil.PolicyViolation{}
 }
+
+// Fixes upper cases the first letter of operation summaries.
+func (rule RuleOperationSummaryStyleFirstUpperCase) Fixes(spec *openapi3.Spec, pointerBase string) []lintutil.Fix {
+	fixes := []lintutil.Fix{}
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil || len(strings.TrimSpace(op.Summary)) == 0 {
+			return
+		}
+		newSummary := lintutil.ToFirstAlphaUpper(op.Summary)
+		if newSummary == op.Summary {
+			return
+		}
+		fixes = append(fixes, lintutil.Fix{
+			RuleName: rule.Name(),
+			Type:     lintutil.FixTypeOperationSummary,
+			Location: jsonpointer.PointerSubEscapeAll("%s#/paths/%s/%s/%s",
+				pointerBase, path, strings.ToLower(method), openapi3.PropertySummary),
+			Path:     path,
+			Method:   method,
+			OldValue: op.Summary,
+			NewValue: newSummary})
+	})
+	return fixes
+}
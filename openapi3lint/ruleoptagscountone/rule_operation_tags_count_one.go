@@ -0,0 +1,48 @@
+package ruleoptagscountone
+
+import (
+	"strconv"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// RuleOperationTagsCountOne reports operations that do not have exactly one tag.
+type RuleOperationTagsCountOne struct {
+	name string
+}
+
+func NewRule() RuleOperationTagsCountOne {
+	return RuleOperationTagsCountOne{
+		name: lintutil.RuleOpTagsCountOneOnly}
+}
+
+func (rule RuleOperationTagsCountOne) Name() string {
+	return rule.name
+}
+
+func (rule RuleOperationTagsCountOne) Description() string {
+	return "Operations must have exactly one tag."
+}
+
+func (rule RuleOperationTagsCountOne) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RuleOperationTagsCountOne) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil || op == nil || len(op.Tags) == 1 {
+		return vios
+	}
+
+	return []lintutil.PolicyViolation{{
+		RuleName: rule.Name(),
+		Location: urlutil.JoinAbsolute(opPointer, openapi3.PropertyTags),
+		Value:    strconv.Itoa(len(op.Tags))}}
+}
+
+func (rule RuleOperationTagsCountOne) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
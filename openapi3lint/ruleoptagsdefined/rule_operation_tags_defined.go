@@ -0,0 +1,60 @@
+package ruleoptagsdefined
+
+import (
+	"strconv"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// RuleOperationTagsDefined reports operation tags that are not defined
+// in the top level `tags` property.
+type RuleOperationTagsDefined struct {
+	name string
+}
+
+func NewRule() RuleOperationTagsDefined {
+	return RuleOperationTagsDefined{
+		name: lintutil.RulenameOpTagsDefined}
+}
+
+func (rule RuleOperationTagsDefined) Name() string {
+	return rule.name
+}
+
+func (rule RuleOperationTagsDefined) Description() string {
+	return "Operation tags must be defined in the top level `tags` property."
+}
+
+func (rule RuleOperationTagsDefined) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RuleOperationTagsDefined) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil || op == nil {
+		return vios
+	}
+	tagNames := map[string]int{}
+	for _, tag := range spec.Tags {
+		if tag != nil {
+			tagNames[strings.TrimSpace(tag.Name)]++
+		}
+	}
+	for i, tagName := range op.Tags {
+		if _, ok := tagNames[strings.TrimSpace(tagName)]; !ok {
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: urlutil.JoinAbsolute(opPointer, openapi3.PropertyTags, strconv.Itoa(i)),
+				Value:    tagName})
+		}
+	}
+	return vios
+}
+
+func (rule RuleOperationTagsDefined) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
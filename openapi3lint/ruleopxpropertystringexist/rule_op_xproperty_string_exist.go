@@ -2,6 +2,7 @@ package ruleopxpropertystringexist
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	oas3 "github.com/getkin/kin-openapi/openapi3"
@@ -42,6 +43,10 @@ func (rule RuleOperationXPropertyStringExist) Name() string {
 	return rule.name
 }
 
+func (rule RuleOperationXPropertyStringExist) Description() string {
+	return fmt.Sprintf("Operations must have a non-empty `%s` string property.", rule.xPropertyName)
+}
+
 func (rule RuleOperationXPropertyStringExist) Scope() string {
 	return lintutil.ScopeSpecification
 }
@@ -0,0 +1,86 @@
+package rulepathparamnameexist
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3edit"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// RulePathParamNameExist reports path template variables, e.g. `{userId}`, which
+// do not have a matching `in: path` parameter in the path item or operation.
+type RulePathParamNameExist struct {
+	name string
+}
+
+func NewRule() RulePathParamNameExist {
+	return RulePathParamNameExist{
+		name: lintutil.RulePathParamNameExist}
+}
+
+func (rule RulePathParamNameExist) Name() string {
+	return rule.name
+}
+
+func (rule RulePathParamNameExist) Description() string {
+	return "Path template variables, such as `{userId}`, must have a matching `in: path` parameter in the path item or operation."
+}
+
+func (rule RulePathParamNameExist) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RulePathParamNameExist) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil || op == nil {
+		return vios
+	}
+	varNames := openapi3edit.ParsePathParametersParens(path)
+	if len(varNames) == 0 {
+		return vios
+	}
+	paramNames := map[string]int{}
+	params := op.Parameters
+	if pathItem, ok := spec.Paths[path]; ok && pathItem != nil {
+		params = append(append(oas3.Parameters{}, pathItem.Parameters...), op.Parameters...)
+	}
+	for _, paramRef := range params {
+		if param := parameterValue(spec, paramRef); param != nil && param.In == oas3.ParameterInPath {
+			paramNames[param.Name]++
+		}
+	}
+	for _, varName := range varNames {
+		if _, ok := paramNames[varName]; !ok {
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: urlutil.JoinAbsolute(opPointer, "parameters"),
+				Value:    varName})
+		}
+	}
+	return vios
+}
+
+// parameterValue returns the parameter, resolving local component references
+// that have not been loaded.
+func parameterValue(spec *openapi3.Spec, paramRef *oas3.ParameterRef) *oas3.Parameter {
+	if paramRef == nil {
+		return nil
+	} else if paramRef.Value != nil {
+		return paramRef.Value
+	}
+	prefix := "#/components/parameters/"
+	if !strings.HasPrefix(paramRef.Ref, prefix) {
+		return nil
+	}
+	if compRef, ok := spec.Components.Parameters[strings.TrimPrefix(paramRef.Ref, prefix)]; ok && compRef != nil {
+		return compRef.Value
+	}
+	return nil
+}
+
+func (rule RulePathParamNameExist) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
@@ -44,8 +44,12 @@ func (rule RulePathParamStyle) Name() string {
 	return rule.name
 }
 
+func (rule RulePathParamStyle) Description() string {
+	return fmt.Sprintf("Path parameter names must be %s.", rule.stringCase)
+}
+
 func (rule RulePathParamStyle) Scope() string {
-	return lintutil.ScopeOperation
+	return lintutil.ScopeSpecification
 }
 
 func (rule RulePathParamStyle) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
@@ -74,7 +78,8 @@ func (rule RulePathParamStyle) ProcessSpec(spec *openapi3.Spec, pointerBase stri
 			} else if !isWantCase {
 				vios = append(vios, lintutil.PolicyViolation{
 					RuleName: rule.Name(),
-					Location: jsPtr})
+					Location: jsPtr,
+					Value:    mi[1]})
 			}
 		}
 	}
@@ -89,7 +94,7 @@ func (rule RulePathParamStyle) ProcessSpec(spec *openapi3.Spec, pointerBase stri
 			path,
 			method)
 		for i, paramRef := range op.Parameters {
-			if paramRef == nil || paramRef.Value == nil {
+			if paramRef == nil || paramRef.Value == nil || paramRef.Value.In != oas3.ParameterInPath {
 				continue
 			}
 			isWantCase, err := stringcase.IsCase(rule.stringCase, paramRef.Value.Name)
@@ -110,3 +115,33 @@ func (rule RulePathParamStyle) ProcessSpec(spec *openapi3.Spec, pointerBase stri
 
 	return vios
 }
+
+// Fixes renames path parameters to the rule's string case. Renames are applied
+// to the path and to path parameters in the path item and operations.
+func (rule RulePathParamStyle) Fixes(spec *openapi3.Spec, pointerBase string) []lintutil.Fix {
+	fixes := []lintutil.Fix{}
+	if spec == nil {
+		return fixes
+	}
+	for pathURL := range spec.Paths {
+		jsPtr := jsonpointer.PointerSubEscapeAll("%s#/paths/%s",
+			pointerBase, pathURL)
+		for _, m := range rxParams.FindAllStringSubmatch(pathURL, -1) {
+			if isWantCase, err := stringcase.IsCase(rule.stringCase, m[1]); err != nil || isWantCase {
+				continue
+			}
+			newName, err := lintutil.ToCase(rule.stringCase, m[1])
+			if err != nil || len(newName) == 0 {
+				continue
+			}
+			fixes = append(fixes, lintutil.Fix{
+				RuleName: rule.Name(),
+				Type:     lintutil.FixTypePathParamRename,
+				Location: jsPtr,
+				Path:     pathURL,
+				OldValue: m[1],
+				NewValue: newName})
+		}
+	}
+	return fixes
+}
@@ -0,0 +1,92 @@
+package ruleschemaduplicate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	OptionIgnoreDescriptions = "ignoreDescriptions"
+	OptionIgnoreExamples     = "ignoreExamples"
+)
+
+// RuleSchemaDuplicate reports component schemas that are structurally equivalent
+// to another component schema. For each group of duplicates, the first schema
+// name is kept and the others are reported with the kept name as the value.
+// Descriptions are ignored by default.
+type RuleSchemaDuplicate struct {
+	name string
+	opts openapi3.SchemaCanonicalOpts
+}
+
+func NewRule() *RuleSchemaDuplicate {
+	return &RuleSchemaDuplicate{
+		name: lintutil.RulenameSchemaDuplicate,
+		opts: openapi3.SchemaCanonicalOpts{IgnoreDescriptions: true}}
+}
+
+// Configure sets the `ignoreDescriptions` and `ignoreExamples` options.
+func (rule *RuleSchemaDuplicate) Configure(opts map[string]interface{}) error {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	cfg := struct {
+		IgnoreDescriptions *bool `json:"ignoreDescriptions"`
+		IgnoreExamples     *bool `json:"ignoreExamples"`
+	}{}
+	if err := json.Unmarshal(bytes, &cfg); err != nil {
+		return err
+	}
+	if cfg.IgnoreDescriptions != nil {
+		rule.opts.IgnoreDescriptions = *cfg.IgnoreDescriptions
+	}
+	if cfg.IgnoreExamples != nil {
+		rule.opts.IgnoreExamples = *cfg.IgnoreExamples
+	}
+	return nil
+}
+
+func (rule *RuleSchemaDuplicate) Name() string {
+	return rule.name
+}
+
+func (rule *RuleSchemaDuplicate) Description() string {
+	return "Component schemas must not be structurally equivalent to another component schema."
+}
+
+func (rule *RuleSchemaDuplicate) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule *RuleSchemaDuplicate) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule *RuleSchemaDuplicate) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	sm := openapi3.SpecMore{Spec: spec}
+	groups, err := sm.SchemaDuplicates(&rule.opts)
+	if err != nil {
+		return vios
+	}
+	for _, group := range groups {
+		for _, schemaName := range group.SchemaNames[1:] {
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: fmt.Sprintf("%s%s/%s", pointerBase, openapi3.PointerComponentsSchemas,
+					jsonpointer.PropertyNameEscape(schemaName)),
+				Value: group.SchemaNames[0],
+				Data:  map[string]string{"hash": group.Hash}})
+		}
+	}
+	return vios
+}
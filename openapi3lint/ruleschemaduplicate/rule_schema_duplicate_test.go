@@ -0,0 +1,51 @@
+package ruleschemaduplicate
+
+import (
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+)
+
+var ruleSchemaDuplicateTests = []struct {
+	opts      map[string]interface{}
+	locations map[string]string
+}{
+	{nil, map[string]string{
+		"spec.yaml#/components/schemas/Location": "Address",
+		"spec.yaml#/components/schemas/Person":   "Customer",
+		"spec.yaml#/components/schemas/TreeNode": "Node"}},
+	{map[string]interface{}{OptionIgnoreDescriptions: false}, map[string]string{
+		"spec.yaml#/components/schemas/TreeNode": "Node"}},
+}
+
+// TestRuleSchemaDuplicate ensures duplicate schemas are reported with the kept
+// schema name, including schemas that differ only in referenced schema names.
+func TestRuleSchemaDuplicate(t *testing.T) {
+	spec, err := openapi3.ReadFile("../testdata/spec_rules_duplicates.yaml", false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	for _, tt := range ruleSchemaDuplicateTests {
+		rule := NewRule()
+		if tt.opts != nil {
+			if err := rule.Configure(tt.opts); err != nil {
+				t.Fatalf("RuleSchemaDuplicate.Configure() Error [%s]", err.Error())
+			}
+		}
+		vios := rule.ProcessSpec(spec, "spec.yaml")
+		got := map[string]string{}
+		for _, vio := range vios {
+			got[vio.Location] = vio.Value
+		}
+		if len(got) != len(tt.locations) {
+			t.Errorf("RuleSchemaDuplicate.ProcessSpec() Mismatch: want [%v], got [%v]", tt.locations, got)
+			continue
+		}
+		for location, keep := range tt.locations {
+			if got[location] != keep {
+				t.Errorf("RuleSchemaDuplicate.ProcessSpec() location [%s] Mismatch: want [%s], got [%s]",
+					location, keep, got[location])
+			}
+		}
+	}
+}
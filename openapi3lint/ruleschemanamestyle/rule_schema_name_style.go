@@ -0,0 +1,76 @@
+package ruleschemanamestyle
+
+import (
+	"fmt"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// RuleSchemaNameStyle reports schema names under `#/components/schemas`
+// which are not in the required string case.
+type RuleSchemaNameStyle struct {
+	name       string
+	stringCase string
+}
+
+func NewRule(requiredStringCase string) (RuleSchemaNameStyle, error) {
+	canonicalCase, err := stringcase.Parse(requiredStringCase)
+	if err != nil {
+		return RuleSchemaNameStyle{},
+			fmt.Errorf("invalid string case [%s]", requiredStringCase)
+	}
+	rule := RuleSchemaNameStyle{
+		stringCase: canonicalCase}
+	switch canonicalCase {
+	case stringcase.CamelCase:
+		rule.name = lintutil.RulenameSchemaNameStyleCamelCase
+	case stringcase.KebabCase:
+		rule.name = lintutil.RulenameSchemaNameStyleKebabCase
+	case stringcase.PascalCase:
+		rule.name = lintutil.RulenameSchemaNameStylePascalCase
+	case stringcase.SnakeCase:
+		rule.name = lintutil.RulenameSchemaNameStyleSnakeCase
+	default:
+		return rule, fmt.Errorf("invalid string case [%s]", canonicalCase)
+	}
+	return rule, nil
+}
+
+func (rule RuleSchemaNameStyle) Name() string {
+	return rule.name
+}
+
+func (rule RuleSchemaNameStyle) Description() string {
+	return fmt.Sprintf("Schema names under `components.schemas` must be %s.", rule.stringCase)
+}
+
+func (rule RuleSchemaNameStyle) Scope() string {
+	return lintutil.ScopeSchema
+}
+
+func (rule RuleSchemaNameStyle) ProcessSchema(spec *openapi3.Spec, schemaRef *oas3.SchemaRef, schemaPointer, schemaName string) []lintutil.PolicyViolation {
+	isWantCase, err := stringcase.IsCase(rule.stringCase, schemaName)
+	if err == nil && isWantCase {
+		return []lintutil.PolicyViolation{}
+	}
+	vio := lintutil.PolicyViolation{
+		RuleName: rule.Name(),
+		Location: schemaPointer,
+		Value:    schemaName}
+	if err != nil {
+		vio.Data = map[string]string{
+			"error": err.Error()}
+	}
+	return []lintutil.PolicyViolation{vio}
+}
+
+func (rule RuleSchemaNameStyle) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+func (rule RuleSchemaNameStyle) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
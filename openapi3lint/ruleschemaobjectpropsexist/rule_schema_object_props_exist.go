@@ -20,6 +20,10 @@ func (rule RuleSchemaObjectPropsExist) Name() string {
 	return rule.name
 }
 
+func (rule RuleSchemaObjectPropsExist) Description() string {
+	return "Object schemas and object schema properties under `components.schemas` must define `properties` or `additionalProperties`."
+}
+
 func (rule RuleSchemaObjectPropsExist) Scope() string {
 	return lintutil.ScopeSpecification
 }
@@ -0,0 +1,56 @@
+package ruleschemapropdescexist
+
+import (
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// RuleSchemaPropDescExist reports schema properties without a description.
+// Properties that are `$ref` references are skipped as the referenced schema
+// provides the description.
+type RuleSchemaPropDescExist struct {
+	name string
+}
+
+func NewRule() RuleSchemaPropDescExist {
+	return RuleSchemaPropDescExist{
+		name: lintutil.RuleSchemaPropDescExist}
+}
+
+func (rule RuleSchemaPropDescExist) Name() string {
+	return rule.name
+}
+
+func (rule RuleSchemaPropDescExist) Description() string {
+	return "Schema properties must have a `description`. Properties that are `$ref` references are skipped."
+}
+
+func (rule RuleSchemaPropDescExist) Scope() string {
+	return lintutil.ScopeSchemaProperty
+}
+
+func (rule RuleSchemaPropDescExist) ProcessSchemaProperty(spec *openapi3.Spec, propRef *oas3.SchemaRef, propPointer, schemaName, propName string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if propRef == nil || len(propRef.Ref) > 0 || propRef.Value == nil {
+		return vios
+	}
+	if len(strings.TrimSpace(propRef.Value.Description)) > 0 {
+		return vios
+	}
+	return []lintutil.PolicyViolation{{
+		RuleName: rule.Name(),
+		Location: urlutil.JoinAbsolute(propPointer, openapi3.PropertyDescription),
+		Value:    propName}}
+}
+
+func (rule RuleSchemaPropDescExist) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+func (rule RuleSchemaPropDescExist) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
@@ -42,6 +42,10 @@ func (rule RuleSchemaPropEnumStyle) Name() string {
 	return rule.name
 }
 
+func (rule RuleSchemaPropEnumStyle) Description() string {
+	return fmt.Sprintf("String enum values of schema properties must be %s.", rule.stringCase)
+}
+
 func (rule RuleSchemaPropEnumStyle) Scope() string {
 	return lintutil.ScopeSpecification
 }
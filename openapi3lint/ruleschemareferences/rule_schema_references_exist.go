@@ -28,6 +28,13 @@ func (rule RuleSchemaReferences) Name() string {
 	return rule.name
 }
 
+func (rule RuleSchemaReferences) Description() string {
+	if rule.name == lintutil.RulenameSchemaReferenceHasSchema {
+		return "Schema `$ref` references must resolve to a schema under `components.schemas`."
+	}
+	return "Schemas under `components.schemas` must be referenced."
+}
+
 func (rule RuleSchemaReferences) Scope() string {
 	return lintutil.ScopeSpecification
 }
@@ -0,0 +1,52 @@
+package ruleschemareferences
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const testSpecSchemaReferences = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {"/users": {"get": {"responses": {"200": {"description": "OK",
+    "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}}}}},
+  "components": {"schemas": {
+    "User": {"type": "object", "properties": {"account": {"$ref": "#/components/schemas/Account"}}},
+    "Unused": {"type": "object"}}}
+}`
+
+var ruleSchemaReferencesTests = []struct {
+	ruleName  string
+	locations string
+}{
+	{lintutil.RulenameSchemaHasReference, "spec.json#/components/schemas/Unused"},
+	{lintutil.RulenameSchemaReferenceHasSchema, "spec.json#/components/schemas/Account"},
+}
+
+// TestRuleSchemaReferences ensures referenced schemas are not reported as
+// unreferenced and missing referenced schemas are reported.
+func TestRuleSchemaReferences(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecSchemaReferences))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	for _, tt := range ruleSchemaReferencesTests {
+		rule, err := NewRule(tt.ruleName)
+		if err != nil {
+			t.Fatalf("NewRule() Error [%s]", err.Error())
+		}
+		locations := []string{}
+		for _, vio := range rule.ProcessSpec(spec, "spec.json") {
+			locations = append(locations, vio.Location)
+		}
+		sort.Strings(locations)
+		if got := strings.Join(locations, ","); got != tt.locations {
+			t.Errorf("RuleSchemaReferences.ProcessSpec() rule [%s] Mismatch: want [%s], got [%s]",
+				tt.ruleName, tt.locations, got)
+		}
+	}
+}
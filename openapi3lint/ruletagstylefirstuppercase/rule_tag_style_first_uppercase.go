@@ -2,6 +2,7 @@ package ruletagstylefirstuppercase
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/grokify/mogo/encoding/jsonpointer"
 	"github.com/grokify/mogo/text/stringcase"
@@ -22,8 +23,12 @@ func (rule RuleTagStyleFirstUpperCase) Name() string {
 	return rule.name
 }
 
+func (rule RuleTagStyleFirstUpperCase) Description() string {
+	return "Tag names must start with an upper case letter."
+}
+
 func (rule RuleTagStyleFirstUpperCase) Scope() string {
-	return lintutil.ScopeOperation
+	return lintutil.ScopeSpecification
 }
 
 func (rule RuleTagStyleFirstUpperCase) ProcessOperation(spec *openapi3.Spec, op *openapi3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
@@ -40,7 +45,7 @@ func (rule RuleTagStyleFirstUpperCase) ProcessSpec(spec *openapi3.Spec, pointerB
 			"%s#/paths/%s/%s/tags/",
 			pointerBase,
 			path,
-			method)
+			strings.ToLower(method))
 		for i, tag := range op.Tags {
 			if !stringcase.IsFirstAlphaUpper(tag) {
 				vios = append(vios, lintutil.PolicyViolation{
@@ -67,3 +72,26 @@ func (rule RuleTagStyleFirstUpperCase) ProcessSpec(spec *openapi3.Spec, pointerB
 	}
 	return vios
 }
+
+// Fixes renames tags so the first letter is upper case. Renames are applied
+// to the top level tags and to operation tags.
+func (rule RuleTagStyleFirstUpperCase) Fixes(spec *openapi3.Spec, pointerBase string) []lintutil.Fix {
+	fixes := []lintutil.Fix{}
+	sm := openapi3.SpecMore{Spec: spec}
+	for _, tagName := range sm.Tags(true, true) {
+		if stringcase.IsFirstAlphaUpper(tagName) {
+			continue
+		}
+		newTagName := lintutil.ToFirstAlphaUpper(tagName)
+		if newTagName == tagName {
+			continue
+		}
+		fixes = append(fixes, lintutil.Fix{
+			RuleName: rule.Name(),
+			Type:     lintutil.FixTypeTagRename,
+			Location: pointerBase + "#/tags",
+			OldValue: tagName,
+			NewValue: newTagName})
+	}
+	return fixes
+}
@@ -0,0 +1,74 @@
+package scorecard
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/grokify/gocharts/v2/data/table"
+)
+
+// MarshalJSONIndent returns the indented JSON encoding of the scorecards.
+func (scs Scorecards) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(scs, prefix, indent)
+}
+
+// Markdown returns a Markdown table with one row per spec.
+func (scs Scorecards) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# API Quality Scorecard\n\n")
+	if len(scs) == 0 {
+		sb.WriteString("No specs scored.\n")
+		return sb.String()
+	}
+	tbl := scs.Table()
+	sb.WriteString("| " + strings.Join(tbl.Columns, " | ") + " |\n")
+	seps := []string{}
+	for i := range tbl.Columns {
+		if i < 3 {
+			seps = append(seps, "---")
+		} else {
+			seps = append(seps, "---:")
+		}
+	}
+	sb.WriteString("|" + strings.Join(seps, "|") + "|\n")
+	for _, row := range tbl.Rows {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+// Table returns a table with one row per spec, including category scores,
+// the overall score and the grade.
+func (scs Scorecards) Table() table.Table {
+	tbl := table.NewTable("Scorecard")
+	tbl.Columns = []string{"Document", "Title", "Version", "Operations"}
+	for _, cat := range Categories() {
+		tbl.Columns = append(tbl.Columns, cat)
+	}
+	tbl.Columns = append(tbl.Columns, "Score", "Grade")
+	for i := 3; i < len(tbl.Columns)-1; i++ {
+		tbl.FormatMap[i] = table.FormatFloat
+	}
+	tbl.FormatMap[3] = table.FormatInt
+	for _, sc := range scs {
+		row := []string{sc.Document, sc.Title, sc.Version, fmt.Sprintf("%d", sc.Operations)}
+		for _, catName := range Categories() {
+			cat, _ := sc.Category(catName)
+			row = append(row, formatScore(cat.Score))
+		}
+		row = append(row, formatScore(sc.Score), sc.Grade)
+		tbl.Rows = append(tbl.Rows, row)
+	}
+	return tbl
+}
+
+// WriteXLSX writes the scorecards to an XLSX file with a single sheet.
+func (scs Scorecards) WriteXLSX(filename string) error {
+	tbl := scs.Table()
+	return table.WriteXLSX(filename, &tbl)
+}
+
+func formatScore(score float64) string {
+	return fmt.Sprintf("%.1f", score)
+}
@@ -0,0 +1,294 @@
+package scorecard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	CategoryLint                       = "lint"
+	CategoryParameterDescriptions      = "parameter-descriptions"
+	CategorySchemaPropertyDescriptions = "schema-property-descriptions"
+	CategoryTags                       = "tags"
+	CategorySchemaReferences           = "schema-references"
+)
+
+// Categories returns the scorecard category names in report order.
+func Categories() []string {
+	return []string{
+		CategoryLint,
+		CategoryParameterDescriptions,
+		CategorySchemaPropertyDescriptions,
+		CategoryTags,
+		CategorySchemaReferences}
+}
+
+// Weights configures a scorecard. `Categories` are the relative weights of
+// category scores in the overall score. A category with a zero weight is
+// reported but not included in the overall score. `Severities` are the penalty
+// points for each lint violation by severity. The lint score is
+// `100 / (1 + penalty points per operation)`.
+type Weights struct {
+	Categories map[string]float64 `json:"categories"`
+	Severities map[string]float64 `json:"severities"`
+}
+
+// DefaultWeights returns weights where lint results count for 40% of the
+// overall score and each coverage category counts for 15%.
+func DefaultWeights() Weights {
+	return Weights{
+		Categories: map[string]float64{
+			CategoryLint:                       40,
+			CategoryParameterDescriptions:      15,
+			CategorySchemaPropertyDescriptions: 15,
+			CategoryTags:                       15,
+			CategorySchemaReferences:           15},
+		Severities: map[string]float64{
+			severity.SeverityEmergency:     2,
+			severity.SeverityAlert:         2,
+			severity.SeverityCritical:      2,
+			severity.SeverityError:         1,
+			severity.SeverityWarning:       0.5,
+			severity.SeverityNotice:        0.25,
+			severity.SeverityInformational: 0,
+			severity.SeverityDebug:         0}}
+}
+
+// ReadWeightsFile reads JSON weights. Categories and severities not in the
+// file use the `DefaultWeights()` values.
+func ReadWeightsFile(filename string) (Weights, error) {
+	w := DefaultWeights()
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return w, err
+	}
+	fileWeights := Weights{}
+	if err := json.Unmarshal(bytes, &fileWeights); err != nil {
+		return w, err
+	}
+	for name, weight := range fileWeights.Categories {
+		w.Categories[strings.ToLower(strings.TrimSpace(name))] = weight
+	}
+	for sev, weight := range fileWeights.Severities {
+		sevCanonical, err := severity.Parse(sev)
+		if err != nil {
+			return w, err
+		}
+		w.Severities[sevCanonical] = weight
+	}
+	return w, w.Validate()
+}
+
+// Validate checks that category names are known, weights are not negative
+// and at least one category has a positive weight.
+func (w Weights) Validate() error {
+	known := map[string]int{}
+	for _, name := range Categories() {
+		known[name]++
+	}
+	total := 0.0
+	for name, weight := range w.Categories {
+		if _, ok := known[name]; !ok {
+			return fmt.Errorf("unknown scorecard category [%s]", name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("scorecard category [%s] has negative weight", name)
+		}
+		total += weight
+	}
+	for sev, weight := range w.Severities {
+		if weight < 0 {
+			return fmt.Errorf("scorecard severity [%s] has negative weight", sev)
+		}
+	}
+	if total <= 0 {
+		return errors.New("scorecard categories have no positive weight")
+	}
+	return nil
+}
+
+// CategoryScore is a score from 0 to 100 for one category.
+type CategoryScore struct {
+	Name   string  `json:"name"`
+	Score  float64 `json:"score"`
+	Weight float64 `json:"weight"`
+	Detail string  `json:"detail"`
+}
+
+// Scorecard is the quality score for one spec.
+type Scorecard struct {
+	Document   string          `json:"document"`
+	Title      string          `json:"title,omitempty"`
+	Version    string          `json:"version,omitempty"`
+	Operations int             `json:"operations"`
+	Violations map[string]uint `json:"violations"`
+	Categories []CategoryScore `json:"categories"`
+	Score      float64         `json:"score"`
+	Grade      string          `json:"grade"`
+}
+
+// Category returns the score for a category name.
+func (sc Scorecard) Category(name string) (CategoryScore, bool) {
+	for _, cat := range sc.Categories {
+		if cat.Name == name {
+			return cat, true
+		}
+	}
+	return CategoryScore{}, false
+}
+
+// NewScorecard returns the scorecard for a spec and its lint violations. `vsets`
+// can be `nil` if the spec has not been linted, in which case the lint category
+// is not included in the overall score.
+func NewScorecard(document string, spec *openapi3.Spec, vsets *lintutil.PolicyViolationsSets, w Weights) (Scorecard, error) {
+	if spec == nil {
+		return Scorecard{}, openapi3.ErrSpecNotSet
+	}
+	sm := openapi3.SpecMore{Spec: spec}
+	sc := Scorecard{
+		Document:   document,
+		Operations: sm.OperationsCount(),
+		Violations: map[string]uint{}}
+	if spec.Info != nil {
+		sc.Title = spec.Info.Title
+		sc.Version = spec.Info.Version
+	}
+
+	penalty := 0.0
+	if vsets != nil {
+		for _, set := range vsets.ByRule {
+			for _, vio := range set.Violations {
+				sev, err := severity.Parse(vio.Severity)
+				if err != nil {
+					sev = severity.SeverityError
+				}
+				sc.Violations[sev]++
+				penalty += w.Severities[sev]
+			}
+		}
+	}
+	sc.addCategory(w, CategoryLint,
+		100/(1+penalty/math.Max(1, float64(sc.Operations))),
+		fmt.Sprintf("%.2f penalty points for %d operations", penalty, sc.Operations))
+	if vsets == nil {
+		sc.Categories[0].Weight = 0
+		sc.Categories[0].Detail = "not linted"
+	}
+
+	with, _, all := sm.OperationParametersDescriptionStatusCounts()
+	sc.addCategory(w, CategoryParameterDescriptions, percent(with, all),
+		fmt.Sprintf("%d of %d parameters have descriptions", with, all))
+
+	with, _, all = sm.SchemaPropertiesDescriptionStatusCounts()
+	sc.addCategory(w, CategorySchemaPropertyDescriptions, percent(with, all),
+		fmt.Sprintf("%d of %d schema properties have descriptions", with, all))
+
+	tagStats := sm.SpecTagStats()
+	tagsDefined := 0
+	for _, tag := range tagStats.TagsOps {
+		if _, ok := tagStats.TagCountsMeta[tag]; ok {
+			tagsDefined++
+		}
+	}
+	sc.addCategory(w, CategoryTags,
+		(percent(tagStats.TagStats.OpsWithTags, tagStats.TagStats.OpsTotal)+
+			percent(tagsDefined, len(tagStats.TagsOps)))/2,
+		fmt.Sprintf("%d of %d operations have tags, %d of %d operation tags are defined",
+			tagStats.TagStats.OpsWithTags, tagStats.TagStats.OpsTotal, tagsDefined, len(tagStats.TagsOps)))
+
+	schemaNoRef, both, refNoSchema, err := sm.SchemaNamesStatus()
+	if err != nil {
+		return sc, err
+	}
+	sc.addCategory(w, CategorySchemaReferences,
+		percent(len(both), len(schemaNoRef)+len(both)+len(refNoSchema)),
+		fmt.Sprintf("%d unreferenced schemas, %d references without schemas", len(schemaNoRef), len(refNoSchema)))
+
+	weighted, total := 0.0, 0.0
+	for _, cat := range sc.Categories {
+		weighted += cat.Score * cat.Weight
+		total += cat.Weight
+	}
+	if total > 0 {
+		sc.Score = round(weighted / total)
+	}
+	sc.Grade = Grade(sc.Score)
+	return sc, nil
+}
+
+func (sc *Scorecard) addCategory(w Weights, name string, score float64, detail string) {
+	sc.Categories = append(sc.Categories, CategoryScore{
+		Name:   name,
+		Score:  round(score),
+		Weight: w.Categories[name],
+		Detail: detail})
+}
+
+// Grade returns a letter grade for a score: `A` for 90 and above, `B` for 80,
+// `C` for 70, `D` for 60 and `F` otherwise.
+func Grade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	}
+	return "F"
+}
+
+// percent returns `part` as a percentage of `all`, or 100 when `all` is 0.
+func percent(part, all int) float64 {
+	if all <= 0 {
+		return 100
+	}
+	return 100 * float64(part) / float64(all)
+}
+
+func round(score float64) float64 {
+	return math.Round(score*10) / 10
+}
+
+// Scorecards is a set of scorecards, one per spec.
+type Scorecards []Scorecard
+
+// NewScorecardsFiles lints and scores each spec file with `pol`. Violations at
+// or above `filterSeverity` are included in the lint score.
+func NewScorecardsFiles(pol *openapi3lint.Policy, filterSeverity string, specfiles []string, w Weights) (Scorecards, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	scs := Scorecards{}
+	for _, file := range specfiles {
+		spec, err := openapi3.ReadFile(file, false)
+		if err != nil {
+			return scs, err
+		}
+		document := lintutil.FileDocument(file)
+		var vsets *lintutil.PolicyViolationsSets
+		if pol != nil {
+			vsets, err = pol.ValidateSpec(spec, document, filterSeverity)
+			if err != nil {
+				return scs, err
+			}
+		}
+		sc, err := NewScorecard(document, spec, vsets, w)
+		if err != nil {
+			return scs, err
+		}
+		scs = append(scs, sc)
+	}
+	return scs, nil
+}
@@ -0,0 +1,88 @@
+package scorecard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const testSpecScorecard = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "tags": [{"name": "Users"}],
+  "paths": {
+    "/users": {"get": {
+      "tags": ["Users"],
+      "parameters": [
+        {"name": "limit", "in": "query", "description": "Max users", "schema": {"type": "integer"}},
+        {"name": "offset", "in": "query", "schema": {"type": "integer"}}],
+      "responses": {"200": {"description": "OK",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}}}},
+    "/accounts": {"get": {
+      "tags": ["Accounts"],
+      "responses": {"200": {"description": "OK"}}}}
+  },
+  "components": {"schemas": {
+    "User": {"type": "object", "properties": {
+      "id": {"type": "string", "description": "User ID"},
+      "name": {"type": "string"}}},
+    "Unused": {"type": "object", "properties": {
+      "id": {"type": "string", "description": "ID"}}}
+  }}
+}`
+
+var scorecardTests = []struct {
+	category string
+	score    float64
+}{
+	{CategoryLint, 50},
+	{CategoryParameterDescriptions, 50},
+	{CategorySchemaPropertyDescriptions, 66.7},
+	{CategoryTags, 75},
+	{CategorySchemaReferences, 50},
+}
+
+// TestNewScorecard ensures category scores, the weighted score and grade are computed.
+func TestNewScorecard(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecScorecard))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	vsets := lintutil.NewPolicyViolationsSets()
+	vsets.AddViolations([]lintutil.PolicyViolation{
+		{RuleName: "operation-summary-exist", Severity: severity.SeverityError},
+		{RuleName: "operation-summary-exist", Severity: severity.SeverityError}})
+
+	sc, err := NewScorecard("spec.json", spec, vsets, DefaultWeights())
+	if err != nil {
+		t.Fatalf("NewScorecard() Error [%s]", err.Error())
+	}
+	for _, tt := range scorecardTests {
+		cat, ok := sc.Category(tt.category)
+		if !ok || cat.Score != tt.score {
+			t.Errorf("Scorecard.Category(\"%s\") Mismatch: want [%v], got [%v]", tt.category, tt.score, cat.Score)
+		}
+	}
+	// (50*40 + 50*15 + 66.7*15 + 75*15 + 50*15) / 100
+	if sc.Score != 56.3 || sc.Grade != "F" {
+		t.Errorf("NewScorecard() Score Mismatch: want [56.3 F], got [%v %s]", sc.Score, sc.Grade)
+	}
+
+	w := DefaultWeights()
+	w.Categories[CategoryLint] = 0
+	sc, err = NewScorecard("spec.json", spec, vsets, w)
+	if err != nil {
+		t.Fatalf("NewScorecard() Error [%s]", err.Error())
+	}
+	if sc.Score != 60.4 || sc.Grade != "D" {
+		t.Errorf("NewScorecard() Weights Mismatch: want [60.4 D], got [%v %s]", sc.Score, sc.Grade)
+	}
+
+	md := Scorecards{sc}.Markdown()
+	if !strings.Contains(md, "| spec.json | Test | 1.0.0 | 2 | 50.0 | 50.0 | 66.7 | 75.0 | 50.0 | 60.4 | D |") {
+		t.Errorf("Scorecards.Markdown() Mismatch: got [%s]", md)
+	}
+}
@@ -0,0 +1,75 @@
+package security
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/security/ruleapikeynotinquery"
+	"github.com/grokify/spectrum/openapi3lint/security/rulebasichttps"
+	"github.com/grokify/spectrum/openapi3lint/security/ruleparamsensitiveformat"
+	"github.com/grokify/spectrum/openapi3lint/security/rulesecurityexist"
+	"github.com/grokify/spectrum/openapi3lint/security/rulesecurityschemedefined"
+	"github.com/grokify/spectrum/openapi3lint/security/rulesecurityscopedefined"
+)
+
+type RuleCollectionSecurity struct {
+	name      string
+	ruleNames map[string]int
+}
+
+func NewRuleCollectionSecurity() RuleCollectionSecurity {
+	rules := RuleCollectionSecurity{
+		name:      "Spectrum OpenAPI 3 Lint Security Rule Collection",
+		ruleNames: map[string]int{}}
+	names := rules.RuleNames()
+	for _, name := range names {
+		rules.ruleNames[name] = 1
+	}
+	return rules
+}
+
+func (sec RuleCollectionSecurity) Name() string {
+	return sec.name
+}
+
+func (sec RuleCollectionSecurity) RuleExists(ruleName string) bool {
+	if _, ok := sec.ruleNames[ruleName]; ok {
+		return true
+	}
+	return false
+}
+
+func (sec RuleCollectionSecurity) RuleNames() []string {
+	rulenames := []string{
+		ruleapikeynotinquery.RuleName,
+		rulebasichttps.RuleName,
+		ruleparamsensitiveformat.RuleName,
+		rulesecurityexist.RuleName,
+		rulesecurityschemedefined.RuleName,
+		rulesecurityscopedefined.RuleName,
+	}
+	sort.Strings(rulenames)
+	return rulenames
+}
+
+func (sec RuleCollectionSecurity) Rule(name string) (openapi3lint.Rule, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case ruleapikeynotinquery.RuleName:
+		return ruleapikeynotinquery.NewRule(), nil
+	case rulebasichttps.RuleName:
+		return rulebasichttps.NewRule(), nil
+	case ruleparamsensitiveformat.RuleName:
+		return ruleparamsensitiveformat.NewRule(), nil
+	case rulesecurityexist.RuleName:
+		return rulesecurityexist.NewRule(), nil
+	case rulesecurityschemedefined.RuleName:
+		return rulesecurityschemedefined.NewRule(), nil
+	case rulesecurityscopedefined.RuleName:
+		return rulesecurityscopedefined.NewRule(), nil
+	}
+
+	return openapi3lint.EmptyRule{}, fmt.Errorf("NewSecurityRule: rule [%s] not found", name)
+}
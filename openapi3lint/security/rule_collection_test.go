@@ -0,0 +1,95 @@
+package security
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint"
+	"github.com/grokify/spectrum/openapi3lint/security/ruleapikeynotinquery"
+	"github.com/grokify/spectrum/openapi3lint/security/rulebasichttps"
+	"github.com/grokify/spectrum/openapi3lint/security/ruleparamsensitiveformat"
+	"github.com/grokify/spectrum/openapi3lint/security/rulesecurityexist"
+	"github.com/grokify/spectrum/openapi3lint/security/rulesecurityschemedefined"
+	"github.com/grokify/spectrum/openapi3lint/security/rulesecurityscopedefined"
+	"golang.org/x/exp/slices"
+)
+
+const testSpecSecurityFile = "testdata/spec_security.yaml"
+
+var ruleCollectionSecurityTests = []struct {
+	ruleName   string
+	violations []string
+}{
+	{ruleapikeynotinquery.RuleName, []string{
+		"spec.yaml#/components/securitySchemes/apiKey/in [api_key]"}},
+	{rulebasichttps.RuleName, []string{
+		"spec.yaml#/paths/~1status/servers/0/url [http://status.example.com]",
+		"spec.yaml#/servers/1/url [http://api.example.com]"}},
+	{ruleparamsensitiveformat.RuleName, []string{
+		"spec.yaml#/components/parameters/ClientSecret/schema/format [clientSecret]",
+		"spec.yaml#/paths/~1users/get/parameters/1/schema/format [access_token]"}},
+	{rulesecurityexist.RuleName, []string{
+		"spec.yaml#/paths/~1status/get/security []",
+		"spec.yaml#/paths/~1users~1{userId}/get/security []"}},
+	{rulesecurityschemedefined.RuleName, []string{
+		"spec.yaml#/paths/~1users~1{userId}/delete/security/1/missingScheme [missingScheme]"}},
+	{rulesecurityscopedefined.RuleName, []string{
+		"spec.yaml#/paths/~1users/post/security [oauth.users:admin]"}},
+}
+
+// TestRuleCollectionSecurity ensures security rules report the expected
+// locations and values for the fixture spec.
+func TestRuleCollectionSecurity(t *testing.T) {
+	spec, err := openapi3.ReadFile(testSpecSecurityFile, false)
+	if err != nil {
+		t.Fatalf("openapi3.ReadFile() Error [%s]", err.Error())
+	}
+	secRules := NewRuleCollectionSecurity()
+	for _, tt := range ruleCollectionSecurityTests {
+		if !secRules.RuleExists(tt.ruleName) {
+			t.Errorf("RuleCollectionSecurity.RuleExists() Mismatch: rule [%s] not found", tt.ruleName)
+			continue
+		}
+		rule, err := secRules.Rule(tt.ruleName)
+		if err != nil {
+			t.Fatalf("RuleCollectionSecurity.Rule() Error [%s]", err.Error())
+		}
+		pol := openapi3lint.NewPolicy()
+		if err := pol.AddRule(rule, severity.SeverityError, true); err != nil {
+			t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+		}
+		vsets, err := pol.ValidateSpec(spec, "spec.yaml", severity.SeverityError)
+		if err != nil {
+			t.Fatalf("Policy.ValidateSpec() rule [%s] Error [%s]", tt.ruleName, err.Error())
+		}
+		got := []string{}
+		for _, vio := range vsets.ByRule[tt.ruleName].Violations {
+			got = append(got, vio.Location+" ["+vio.Value+"]")
+		}
+		sort.Strings(got)
+		if !slices.Equal(got, tt.violations) {
+			t.Errorf("Policy.ValidateSpec() rule [%s] Mismatch: want [%v], got [%v]",
+				tt.ruleName, tt.violations, got)
+		}
+	}
+}
+
+// TestPolicyConfigSecurity ensures the security collection can be enabled
+// through a `PolicyConfig`.
+func TestPolicyConfigSecurity(t *testing.T) {
+	cfg := openapi3lint.PolicyConfig{
+		Rules: map[string]openapi3lint.RuleConfig{
+			rulesecurityexist.RuleName: {Severity: severity.SeverityWarning}}}
+	cfg.AddRuleCollection(NewRuleCollectionSecurity())
+	pol, err := cfg.Policy()
+	if err != nil {
+		t.Fatalf("PolicyConfig.Policy() Error [%s]", err.Error())
+	}
+	ruleNames := pol.RuleNames()
+	if !slices.Equal(ruleNames, []string{rulesecurityexist.RuleName}) {
+		t.Errorf("Policy.RuleNames() Mismatch: want [%v], got [%v]",
+			[]string{rulesecurityexist.RuleName}, ruleNames)
+	}
+}
@@ -0,0 +1,68 @@
+package ruleapikeynotinquery
+
+import (
+	"sort"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "security-apikey-not-in-query"
+)
+
+// RuleAPIKeyNotInQuery reports `apiKey` security schemes passed in query
+// parameters, which are commonly recorded in server and proxy logs.
+type RuleAPIKeyNotInQuery struct {
+	name string
+}
+
+func NewRule() RuleAPIKeyNotInQuery {
+	return RuleAPIKeyNotInQuery{
+		name: RuleName}
+}
+
+func (rule RuleAPIKeyNotInQuery) Name() string {
+	return rule.name
+}
+
+func (rule RuleAPIKeyNotInQuery) Description() string {
+	return "`apiKey` security schemes must not be passed in query parameters, which are commonly logged."
+}
+
+func (rule RuleAPIKeyNotInQuery) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RuleAPIKeyNotInQuery) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RuleAPIKeyNotInQuery) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	schemeNames := []string{}
+	for schemeName := range spec.Components.SecuritySchemes {
+		schemeNames = append(schemeNames, schemeName)
+	}
+	sort.Strings(schemeNames)
+	for _, schemeName := range schemeNames {
+		schemeRef := spec.Components.SecuritySchemes[schemeName]
+		if schemeRef == nil || len(schemeRef.Ref) > 0 || schemeRef.Value == nil {
+			continue
+		}
+		if schemeRef.Value.Type == openapi3.SecuritySchemeTypeAPIKey &&
+			schemeRef.Value.In == openapi3.InQuery {
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: jsonpointer.PointerSubEscapeAll(
+					"%s#/components/securitySchemes/%s/in", pointerBase, schemeName),
+				Value: schemeRef.Value.Name})
+		}
+	}
+	return vios
+}
@@ -0,0 +1,103 @@
+package rulebasichttps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "security-http-basic-https"
+)
+
+// RuleBasicHTTPS reports `servers` URLs that do not use HTTPS when the spec
+// defines an `http` security scheme using `basic` authentication, as the
+// credentials would be sent in clear text. Top level, path item and operation
+// `servers` are checked. Relative and templated URLs are not reported.
+type RuleBasicHTTPS struct {
+	name string
+}
+
+func NewRule() RuleBasicHTTPS {
+	return RuleBasicHTTPS{
+		name: RuleName}
+}
+
+func (rule RuleBasicHTTPS) Name() string {
+	return rule.name
+}
+
+func (rule RuleBasicHTTPS) Description() string {
+	return "Server URLs must use HTTPS when an `http` security scheme uses `basic` authentication."
+}
+
+func (rule RuleBasicHTTPS) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RuleBasicHTTPS) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RuleBasicHTTPS) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil || !HasHTTPBasic(spec) {
+		return vios
+	}
+	check := func(servers oas3.Servers, serversPointer string) {
+		for i, server := range servers {
+			if server == nil || !IsInsecureURL(server.URL) {
+				continue
+			}
+			vios = append(vios, lintutil.PolicyViolation{
+				RuleName: rule.Name(),
+				Location: fmt.Sprintf("%s/%d/url", serversPointer, i),
+				Value:    server.URL})
+		}
+	}
+	check(spec.Servers, pointerBase+"#/servers")
+	paths := []string{}
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if pathItem := spec.Paths[path]; pathItem != nil {
+			check(pathItem.Servers, jsonpointer.PointerSubEscapeAll("%s#/paths/%s/servers", pointerBase, path))
+		}
+	}
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil || op.Servers == nil {
+			return
+		}
+		check(*op.Servers, jsonpointer.PointerSubEscapeAll(
+			"%s#/paths/%s/%s/servers", pointerBase, path, strings.ToLower(method)))
+	})
+	return vios
+}
+
+// HasHTTPBasic returns true if the spec defines an `http` security scheme with
+// the `basic` authentication scheme.
+func HasHTTPBasic(spec *openapi3.Spec) bool {
+	for _, schemeRef := range spec.Components.SecuritySchemes {
+		if schemeRef == nil || schemeRef.Value == nil {
+			continue
+		}
+		if schemeRef.Value.Type == openapi3.SecuritySchemeTypeHTTP &&
+			strings.EqualFold(strings.TrimSpace(schemeRef.Value.Scheme), openapi3.SecuritySchemeHTTPBasic) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInsecureURL returns true if the URL uses the `http` or `ws` scheme.
+func IsInsecureURL(u string) bool {
+	u = strings.ToLower(strings.TrimSpace(u))
+	return strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "ws://")
+}
@@ -0,0 +1,78 @@
+package ruleparamsensitiveformat
+
+import (
+	"regexp"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "security-parameter-sensitive-format-password"
+)
+
+var (
+	rxSensitive    = regexp.MustCompile(`(?i)(password|passwd|secret|token)`)
+	rxNotSensitive = regexp.MustCompile(`(?i)(page|next|continuation|cursor|sync)[-_]?token`)
+)
+
+// RuleParamSensitiveFormat reports parameters with names that look sensitive,
+// such as those containing `password`, `secret` or `token`, whose schema does not
+// have `format: password` so that tooling can mask the value. Pagination tokens
+// such as `pageToken` and `nextToken` are not reported.
+type RuleParamSensitiveFormat struct {
+	name string
+}
+
+func NewRule() RuleParamSensitiveFormat {
+	return RuleParamSensitiveFormat{
+		name: RuleName}
+}
+
+func (rule RuleParamSensitiveFormat) Name() string {
+	return rule.name
+}
+
+func (rule RuleParamSensitiveFormat) Description() string {
+	return "Parameters with sensitive names, such as `password`, `secret` or `token`, must use `format: password`."
+}
+
+func (rule RuleParamSensitiveFormat) Scope() string {
+	return lintutil.ScopeParameter
+}
+
+func (rule RuleParamSensitiveFormat) ProcessParameter(spec *openapi3.Spec, paramRef *oas3.ParameterRef, paramPointer string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if paramRef == nil || len(paramRef.Ref) > 0 || paramRef.Value == nil {
+		return vios
+	}
+	param := paramRef.Value
+	if !IsSensitiveName(param.Name) {
+		return vios
+	}
+	if param.Schema != nil && param.Schema.Value != nil &&
+		param.Schema.Value.Format == openapi3.FormatPassword {
+		return vios
+	}
+	return []lintutil.PolicyViolation{{
+		RuleName: rule.Name(),
+		Location: urlutil.JoinAbsolute(paramPointer, "schema/format"),
+		Value:    param.Name}}
+}
+
+func (rule RuleParamSensitiveFormat) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+func (rule RuleParamSensitiveFormat) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
+
+// IsSensitiveName returns true if a parameter name looks like it holds a credential.
+func IsSensitiveName(name string) bool {
+	name = strings.TrimSpace(name)
+	return rxSensitive.MatchString(name) && !rxNotSensitive.MatchString(name)
+}
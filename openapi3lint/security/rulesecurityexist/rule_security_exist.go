@@ -0,0 +1,63 @@
+package rulesecurityexist
+
+import (
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/net/urlutil"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName         = "security-requirement-exist"
+	PropertySecurity = "security"
+)
+
+// RuleSecurityExist reports operations without an effective security requirement.
+// The operation `security` overrides the top level `security`. A requirement list
+// that is empty or includes an empty requirement (`{}`) allows anonymous access.
+type RuleSecurityExist struct {
+	name string
+}
+
+func NewRule() RuleSecurityExist {
+	return RuleSecurityExist{
+		name: RuleName}
+}
+
+func (rule RuleSecurityExist) Name() string {
+	return rule.name
+}
+
+func (rule RuleSecurityExist) Description() string {
+	return "Operations must have a security requirement that does not allow anonymous access, either on the operation or at the top level."
+}
+
+func (rule RuleSecurityExist) Scope() string {
+	return lintutil.ScopeOperation
+}
+
+func (rule RuleSecurityExist) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	if spec == nil || op == nil {
+		return nil
+	}
+	secReqs := spec.Security
+	if op.Security != nil {
+		secReqs = *op.Security
+	}
+	anonymous := len(secReqs) == 0
+	for _, secReq := range openapi3.SecurityRequirementsToRaw(secReqs) {
+		if len(secReq) == 0 {
+			anonymous = true
+		}
+	}
+	if !anonymous {
+		return nil
+	}
+	return []lintutil.PolicyViolation{{
+		RuleName: rule.Name(),
+		Location: urlutil.JoinAbsolute(opPointer, PropertySecurity)}}
+}
+
+func (rule RuleSecurityExist) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	return []lintutil.PolicyViolation{}
+}
@@ -0,0 +1,77 @@
+package rulesecurityschemedefined
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "security-scheme-defined"
+)
+
+// RuleSecuritySchemeDefined reports top level and operation security requirements
+// which reference schemes not defined in `components.securitySchemes`.
+type RuleSecuritySchemeDefined struct {
+	name string
+}
+
+func NewRule() RuleSecuritySchemeDefined {
+	return RuleSecuritySchemeDefined{
+		name: RuleName}
+}
+
+func (rule RuleSecuritySchemeDefined) Name() string {
+	return rule.name
+}
+
+func (rule RuleSecuritySchemeDefined) Description() string {
+	return "Security requirements must reference schemes defined in `components.securitySchemes`."
+}
+
+func (rule RuleSecuritySchemeDefined) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RuleSecuritySchemeDefined) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RuleSecuritySchemeDefined) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	check := func(secReqs oas3.SecurityRequirements, secPointer string) {
+		for i, secReq := range openapi3.SecurityRequirementsToRaw(secReqs) {
+			schemeNames := []string{}
+			for schemeName := range secReq {
+				schemeNames = append(schemeNames, schemeName)
+			}
+			sort.Strings(schemeNames)
+			for _, schemeName := range schemeNames {
+				if _, ok := spec.Components.SecuritySchemes[schemeName]; ok {
+					continue
+				}
+				vios = append(vios, lintutil.PolicyViolation{
+					RuleName: rule.Name(),
+					Location: fmt.Sprintf("%s/%d/%s", secPointer, i, jsonpointer.PropertyNameEscape(schemeName)),
+					Value:    schemeName})
+			}
+		}
+	}
+	check(spec.Security, pointerBase+"#/security")
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil || op.Security == nil {
+			return
+		}
+		check(*op.Security, jsonpointer.PointerSubEscapeAll(
+			"%s#/paths/%s/%s/security", pointerBase, path, strings.ToLower(method)))
+	})
+	return vios
+}
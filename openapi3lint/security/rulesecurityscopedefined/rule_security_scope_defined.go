@@ -0,0 +1,130 @@
+package rulesecurityscopedefined
+
+import (
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+const (
+	RuleName = "security-oauth2-scope-defined"
+)
+
+// RuleSecurityScopeDefined reports OAuth 2.0 scopes used in top level and operation
+// security requirements which are not declared in any flow of the referenced
+// `oauth2` security scheme. Undefined schemes are reported by `security-scheme-defined`.
+type RuleSecurityScopeDefined struct {
+	name string
+}
+
+func NewRule() RuleSecurityScopeDefined {
+	return RuleSecurityScopeDefined{
+		name: RuleName}
+}
+
+func (rule RuleSecurityScopeDefined) Name() string {
+	return rule.name
+}
+
+func (rule RuleSecurityScopeDefined) Description() string {
+	return "OAuth 2.0 scopes used in security requirements must be declared in a flow of the referenced `oauth2` security scheme."
+}
+
+func (rule RuleSecurityScopeDefined) Scope() string {
+	return lintutil.ScopeSpecification
+}
+
+func (rule RuleSecurityScopeDefined) ProcessOperation(spec *openapi3.Spec, op *oas3.Operation, opPointer, path, method string) []lintutil.PolicyViolation {
+	return nil
+}
+
+func (rule RuleSecurityScopeDefined) ProcessSpec(spec *openapi3.Spec, pointerBase string) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	if spec == nil {
+		return vios
+	}
+	oauth2Schemes, declared := declaredScopes(spec)
+
+	topScopes := []string{}
+	for _, secReq := range openapi3.SecurityRequirementsToRaw(spec.Security) {
+		for schemeName, scopes := range secReq {
+			schemeName = strings.TrimSpace(schemeName)
+			for _, scope := range scopes {
+				if scope = strings.TrimSpace(scope); len(scope) > 0 {
+					topScopes = append(topScopes, schemeName+"."+scope)
+				}
+			}
+		}
+	}
+	sort.Strings(topScopes)
+	vios = append(vios, rule.checkScopes(topScopes, pointerBase+"#/security", oauth2Schemes, declared)...)
+
+	openapi3.VisitOperations(spec, func(path, method string, op *oas3.Operation) {
+		if op == nil || op.Security == nil {
+			return
+		}
+		om := openapi3.OperationMore{
+			Path:      path,
+			Method:    method,
+			Operation: op}
+		vios = append(vios, rule.checkScopes(
+			om.SecurityScopes(true),
+			jsonpointer.PointerSubEscapeAll("%s#/paths/%s/%s/security", pointerBase, path, strings.ToLower(method)),
+			oauth2Schemes, declared)...)
+	})
+	return vios
+}
+
+func (rule RuleSecurityScopeDefined) checkScopes(scopes []string, secPointer string, oauth2Schemes, declared map[string]int) []lintutil.PolicyViolation {
+	vios := []lintutil.PolicyViolation{}
+	for _, scope := range scopes {
+		if _, ok := declared[scope]; ok {
+			continue
+		}
+		schemeName := scope
+		if idx := strings.Index(scope, "."); idx >= 0 {
+			schemeName = scope[:idx]
+		}
+		if _, ok := oauth2Schemes[schemeName]; !ok {
+			continue
+		}
+		vios = append(vios, lintutil.PolicyViolation{
+			RuleName: rule.Name(),
+			Location: secPointer,
+			Value:    scope})
+	}
+	return vios
+}
+
+// declaredScopes returns the names of `oauth2` security schemes and the fully
+// qualified `scheme.scope` names declared across all of their flows.
+func declaredScopes(spec *openapi3.Spec) (map[string]int, map[string]int) {
+	oauth2Schemes := map[string]int{}
+	declared := map[string]int{}
+	for schemeName, schemeRef := range spec.Components.SecuritySchemes {
+		if schemeRef == nil || schemeRef.Value == nil ||
+			schemeRef.Value.Type != openapi3.SecuritySchemeTypeOAuth2 {
+			continue
+		}
+		schemeName = strings.TrimSpace(schemeName)
+		oauth2Schemes[schemeName]++
+		flows := schemeRef.Value.Flows
+		if flows == nil {
+			continue
+		}
+		for _, flow := range []*oas3.OAuthFlow{
+			flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+			if flow == nil {
+				continue
+			}
+			for scope := range flow.Scopes {
+				declared[schemeName+"."+strings.TrimSpace(scope)]++
+			}
+		}
+	}
+	return oauth2Schemes, declared
+}
@@ -0,0 +1,178 @@
+package openapi3lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	oas3 "github.com/getkin/kin-openapi/openapi3"
+	"github.com/grokify/mogo/encoding/jsonpointer"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/lintutil"
+)
+
+// SuppressionReasonDefault is used when an `x-lint-ignore` entry has no reason.
+const SuppressionReasonDefault = "x-lint-ignore"
+
+// Suppression is a set of rules ignored at and below a JSON pointer. An empty
+// `Pointer` applies to the entire document.
+type Suppression struct {
+	Pointer string
+	Rules   map[string]string // rule name to reason
+}
+
+// Match returns the reason if the rule is suppressed for the JSON pointer.
+// Operation methods in the pointer are matched case-insensitively.
+func (sup Suppression) Match(ruleName, pointer string) (string, bool) {
+	reason, ok := sup.Rules[ruleName]
+	if !ok {
+		return "", false
+	}
+	pointer = normalizePointer(pointer)
+	if len(sup.Pointer) == 0 || pointer == sup.Pointer ||
+		strings.HasPrefix(pointer, sup.Pointer+"/") {
+		return reason, true
+	}
+	return "", false
+}
+
+// normalizePointer lowercases the operation method in `/paths/{path}/{method}`
+// pointers as some rules build locations with uppercase methods.
+func normalizePointer(pointer string) string {
+	parts := strings.SplitN(pointer, "/", 5)
+	if len(parts) < 4 || parts[0] != "" || parts[1] != "paths" {
+		return pointer
+	}
+	switch strings.ToUpper(parts[3]) {
+	case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace:
+		parts[3] = strings.ToLower(parts[3])
+	}
+	return strings.Join(parts, "/")
+}
+
+// Suppressions is a set of `Suppression` items for a spec.
+type Suppressions []Suppression
+
+// Reason returns the suppression reason for a violation, or an empty
+// string if the violation is not suppressed.
+func (sups Suppressions) Reason(vio lintutil.PolicyViolation) string {
+	_, pointer := vio.LocationParts()
+	for _, sup := range sups {
+		if reason, ok := sup.Match(vio.RuleName, pointer); ok {
+			return reason
+		}
+	}
+	return ""
+}
+
+type lintIgnoreItem struct {
+	Rule   string   `json:"rule"`
+	Rules  []string `json:"rules"`
+	Reason string   `json:"reason"`
+}
+
+// ParseLintIgnore parses an `x-lint-ignore` extension value and returns a map
+// of rule names to reasons. Supported formats are a rule name, a list of rule
+// names, an object with `rules` and an optional `reason`, or a list of objects
+// with `rule` and an optional `reason`.
+func ParseLintIgnore(xprops oas3.ExtensionProps) (map[string]string, error) {
+	rules := map[string]string{}
+	var raw json.RawMessage
+	ok, err := openapi3.GetExtensionPropUnmarshal(xprops, openapi3.XLintIgnore, &raw)
+	if err != nil || !ok {
+		return rules, err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		items = []json.RawMessage{raw}
+	}
+	for _, itemRaw := range items {
+		var ruleName string
+		if err := json.Unmarshal(itemRaw, &ruleName); err == nil {
+			addLintIgnoreRule(rules, ruleName, "")
+			continue
+		}
+		var item lintIgnoreItem
+		if err := json.Unmarshal(itemRaw, &item); err != nil {
+			return rules, fmt.Errorf("invalid `%s` value [%s]", openapi3.XLintIgnore, string(itemRaw))
+		}
+		addLintIgnoreRule(rules, item.Rule, item.Reason)
+		for _, ruleName := range item.Rules {
+			addLintIgnoreRule(rules, ruleName, item.Reason)
+		}
+	}
+	return rules, nil
+}
+
+func addLintIgnoreRule(rules map[string]string, ruleName, reason string) {
+	ruleName = strings.TrimSpace(ruleName)
+	if len(ruleName) == 0 {
+		return
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) == 0 {
+		reason = SuppressionReasonDefault
+	}
+	rules[ruleName] = reason
+}
+
+// SpecSuppressions returns the `x-lint-ignore` suppressions on the document, path
+// items, operations, component schemas and schema properties.
+func SpecSuppressions(spec *openapi3.Spec) (Suppressions, error) {
+	sups := Suppressions{}
+	if spec == nil {
+		return sups, nil
+	}
+	errs := []string{}
+	add := func(pointer string, xprops oas3.ExtensionProps) {
+		rules, err := ParseLintIgnore(xprops)
+		if err != nil {
+			errs = append(errs, "#"+pointer+" "+err.Error())
+		} else if len(rules) > 0 {
+			sups = append(sups, Suppression{Pointer: pointer, Rules: rules})
+		}
+	}
+
+	add("", spec.ExtensionProps)
+	for path, pathItem := range spec.Paths {
+		if pathItem == nil {
+			continue
+		}
+		pathPointer := jsonpointer.PointerSubEscapeAll("/paths/%s", path)
+		add(pathPointer, pathItem.ExtensionProps)
+		openapi3.VisitOperationsPathItem(path, pathItem, func(path, method string, op *oas3.Operation) {
+			if op != nil {
+				add(pathPointer+"/"+strings.ToLower(method), op.ExtensionProps)
+			}
+		})
+	}
+	openapi3.VisitSchemas(spec, func(schemaPointer, schemaName string, schemaRef *oas3.SchemaRef) {
+		addSchemaSuppressions(strings.TrimPrefix(schemaPointer, "#"), schemaRef, add, 0)
+	})
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return sups, fmt.Errorf("invalid suppressions [%s]", strings.Join(errs, ", "))
+	}
+	sort.Slice(sups, func(i, j int) bool { return sups[i].Pointer < sups[j].Pointer })
+	return sups, nil
+}
+
+const suppressionsSchemaDepthMax = 32
+
+// addSchemaSuppressions adds suppressions for a schema and its nested properties.
+// `$ref` schemas are skipped as they are visited at their definition.
+func addSchemaSuppressions(pointer string, schemaRef *oas3.SchemaRef, add func(string, oas3.ExtensionProps), depth int) {
+	if schemaRef == nil || len(schemaRef.Ref) > 0 || schemaRef.Value == nil || depth > suppressionsSchemaDepthMax {
+		return
+	}
+	add(pointer, schemaRef.Value.ExtensionProps)
+	for propName, propRef := range schemaRef.Value.Properties {
+		addSchemaSuppressions(
+			pointer+"/properties/"+jsonpointer.PropertyNameEscape(propName),
+			propRef, add, depth+1)
+	}
+}
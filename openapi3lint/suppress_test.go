@@ -0,0 +1,131 @@
+package openapi3lint
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/grokify/mogo/log/severity"
+	"github.com/grokify/mogo/text/stringcase"
+	"github.com/grokify/spectrum/openapi3"
+	"github.com/grokify/spectrum/openapi3lint/ruleopidstyle"
+	"github.com/grokify/spectrum/openapi3lint/ruleopsummaryexist"
+	"github.com/grokify/spectrum/openapi3lint/rulepathparamstyle"
+	"golang.org/x/exp/slices"
+)
+
+const testSpecSuppress = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "x-lint-ignore": {"rules": ["operation-summary-exist"], "reason": "summaries are generated"},
+  "paths": {
+    "/users": {
+      "x-lint-ignore": "operation-operationid-style-camelcase",
+      "get": {
+        "operationId": "ListUsers",
+        "responses": {"200": {"description": "OK"}}
+      }
+    },
+    "/accounts": {
+      "get": {
+        "operationId": "ListAccounts",
+        "x-lint-ignore": [{"rule": "operation-operationid-style-camelcase", "reason": "legacy"}],
+        "responses": {"200": {"description": "OK"}}
+      },
+      "post": {
+        "operationId": "CreateAccount",
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+// TestSuppressions ensures `x-lint-ignore` suppressions at the document, path
+// item and operation levels move violations to the suppressed sets.
+func TestSuppressions(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecSuppress))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	pol := NewPolicy()
+	opIDRule, err := ruleopidstyle.NewRule(stringcase.CamelCase)
+	if err != nil {
+		t.Fatalf("ruleopidstyle.NewRule() Error [%s]", err.Error())
+	}
+	for _, rule := range []Rule{opIDRule, ruleopsummaryexist.NewRule()} {
+		if err := pol.AddRule(rule, severity.SeverityError, true); err != nil {
+			t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+		}
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+
+	if count := vsets.Count(); count != 1 {
+		t.Errorf("Policy.ValidateSpec() Count Mismatch: want [%d], got [%d]", 1, count)
+	}
+	vset := vsets.ByRule[opIDRule.Name()]
+	if got := vset.Locations().Locations; !slices.Equal(got, []string{"spec.json#/paths/~1accounts/post/operationId"}) {
+		t.Errorf("Policy.ValidateSpec() unsuppressed Mismatch: got [%v]", got)
+	}
+	if count := vsets.SuppressedCount(); count != 5 {
+		t.Errorf("Policy.ValidateSpec() SuppressedCount Mismatch: want [%d], got [%d]", 5, count)
+	}
+	reasons := []string{}
+	for _, vio := range vsets.Suppressed[opIDRule.Name()].Violations {
+		reasons = append(reasons, vio.SuppressionReason)
+	}
+	sort.Strings(reasons)
+	if !slices.Equal(reasons, []string{"legacy", SuppressionReasonDefault}) {
+		t.Errorf("Policy.ValidateSpec() suppression reasons Mismatch: got [%v]", reasons)
+	}
+}
+
+const testSpecSuppressPathParam = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {
+    "/users/{userId}": {
+      "get": {
+        "x-lint-ignore": "path-param-style-camelcase",
+        "parameters": [
+          {"name": "user_id", "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {"200": {"description": "OK"}}
+      },
+      "delete": {
+        "parameters": [
+          {"name": "user_id", "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {"204": {"description": "No Content"}}
+      }
+    }
+  }
+}`
+
+// TestSuppressionsMethodCase ensures operation suppressions match violations
+// with uppercase methods in their locations.
+func TestSuppressionsMethodCase(t *testing.T) {
+	spec, err := openapi3.Parse([]byte(testSpecSuppressPathParam))
+	if err != nil {
+		t.Fatalf("openapi3.Parse() Error [%s]", err.Error())
+	}
+	pol := NewPolicy()
+	rule, err := rulepathparamstyle.NewRule(stringcase.CamelCase)
+	if err != nil {
+		t.Fatalf("rulepathparamstyle.NewRule() Error [%s]", err.Error())
+	}
+	if err := pol.AddRule(rule, severity.SeverityError, true); err != nil {
+		t.Fatalf("Policy.AddRule() Error [%s]", err.Error())
+	}
+	vsets, err := pol.ValidateSpec(spec, "spec.json", severity.SeverityError)
+	if err != nil {
+		t.Fatalf("Policy.ValidateSpec() Error [%s]", err.Error())
+	}
+	if count := vsets.Count(); count != 1 {
+		t.Errorf("Policy.ValidateSpec() Count Mismatch: want [%d], got [%d]", 1, count)
+	}
+	if count := vsets.SuppressedCount(); count != 1 {
+		t.Errorf("Policy.ValidateSpec() SuppressedCount Mismatch: want [%d], got [%d]", 1, count)
+	}
+}